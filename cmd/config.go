@@ -0,0 +1,207 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// redacted replaces the values of secrets in dumped configurations.
+const redacted = "REDACTED"
+
+// sensitiveField matches the names of fields whose values are credentials.
+var sensitiveField = regexp.MustCompile(`(?i)(password|passwd|secret|token|api[-_]?key|credential|private[-_]?key|authorization)`)
+
+type configDumpOptions struct {
+	toolsFileOptions
+	format string
+}
+
+func newConfigCommand() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect tool configuration",
+		Args:  cobra.NoArgs,
+	}
+	configCmd.AddCommand(newConfigDumpCommand())
+	return configCmd
+}
+
+func newConfigDumpCommand() *cobra.Command {
+	opts := &configDumpOptions{}
+	dumpCmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Print the merged tool configuration, with secrets redacted",
+		Long: "Print the merged tool configuration, with secrets redacted.\n\n" +
+			"Loads the tool files and prebuilt configurations the same way as the server, " +
+			"including includes and overrides, and prints the resulting resources with " +
+			"environment variables replaced. Values read from secret references, " +
+			"fields that hold credentials, and passwords in URLs are redacted. No source is connected to.",
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			err := runConfigDump(c, opts)
+			if err != nil {
+				fmt.Fprintf(c.ErrOrStderr(), "Error: %s\n", err)
+			}
+			return err
+		},
+	}
+	opts.addFlags(dumpCmd)
+	dumpCmd.Flags().StringVar(&opts.format, "format", "yaml", "Output format. Allowed: 'yaml' or 'json'.")
+	return dumpCmd
+}
+
+func runConfigDump(c *cobra.Command, opts *configDumpOptions) error {
+	if opts.format != "yaml" && opts.format != "json" {
+		return usageError(fmt.Errorf("format invalid: must be 'yaml' or 'json'"))
+	}
+	logger, err := log.NewStdLogger(c.ErrOrStderr(), c.ErrOrStderr(), "WARN")
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	ctx := util.WithLogger(c.Context(), logger)
+	// secrets are never fetched, since they'd be redacted anyway
+	redactAll := make(map[string]secrets.Provider)
+	for scheme := range secrets.Providers() {
+		redactAll[scheme] = redactingProvider{}
+	}
+	ctx = secrets.WithResolver(ctx, secrets.NewResolver(redactAll))
+
+	files, err := opts.loadFiles(ctx)
+	if err != nil {
+		return usageError(err)
+	}
+	// merging checks for conflicts between files
+	if _, err := mergeLoadedFiles(files); err != nil {
+		return usageError(err)
+	}
+	dump := dumpConfig(files)
+
+	var out []byte
+	if opts.format == "json" {
+		out, err = json.MarshalIndent(dump, "", "  ")
+		out = append(out, '\n')
+	} else {
+		// sections are written in the order of tool files
+		var sections yaml.MapSlice
+		for _, section := range resourceSections {
+			if entries, ok := dump[section]; ok {
+				sections = append(sections, yaml.MapItem{Key: section, Value: entries})
+			}
+		}
+		out, err = yaml.MarshalWithOptions(sections, yaml.IndentSequence(true), yaml.UseLiteralStyleIfMultiline(true))
+	}
+	if err != nil {
+		return fmt.Errorf("unable to marshal configuration: %w", err)
+	}
+	_, err = c.OutOrStdout().Write(out)
+	return err
+}
+
+// dumpConfig merges the resources of loaded files by section, with the
+// deprecated authSources moved into authServices, and redacts credentials.
+func dumpConfig(files []loadedFile) map[string]map[string]any {
+	dump := make(map[string]map[string]any)
+	for _, f := range files {
+		for section, entries := range f.raw {
+			if section == "authSources" {
+				section = "authServices"
+			}
+			if len(entries) == 0 {
+				continue
+			}
+			if dump[section] == nil {
+				dump[section] = make(map[string]any)
+			}
+			for name, fields := range entries {
+				dump[section][name] = redact(fields)
+			}
+		}
+	}
+	return dump
+}
+
+// sensitiveParamFields are the fields of parameters marked `sensitive` whose
+// values are secrets too.
+var sensitiveParamFields = []string{"default", "allowedValues"}
+
+// redact returns a copy of v with the values of sensitive fields, the
+// passwords of URLs, the defaults and allowed values of sensitive parameters,
+// and the values of secret references replaced.
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		sensitiveParam := v["sensitive"] == true
+		for k, field := range v {
+			if field != nil && field != "" && sensitiveField.MatchString(k) {
+				out[k] = redacted
+				continue
+			}
+			if sensitiveParam && field != nil && slices.Contains(sensitiveParamFields, k) {
+				out[k] = redacted
+				continue
+			}
+			out[k] = redact(field)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redact(item)
+		}
+		return out
+	case string:
+		return redactURL(v)
+	default:
+		return v
+	}
+}
+
+// redactURL replaces the password of a URL with credentials, such as a
+// connection string, and returns any other string unchanged.
+func redactURL(s string) string {
+	if !strings.Contains(s, "://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, ok := u.User.Password(); !ok {
+		return s
+	}
+	u.User = url.UserPassword(u.User.Username(), redacted)
+	return u.String()
+}
+
+// redactingProvider resolves every secret reference to the redacted value.
+type redactingProvider struct{}
+
+func (redactingProvider) Resolve(context.Context, string) (string, error) {
+	return redacted, nil
+}
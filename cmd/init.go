@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/scaffold"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type initOptions struct {
+	toolsFileOptions
+	source     string
+	schema     string
+	tables     []string
+	outputFile string
+	openAPI    string
+	baseURL    string
+	operations []string
+	tags       []string
+}
+
+func newInitCommand() *cobra.Command {
+	opts := &initOptions{}
+	initCmd := &cobra.Command{
+		Use:   "init",
+		Short: "Generate starter tools from the tables of a source",
+		Long: "Generate starter tools from the tables of a source.\n\n" +
+			"Connects to the source, reads its catalog, and writes list, get-by-primary-key, " +
+			"and search tools for each table, along with a toolset of all of them. The " +
+			"output doesn't include the source, so use it alongside the file that defines it.\n\n" +
+			"With --openapi, writes an http tool for each operation of an OpenAPI 3 document " +
+			"instead, along with a toolset for each tag.",
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			err := runInit(c, opts)
+			if err != nil {
+				fmt.Fprintf(c.ErrOrStderr(), "Error: %s\n", err)
+			}
+			return err
+		},
+	}
+	opts.addFlags(initCmd)
+	flags := initCmd.Flags()
+	flags.StringVar(&opts.source, "source", "", "Name of the source to read tables from, or of the http source the tools generated with --openapi use.")
+	flags.StringVar(&opts.schema, "schema", "", "Schema, or dataset for BigQuery, to read tables from. Defaults to the source's default schema.")
+	flags.StringSliceVar(&opts.tables, "tables", []string{}, "Tables to generate tools for. Defaults to every table in the schema.")
+	flags.StringVar(&opts.outputFile, "output-file", "", "File to write the generated tools to. Defaults to stdout.")
+	flags.StringVar(&opts.openAPI, "openapi", "", "OpenAPI 3 document, in YAML or JSON, to generate http tools from.")
+	flags.StringVar(&opts.baseURL, "base-url", "", "Base URL of the generated http source. Defaults to the first server of the OpenAPI document.")
+	flags.StringSliceVar(&opts.operations, "operations", []string{}, "OpenAPI operations to generate tools for, by operationId. Defaults to every operation.")
+	flags.StringSliceVar(&opts.tags, "tags", []string{}, "Only generate tools for OpenAPI operations with any of these tags.")
+	_ = initCmd.MarkFlagRequired("source")
+	return initCmd
+}
+
+func runInit(c *cobra.Command, opts *initOptions) error {
+	// keep stdout for the generated tools
+	logger, err := log.NewStdLogger(c.ErrOrStderr(), c.ErrOrStderr(), "WARN")
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	ctx := util.WithLogger(c.Context(), logger)
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(versionString)
+	if err != nil {
+		return fmt.Errorf("unable to create telemetry instrumentation: %w", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	if opts.openAPI != "" {
+		out, err := generateOpenAPI(ctx, opts)
+		if err != nil {
+			return err
+		}
+		return opts.write(c, out)
+	}
+
+	toolsFile, err := opts.load(ctx)
+	if err != nil {
+		return usageError(err)
+	}
+	sc, ok := toolsFile.Sources[opts.source]
+	if !ok {
+		return usageError(fmt.Errorf("source %q not found", opts.source))
+	}
+
+	cfg := server.ServerConfig{
+		Version:       versionString + "+init",
+		SourceConfigs: server.SourceConfigs{opts.source: sc},
+	}
+	sourcesMap, _, _, _, _, _, _, err := server.InitializeConfigs(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("unable to initialize source %q: %w", opts.source, err)
+	}
+	defer server.CloseReplacedSources(ctx, sourcesMap, nil)
+
+	out, err := scaffold.Generate(ctx, sourcesMap[opts.source], scaffold.Options{
+		SourceName: opts.source,
+		Schema:     opts.schema,
+		Tables:     opts.tables,
+	})
+	if err != nil {
+		return err
+	}
+	return opts.write(c, out)
+}
+
+// generateOpenAPI generates http tools from an OpenAPI document. The http
+// source is included unless it's defined in the given tool files.
+func generateOpenAPI(ctx context.Context, opts *initOptions) ([]byte, error) {
+	spec, err := os.ReadFile(opts.openAPI)
+	if err != nil {
+		return nil, usageError(fmt.Errorf("unable to read OpenAPI document: %w", err))
+	}
+	includeSource := true
+	if opts.toolsFile != "" || len(opts.toolsFiles) > 0 || opts.toolsFolder != "" || len(opts.prebuiltConfigs) > 0 {
+		toolsFile, err := opts.load(ctx)
+		if err != nil {
+			return nil, usageError(err)
+		}
+		if sc, ok := toolsFile.Sources[opts.source]; ok {
+			if sc.SourceConfigKind() != "http" {
+				return nil, usageError(fmt.Errorf("source %q must be of kind \"http\", got %q", opts.source, sc.SourceConfigKind()))
+			}
+			includeSource = false
+		}
+	}
+	return scaffold.GenerateOpenAPI(spec, scaffold.OpenAPIOptions{
+		SourceName:    opts.source,
+		IncludeSource: includeSource,
+		BaseURL:       opts.baseURL,
+		Operations:    opts.operations,
+		Tags:          opts.tags,
+	})
+}
+
+// write writes generated tools to the output file, or stdout.
+func (o *initOptions) write(c *cobra.Command, out []byte) error {
+	if o.outputFile == "" {
+		_, err := c.OutOrStdout().Write(out)
+		return err
+	}
+	if err := os.WriteFile(o.outputFile, out, 0o644); err != nil {
+		return fmt.Errorf("unable to write %q: %w", o.outputFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestInit(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("unable to open database: %s", err)
+	}
+	_, err = db.Exec(`CREATE TABLE hotels (id INTEGER PRIMARY KEY, name TEXT);
+INSERT INTO hotels VALUES (1, 'Hilton Basel'), (2, 'Marriott Zurich');`)
+	db.Close()
+	if err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	sourcesFile := filepath.Join(dir, "sources.yaml")
+	content := fmt.Sprintf("sources:\n  my-sqlite:\n    kind: sqlite\n    database: %s\n", dbPath)
+	if err := os.WriteFile(sourcesFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("unable to write tools file: %s", err)
+	}
+	toolsFile := filepath.Join(dir, "tools.yaml")
+
+	c := NewCommand()
+	c.SetOut(new(bytes.Buffer))
+	c.SetErr(new(bytes.Buffer))
+	c.SetArgs([]string{"init", "--tools-file", sourcesFile, "--source", "my-sqlite", "--output-file", toolsFile})
+	if err := c.Execute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the generated tools run against the source
+	out := new(bytes.Buffer)
+	c = NewCommand()
+	c.SetOut(out)
+	c.SetErr(new(bytes.Buffer))
+	c.SetArgs([]string{"invoke", "search_hotels", "--tools-files", sourcesFile + "," + toolsFile, "--param", "query=zur", "-o", "csv"})
+	if err := c.Execute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "id,name\n2,Marriott Zurich\n"; out.String() != want {
+		t.Fatalf("unexpected output: got %q, want %q", out.String(), want)
+	}
+
+	c = NewCommand()
+	c.SetOut(new(bytes.Buffer))
+	c.SetErr(new(bytes.Buffer))
+	c.SetArgs([]string{"init", "--tools-file", sourcesFile, "--source", "missing"})
+	if err := c.Execute(); err == nil {
+		t.Fatalf("expected an error for an unknown source")
+	}
+}
+
+func TestInitOpenAPI(t *testing.T) {
+	var gotMethod, gotPath, gotBody string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotMethod, gotPath, gotBody = r.Method, r.URL.RequestURI(), string(b)
+		fmt.Fprint(w, `{"id": 7}`)
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	specFile := filepath.Join(dir, "openapi.json")
+	spec := fmt.Sprintf(`{
+  "openapi": "3.1.0",
+  "servers": [{"url": %q}],
+  "paths": {
+    "/pets/{id}/tags": {
+      "post": {
+        "operationId": "tagPet",
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}},
+          {"name": "notify", "in": "query", "schema": {"type": ["boolean", "null"]}}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {
+            "type": "object",
+            "required": ["tag"],
+            "properties": {"tag": {"type": "string"}}
+          }}}
+        }
+      }
+    }
+  }
+}`, ts.URL)
+	if err := os.WriteFile(specFile, []byte(spec), 0o644); err != nil {
+		t.Fatalf("unable to write OpenAPI document: %s", err)
+	}
+	toolsFile := filepath.Join(dir, "tools.yaml")
+
+	c := NewCommand()
+	c.SetOut(new(bytes.Buffer))
+	c.SetErr(new(bytes.Buffer))
+	c.SetArgs([]string{"init", "--openapi", specFile, "--source", "pets", "--output-file", toolsFile})
+	if err := c.Execute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	out := new(bytes.Buffer)
+	c = NewCommand()
+	c.SetOut(out)
+	c.SetErr(new(bytes.Buffer))
+	c.SetArgs([]string{"invoke", "tagPet", "--tools-file", toolsFile, "--json", `{"id": 3, "notify": true, "tag": "good"}`})
+	if err := c.Execute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/pets/3/tags?notify=true" {
+		t.Fatalf("unexpected request: %s %s", gotMethod, gotPath)
+	}
+	if want := "{\n  \"tag\": \"good\"\n}\n"; gotBody != want {
+		t.Fatalf("unexpected request body: got %q, want %q", gotBody, want)
+	}
+	if want := "{\n  \"id\": 7\n}\n"; out.String() != want {
+		t.Fatalf("unexpected output: got %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/server/resources"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/utility/pipeline"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/spf13/cobra"
+)
+
+type invokeOptions struct {
+	toolsFileOptions
+	params      []string
+	jsonParams  string
+	output      string
+	accessToken string
+}
+
+func newInvokeCommand() *cobra.Command {
+	opts := &invokeOptions{}
+	invokeCmd := &cobra.Command{
+		Use:   "invoke <tool>",
+		Short: "Invoke a tool directly, without starting the server",
+		Long: "Invoke a tool directly, without starting the server.\n\n" +
+			"Only the sources used by the tool, and by the steps of pipelines, are initialized. Exits with status 1 if the " +
+			"tool fails, or 2 if the tool can't be invoked, e.g. because it doesn't exist " +
+			"or its parameters are invalid.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			err := runInvoke(c, opts, args[0])
+			if err != nil {
+				fmt.Fprintf(c.ErrOrStderr(), "Error: %s\n", err)
+			}
+			return err
+		},
+	}
+	opts.addFlags(invokeCmd)
+	flags := invokeCmd.Flags()
+	flags.StringArrayVar(&opts.params, "param", []string{}, "Parameter of the tool as name=value. Values of non-string parameters are parsed as JSON. Can be repeated.")
+	flags.StringVar(&opts.jsonParams, "json", "", "Parameters of the tool as a JSON object, or '-' to read it from stdin. Combined with any --param flags, which take precedence.")
+	flags.StringVarP(&opts.output, "output", "o", "json", "Output format of the result. Allowed: 'json', 'table', or 'csv'.")
+	flags.StringVar(&opts.accessToken, "access-token", "", "Access token passed to tools that use client authorization.")
+	return invokeCmd
+}
+
+func runInvoke(c *cobra.Command, opts *invokeOptions, toolName string) error {
+	if opts.output != "json" && opts.output != "table" && opts.output != "csv" {
+		return usageError(fmt.Errorf("output format invalid: must be 'json', 'table', or 'csv'"))
+	}
+
+	// keep stdout for the result
+	logger, err := log.NewStdLogger(c.ErrOrStderr(), c.ErrOrStderr(), "WARN")
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	ctx := util.WithLogger(c.Context(), logger)
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(versionString)
+	if err != nil {
+		return fmt.Errorf("unable to create telemetry instrumentation: %w", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	toolsFile, err := opts.load(ctx)
+	if err != nil {
+		return usageError(err)
+	}
+	toolConfigs, err := invokedTools(toolsFile.Tools, toolName)
+	if err != nil {
+		return usageError(err)
+	}
+
+	// only initialize the sources the tools use
+	cfg := server.ServerConfig{
+		Version:               versionString + "+invoke",
+		SourceConfigs:         server.SourceConfigs{},
+		AuthServiceConfigs:    toolsFile.AuthServices,
+		EmbeddingModelConfigs: toolsFile.EmbeddingModels,
+		ToolConfigs:           toolConfigs,
+	}
+	for _, tc := range toolConfigs {
+		if name := toolSourceName(tc); name != "" {
+			if sc, ok := toolsFile.Sources[name]; ok {
+				cfg.SourceConfigs[name] = sc
+			}
+		}
+	}
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := server.InitializeConfigs(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("unable to initialize tool %q: %w", toolName, err)
+	}
+	defer server.CloseReplacedSources(ctx, sourcesMap, nil)
+	resourceMgr := resources.NewResourceManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
+	tool := toolsMap[toolName]
+
+	data, err := opts.paramData(c.InOrStdin(), tool.Manifest().Parameters)
+	if err != nil {
+		return usageError(err)
+	}
+	params, err := tool.ParseParams(data, map[string]map[string]any{})
+	if err != nil {
+		return usageError(fmt.Errorf("provided parameters were invalid: %w", err))
+	}
+	res, err := tools.Invoke(ctx, tool, resourceMgr, params, tools.AccessToken(opts.accessToken))
+	if err != nil {
+		return fmt.Errorf("error while invoking tool: %w", err)
+	}
+	return writeResult(c.OutOrStdout(), opts.output, res)
+}
+
+// invokedTools returns the tools that invoking a tool runs, which are the tool
+// itself and the steps of pipelines.
+func invokedTools(all server.ToolConfigs, toolName string) (server.ToolConfigs, error) {
+	if _, ok := all[toolName]; !ok {
+		return nil, fmt.Errorf("tool %q not found", toolName)
+	}
+	invoked := server.ToolConfigs{}
+	pending := []string{toolName}
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if _, ok := invoked[name]; ok {
+			continue
+		}
+		tc := all[name]
+		invoked[name] = tc
+		p, ok := tools.BaseConfig(tc).(pipeline.Config)
+		if !ok {
+			continue
+		}
+		for _, s := range p.Steps {
+			if _, ok := all[s.Tool]; !ok {
+				return nil, fmt.Errorf("tool %q used by pipeline %q not found", s.Tool, name)
+			}
+			pending = append(pending, s.Tool)
+		}
+	}
+	return invoked, nil
+}
+
+// toolSourceName returns the name of the source a tool uses, if it has one.
+func toolSourceName(tc tools.ToolConfig) string {
+	v := reflect.ValueOf(tools.BaseConfig(tc))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	if f := v.FieldByName("Source"); f.IsValid() && f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
+
+// rawStringTypes are the types of parameters whose values are strings, which
+// --param takes as they are rather than as JSON.
+var rawStringTypes = map[string]bool{
+	parameters.TypeString:   true,
+	parameters.TypeDate:     true,
+	parameters.TypeDateTime: true,
+	parameters.TypeUUID:     true,
+	parameters.TypeDecimal:  true,
+	// template parameters
+	parameters.TypeIdentifier: true,
+	parameters.TypeKeyword:    true,
+}
+
+// paramData combines the --json and --param flags into the data passed to
+// ParseParams.
+func (o *invokeOptions) paramData(stdin io.Reader, manifest []parameters.ParameterManifest) (map[string]any, error) {
+	data := make(map[string]any)
+	if o.jsonParams != "" {
+		var r io.Reader = strings.NewReader(o.jsonParams)
+		if o.jsonParams == "-" {
+			r = stdin
+		}
+		if err := decodeJSONValue(r, &data); err != nil {
+			return nil, fmt.Errorf("--json was not a valid JSON object: %w", err)
+		}
+	}
+
+	types := make(map[string]string, len(manifest))
+	for _, p := range manifest {
+		types[p.Name] = p.Type
+	}
+	for _, p := range o.params {
+		name, value, ok := strings.Cut(p, "=")
+		if !ok {
+			return nil, fmt.Errorf("--param %q must be of the form name=value", p)
+		}
+		typ, ok := types[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown parameter %q", name)
+		}
+		if rawStringTypes[typ] {
+			data[name] = value
+			continue
+		}
+		var v any
+		if err := decodeJSONValue(strings.NewReader(value), &v); err != nil {
+			return nil, fmt.Errorf("value of parameter %q must be valid JSON for type %q: %w", name, typ, err)
+		}
+		data[name] = v
+	}
+	return data, nil
+}
+
+// decodeJSONValue decodes a single JSON value like util.DecodeJSON, but fails
+// if anything follows it, so that e.g. `10abc` isn't taken as 10.
+func decodeJSONValue(r io.Reader, v any) error {
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after the JSON value")
+	}
+	return nil
+}
+
+func writeResult(w io.Writer, format string, res any) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+
+	columns, rows, err := tabulate(res)
+	if err != nil {
+		return err
+	}
+	if format == "csv" {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return err
+		}
+		return cw.WriteAll(rows)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// tabulate converts a result to columns and rows. A list of objects has a
+// row per object and a column per field, in the order the fields are first
+// seen. Any other result is shown in a single "result" column.
+func tabulate(res any) ([]string, [][]string, error) {
+	b, err := json.Marshal(res)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal result: %w", err)
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil {
+		// a single value is a single row
+		items = []json.RawMessage{b}
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	objects := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		keys, values, ok := objectFields(item)
+		if !ok {
+			// not a list of objects
+			rows := make([][]string, 0, len(items))
+			for _, item := range items {
+				rows = append(rows, []string{cell(item)})
+			}
+			return []string{"result"}, rows, nil
+		}
+		for _, k := range keys {
+			if !seen[k] {
+				seen[k] = true
+				columns = append(columns, k)
+			}
+		}
+		objects = append(objects, values)
+	}
+
+	rows := make([][]string, 0, len(objects))
+	for _, o := range objects {
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = o[c]
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows, nil
+}
+
+// objectFields returns the keys of a JSON object in order, along with the
+// cell of each value.
+func objectFields(raw json.RawMessage) ([]string, map[string]string, bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, false
+	}
+	var keys []string
+	values := make(map[string]string)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, false
+		}
+		key, _ := tok.(string)
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return nil, nil, false
+		}
+		keys = append(keys, key)
+		values[key] = cell(v)
+	}
+	return keys, values, true
+}
+
+// cell formats a JSON value for a table or CSV. Strings are unquoted, nulls
+// are empty, and anything else is left as JSON.
+func cell(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	if string(raw) == "null" {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
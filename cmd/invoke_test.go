@@ -0,0 +1,266 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInvoke(t *testing.T) {
+	dir := t.TempDir()
+	toolsFile := filepath.Join(dir, "tools.yaml")
+	content := fmt.Sprintf(`
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: %s
+tools:
+  count:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: count up from n
+    statement: SELECT ?1 AS n, ?2 AS label, NULL AS note UNION ALL SELECT ?1 + 1, 'a,b', 'x'
+    parameters:
+      - name: n
+        type: integer
+        description: the first number
+      - name: label
+        type: string
+        description: a label
+  since:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: echoes a date and a decimal
+    statement: SELECT ?1 AS day, ?2 AS amount
+    parameters:
+      - name: day
+        type: date
+        description: a day
+      - name: amount
+        type: decimal
+        description: an amount
+  sorted:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: sorts by a column
+    statement: SELECT 1 AS n UNION ALL SELECT 2 ORDER BY {{.col}} {{.order}}
+    templateParameters:
+      - name: col
+        type: identifier
+        description: the column to sort by
+      - name: order
+        type: keyword
+        description: the sort order
+        allowedValues: ["ASC", "DESC"]
+  broken:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: queries a missing table
+    statement: SELECT * FROM missing
+  count_twice:
+    kind: pipeline
+    description: counts up from n twice
+    parameters:
+      - name: n
+        type: integer
+        description: the first number
+    steps:
+      - name: first
+        tool: count
+        params:
+          n: $params.n
+          label: first
+      - tool: count
+        params:
+          n: $steps.first[1].n
+          label: second
+  dangling:
+    kind: pipeline
+    description: uses a missing tool
+    steps:
+      - tool: missing
+`, filepath.Join(dir, "test.db"))
+	if err := os.WriteFile(toolsFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("unable to write tools file: %s", err)
+	}
+
+	tcs := []struct {
+		desc     string
+		args     []string
+		stdin    string
+		want     string
+		wantCode int
+	}{
+		{
+			desc: "json output",
+			args: []string{"count", "--param", "n=1", "--param", "label=first"},
+			want: `[
+  {
+    "n": 1,
+    "label": "first",
+    "note": null
+  },
+  {
+    "n": 2,
+    "label": "a,b",
+    "note": "x"
+  }
+]
+`,
+		},
+		{
+			desc: "table output",
+			args: []string{"count", "--json", `{"n": 1, "label": "first"}`, "-o", "table"},
+			want: "n  label  note\n1  first  \n2  a,b    x\n",
+		},
+		{
+			desc:  "csv output with parameters from stdin",
+			args:  []string{"count", "--json", "-", "--param", "n=5", "-o", "csv"},
+			stdin: `{"n": 1, "label": "first"}`,
+			want:  "n,label,note\n5,first,\n6,\"a,b\",x\n",
+		},
+		{
+			desc: "string-valued parameters taken as they are",
+			args: []string{"since", "--param", "day=2024-01-05", "--param", "amount=12.50", "-o", "csv"},
+			want: "day,amount\n2024-01-05,12.5\n",
+		},
+		{
+			desc:     "invalid date",
+			args:     []string{"since", "--param", "day=notadate", "--param", "amount=1"},
+			wantCode: 2,
+		},
+		{
+			desc: "template parameters taken as they are",
+			args: []string{"sorted", "--param", "col=n", "--param", "order=DESC", "-o", "csv"},
+			want: "n\n2\n1\n",
+		},
+		{
+			desc: "pipeline",
+			args: []string{"count_twice", "--param", "n=1", "-o", "csv"},
+			want: "label,n,note\nsecond,2,\n\"a,b\",3,x\n",
+		},
+		{
+			desc:     "pipeline with an unknown step tool",
+			args:     []string{"dangling"},
+			wantCode: 2,
+		},
+		{
+			desc:     "unknown tool",
+			args:     []string{"missing"},
+			wantCode: 2,
+		},
+		{
+			desc:     "invalid parameter",
+			args:     []string{"count", "--param", "n=one", "--param", "label=x"},
+			wantCode: 2,
+		},
+		{
+			desc:     "parameter with trailing data",
+			args:     []string{"count", "--param", "n=10abc", "--param", "label=x"},
+			wantCode: 2,
+		},
+		{
+			desc:     "json with trailing data",
+			args:     []string{"count", "--json", `{"n": 1, "label": "x"} {}`},
+			wantCode: 2,
+		},
+		{
+			desc:     "missing parameter",
+			args:     []string{"count", "--param", "n=1"},
+			wantCode: 2,
+		},
+		{
+			desc:     "failed invocation",
+			args:     []string{"broken"},
+			wantCode: 1,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+			c := NewCommand()
+			c.SetIn(strings.NewReader(tc.stdin))
+			c.SetOut(out)
+			c.SetErr(errOut)
+			c.SetArgs(append([]string{"invoke", "--tools-file", toolsFile}, tc.args...))
+			err := c.Execute()
+
+			if tc.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if out.String() != tc.want {
+					t.Fatalf("unexpected output: got %q, want %q", out.String(), tc.want)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error, got output %q", out.String())
+			}
+			code := 1
+			var exitErr *exitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.code
+			}
+			if code != tc.wantCode {
+				t.Fatalf("unexpected exit code for %q: got %d, want %d", err, code, tc.wantCode)
+			}
+			if !strings.Contains(errOut.String(), err.Error()) {
+				t.Fatalf("expected error to be printed, got %q", errOut.String())
+			}
+		})
+	}
+}
+
+func TestTabulate(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   any
+		want string
+	}{
+		{
+			desc: "rows with different fields",
+			in:   []any{map[string]any{"a": 1}, map[string]any{"a": 2, "b": []int{1, 2}}},
+			want: "a,b\n1,\n2,\"[1,2]\"\n",
+		},
+		{
+			desc: "single object",
+			in:   map[string]any{"rowsAffected": 3},
+			want: "rowsAffected\n3\n",
+		},
+		{
+			desc: "scalars",
+			in:   []any{"x", 1},
+			want: "result\nx\n1\n",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeResult(&buf, "csv", tc.in); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if buf.String() != tc.want {
+				t.Fatalf("unexpected output: got %q, want %q", buf.String(), tc.want)
+			}
+		})
+	}
+}
@@ -17,15 +17,14 @@ package cmd
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
-	"maps"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"regexp"
 	"runtime"
-	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -33,9 +32,11 @@ import (
 	"github.com/fsnotify/fsnotify"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/log"
 	"github.com/googleapis/genai-toolbox/internal/prebuiltconfigs"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
@@ -168,6 +169,7 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mssql/mssqlexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mssql/mssqllisttables"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mssql/mssqlsql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/mssql/mssqltransaction"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlgetqueryplan"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqllistactivequeries"
@@ -175,6 +177,7 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqllisttables"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqllisttablesmissinguniqueindexes"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlsql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqltransaction"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/neo4j/neo4jcypher"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/neo4j/neo4jexecutecypher"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/neo4j/neo4jschema"
@@ -205,6 +208,7 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgreslongrunningtransactions"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresreplicationstats"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgressql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrestransaction"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/redis"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/serverlessspark/serverlesssparkcancelbatch"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/serverlessspark/serverlesssparkcreatepysparkbatch"
@@ -217,12 +221,15 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/tools/spanner/spannerlistgraphs"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/spanner/spannerlisttables"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/spanner/spannersql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/spanner/spannertransaction"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/sqlite/sqliteexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/sqlite/sqlitesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/sqlite/sqlitetransaction"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/tidb/tidbexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/tidb/tidbsql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/trino/trinoexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/trino/trinosql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/utility/pipeline"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/utility/wait"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/valkey"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/yugabytedbsql"
@@ -300,23 +307,45 @@ func semanticVersion() string {
 func Execute() {
 	if err := NewCommand().Execute(); err != nil {
 		exit := 1
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			exit = exitErr.code
+		}
 		os.Exit(exit)
 	}
 }
 
+// exitError is an error with a specific exit code.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// usageError marks an error as caused by how a command was called, rather
+// than by the command failing.
+func usageError(err error) error {
+	return &exitError{code: 2, err: err}
+}
+
 // Command represents an invocation of the CLI.
 type Command struct {
 	*cobra.Command
 
-	cfg            server.ServerConfig
-	logger         log.Logger
-	tools_file     string
-	tools_files    []string
-	tools_folder   string
-	prebuiltConfig string
-	inStream       io.Reader
-	outStream      io.Writer
-	errStream      io.Writer
+	cfg             server.ServerConfig
+	logger          log.Logger
+	tools_file      string
+	tools_files     []string
+	tools_folder    string
+	prebuiltConfigs []string
+	// secretRefreshInterval is how often secrets are fetched again
+	secretRefreshInterval time.Duration
+	inStream              io.Reader
+	outStream             io.Writer
+	errStream             io.Writer
 }
 
 // NewCommand returns a Command object representing an invocation of the CLI.
@@ -369,34 +398,51 @@ func NewCommand(opts ...Option) *Command {
 	flags.StringVar(&cmd.cfg.TelemetryServiceName, "telemetry-service-name", "toolbox", "Sets the value of the service.name resource attribute for telemetry data.")
 	// Fetch prebuilt tools sources to customize the help description
 	prebuiltHelp := fmt.Sprintf(
-		"Use a prebuilt tool configuration by source type. Allowed: '%s'.",
+		"Use prebuilt tool configurations by source type. Can be given more than once, and combined with tool files. Allowed: '%s'.",
 		strings.Join(prebuiltconfigs.GetPrebuiltSources(), "', '"),
 	)
-	flags.StringVar(&cmd.prebuiltConfig, "prebuilt", "", prebuiltHelp)
+	flags.StringSliceVar(&cmd.prebuiltConfigs, "prebuilt", []string{}, prebuiltHelp)
 	flags.BoolVar(&cmd.cfg.Stdio, "stdio", false, "Listens via MCP STDIO instead of acting as a remote HTTP server.")
 	flags.BoolVar(&cmd.cfg.DisableReload, "disable-reload", false, "Disables dynamic reloading of tools file.")
+	flags.DurationVar(&cmd.secretRefreshInterval, "secret-refresh-interval", 5*time.Minute, "How often secrets referenced in tools files are fetched again. Sources whose secrets changed are reinitialized, even with --disable-reload. Set to 0 to disable.")
 	flags.BoolVar(&cmd.cfg.UI, "ui", false, "Launches the Toolbox UI web server.")
 	flags.StringSliceVar(&cmd.cfg.AllowedOrigins, "allowed-origins", []string{"*"}, "Specifies a list of origins permitted to access this server. Defaults to '*'.")
 
 	// wrap RunE command so that we have access to original Command object
 	cmd.RunE = func(*cobra.Command, []string) error { return run(cmd) }
 
+	cmd.AddCommand(newConfigCommand())
+	cmd.AddCommand(newInitCommand())
+	cmd.AddCommand(newInvokeCommand())
+	cmd.AddCommand(newSchemaCommand())
+	cmd.AddCommand(newValidateCommand())
+
 	return cmd
 }
 
 type ToolsFile struct {
-	Sources      server.SourceConfigs      `yaml:"sources"`
-	AuthSources  server.AuthServiceConfigs `yaml:"authSources"` // Deprecated: Kept for compatibility.
-	AuthServices server.AuthServiceConfigs `yaml:"authServices"`
-	Tools        server.ToolConfigs        `yaml:"tools"`
-	Toolsets     server.ToolsetConfigs     `yaml:"toolsets"`
-	Prompts      server.PromptConfigs      `yaml:"prompts"`
+	Sources         server.SourceConfigs         `yaml:"sources"`
+	AuthSources     server.AuthServiceConfigs    `yaml:"authSources"` // Deprecated: Kept for compatibility.
+	AuthServices    server.AuthServiceConfigs    `yaml:"authServices"`
+	EmbeddingModels server.EmbeddingModelConfigs `yaml:"embeddingModels"`
+	Tools           server.ToolConfigs           `yaml:"tools"`
+	Toolsets        server.ToolsetConfigs        `yaml:"toolsets"`
+	Prompts         server.PromptConfigs         `yaml:"prompts"`
+	Promptsets      server.PromptsetConfigs      `yaml:"promptsets"`
+	// Include lists other tool files to load before this one. Relative paths
+	// are relative to this file.
+	Include []string `yaml:"include"`
+	// Overrides patch resources loaded before, by section and name.
+	Overrides map[string]map[string]any `yaml:"overrides"`
 }
 
 // parseEnv replaces environment variables ${ENV_NAME} with their values.
-// also support ${ENV_NAME:default_value}.
-func parseEnv(input string) (string, error) {
+// also support ${ENV_NAME:default_value}, and references to secrets such as
+// ${file:/run/secrets/password}, which are resolved by the secrets.Resolver in
+// ctx.
+func parseEnv(ctx context.Context, input string) (string, error) {
 	re := regexp.MustCompile(`\$\{(\w+)(:([^}]*))?\}`)
+	resolver := secrets.ResolverFromContext(ctx)
 
 	var err error
 	output := re.ReplaceAllStringFunc(input, func(match string) string {
@@ -404,6 +450,13 @@ func parseEnv(input string) (string, error) {
 
 		// extract the variable name
 		variableName := parts[1]
+		if parts[2] != "" && resolver.Handles(variableName) {
+			value, resolveErr := resolver.Resolve(ctx, variableName, parts[3])
+			if resolveErr != nil {
+				err = resolveErr
+			}
+			return value
+		}
 		if value, found := os.LookupEnv(variableName); found {
 			return value
 		}
@@ -418,16 +471,30 @@ func parseEnv(input string) (string, error) {
 
 // parseToolsFile parses the provided yaml into appropriate configs.
 func parseToolsFile(ctx context.Context, raw []byte) (ToolsFile, error) {
-	var toolsFile ToolsFile
 	// Replace environment variables if found
-	output, err := parseEnv(string(raw))
+	output, err := expandToolsFile(ctx, raw)
+	if err != nil {
+		return ToolsFile{}, err
+	}
+	return unmarshalToolsFile(ctx, output)
+}
+
+// expandToolsFile replaces the environment variables and secrets referenced
+// by a tool file.
+func expandToolsFile(ctx context.Context, raw []byte) ([]byte, error) {
+	output, err := parseEnv(ctx, string(raw))
 	if err != nil {
-		return toolsFile, fmt.Errorf("error parsing environment variables: %s", err)
+		return nil, fmt.Errorf("error parsing environment variables: %s", err)
 	}
-	raw = []byte(output)
+	return []byte(output), nil
+}
 
+// unmarshalToolsFile parses a tool file whose environment variables are
+// already replaced.
+func unmarshalToolsFile(ctx context.Context, expanded []byte) (ToolsFile, error) {
+	var toolsFile ToolsFile
 	// Parse contents
-	err = yaml.UnmarshalContext(ctx, raw, &toolsFile, yaml.Strict())
+	err := yaml.UnmarshalContext(ctx, expanded, &toolsFile, yaml.Strict())
 	if err != nil {
 		return toolsFile, err
 	}
@@ -435,8 +502,8 @@ func parseToolsFile(ctx context.Context, raw []byte) (ToolsFile, error) {
 }
 
 // mergeToolsFiles merges multiple ToolsFile structs into one.
-// Detects and raises errors for resource conflicts in sources, authServices, tools, and toolsets.
-// All resource names (sources, authServices, tools, toolsets) must be unique across all files.
+// Detects and raises errors for resource conflicts in sources, authServices, embeddingModels, tools, toolsets, prompts, and promptsets.
+// All resource names (sources, authServices, embeddingModels, tools, toolsets, prompts, promptsets) must be unique across all files.
 func mergeToolsFiles(files ...ToolsFile) (ToolsFile, error) {
 	merged := ToolsFile{
 		Sources:      make(server.SourceConfigs),
@@ -444,6 +511,7 @@ func mergeToolsFiles(files ...ToolsFile) (ToolsFile, error) {
 		Tools:        make(server.ToolConfigs),
 		Toolsets:     make(server.ToolsetConfigs),
 		Prompts:      make(server.PromptConfigs),
+		Promptsets:   make(server.PromptsetConfigs),
 	}
 
 	var conflicts []string
@@ -479,6 +547,18 @@ func mergeToolsFiles(files ...ToolsFile) (ToolsFile, error) {
 			}
 		}
 
+		// Check for conflicts and merge embeddingModels
+		for name, embeddingModel := range file.EmbeddingModels {
+			if _, exists := merged.EmbeddingModels[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("embeddingModel '%s' (file #%d)", name, fileIndex+1))
+			} else {
+				if merged.EmbeddingModels == nil {
+					merged.EmbeddingModels = make(server.EmbeddingModelConfigs)
+				}
+				merged.EmbeddingModels[name] = embeddingModel
+			}
+		}
+
 		// Check for conflicts and merge tools
 		for name, tool := range file.Tools {
 			if _, exists := merged.Tools[name]; exists {
@@ -505,100 +585,52 @@ func mergeToolsFiles(files ...ToolsFile) (ToolsFile, error) {
 				merged.Prompts[name] = prompt
 			}
 		}
+
+		// Check for conflicts and merge promptsets
+		for name, promptset := range file.Promptsets {
+			if _, exists := merged.Promptsets[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("promptset '%s' (file #%d)", name, fileIndex+1))
+			} else {
+				merged.Promptsets[name] = promptset
+			}
+		}
 	}
 
 	// If conflicts were detected, return an error
 	if len(conflicts) > 0 {
-		return ToolsFile{}, fmt.Errorf("resource conflicts detected:\n  - %s\n\nPlease ensure each source, authService, tool, toolset and prompt has a unique name across all files", strings.Join(conflicts, "\n  - "))
+		return ToolsFile{}, fmt.Errorf("resource conflicts detected:\n  - %s\n\nPlease ensure each source, authService, tool, toolset, prompt and promptset has a unique name across all files", strings.Join(conflicts, "\n  - "))
 	}
 
 	return merged, nil
 }
 
-// loadAndMergeToolsFiles loads multiple YAML files and merges them
-func loadAndMergeToolsFiles(ctx context.Context, filePaths []string) (ToolsFile, error) {
-	var toolsFiles []ToolsFile
-
-	for _, filePath := range filePaths {
-		buf, err := os.ReadFile(filePath)
-		if err != nil {
-			return ToolsFile{}, fmt.Errorf("unable to read tool file at %q: %w", filePath, err)
-		}
-
-		toolsFile, err := parseToolsFile(ctx, buf)
-		if err != nil {
-			return ToolsFile{}, fmt.Errorf("unable to parse tool file at %q: %w", filePath, err)
-		}
-
-		toolsFiles = append(toolsFiles, toolsFile)
-	}
-
-	mergedFile, err := mergeToolsFiles(toolsFiles...)
-	if err != nil {
-		return ToolsFile{}, fmt.Errorf("unable to merge tools files: %w", err)
-	}
-
-	return mergedFile, nil
-}
-
-// loadAndMergeToolsFolder loads all YAML files from a directory and merges them
-func loadAndMergeToolsFolder(ctx context.Context, folderPath string) (ToolsFile, error) {
-	// Check if directory exists
-	info, err := os.Stat(folderPath)
-	if err != nil {
-		return ToolsFile{}, fmt.Errorf("unable to access tools folder at %q: %w", folderPath, err)
-	}
-	if !info.IsDir() {
-		return ToolsFile{}, fmt.Errorf("path %q is not a directory", folderPath)
-	}
-
-	// Find all YAML files in the directory
-	pattern := filepath.Join(folderPath, "*.yaml")
-	yamlFiles, err := filepath.Glob(pattern)
-	if err != nil {
-		return ToolsFile{}, fmt.Errorf("error finding YAML files in %q: %w", folderPath, err)
-	}
-
-	// Also find .yml files
-	ymlPattern := filepath.Join(folderPath, "*.yml")
-	ymlFiles, err := filepath.Glob(ymlPattern)
-	if err != nil {
-		return ToolsFile{}, fmt.Errorf("error finding YML files in %q: %w", folderPath, err)
-	}
-
-	// Combine both file lists
-	allFiles := append(yamlFiles, ymlFiles...)
-
-	if len(allFiles) == 0 {
-		return ToolsFile{}, fmt.Errorf("no YAML files found in directory %q", folderPath)
-	}
-
-	// Use existing loadAndMergeToolsFiles function
-	return loadAndMergeToolsFiles(ctx, allFiles)
-}
-
 func handleDynamicReload(ctx context.Context, toolsFile ToolsFile, s *server.Server) error {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		panic(err)
 	}
 
-	sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := validateReloadEdits(ctx, toolsFile)
+	currentSources := s.ResourceMgr.GetSourcesMap()
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := validateReloadEdits(ctx, toolsFile, currentSources)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to validate reloaded edits: %w", err)
 		logger.WarnContext(ctx, errMsg.Error())
 		return err
 	}
 
-	s.ResourceMgr.SetResources(sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
+	s.ResourceMgr.SetResources(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
+	// closing waits for in-flight invocations to release their connections,
+	// so don't hold up the reload
+	go server.CloseReplacedSources(context.WithoutCancel(ctx), currentSources, sourcesMap)
 
 	return nil
 }
 
-// validateReloadEdits checks that the reloaded tools file configs can initialized without failing
+// validateReloadEdits checks that the reloaded tools file configs can initialized without failing.
+// Sources in currentSources whose config is unchanged are reused rather than reinitialized.
 func validateReloadEdits(
-	ctx context.Context, toolsFile ToolsFile,
-) (map[string]sources.Source, map[string]auth.AuthService, map[string]tools.Tool, map[string]tools.Toolset, map[string]prompts.Prompt, map[string]prompts.Promptset, error,
+	ctx context.Context, toolsFile ToolsFile, currentSources map[string]sources.Source,
+) (map[string]sources.Source, map[string]auth.AuthService, map[string]embeddingmodels.EmbeddingModel, map[string]tools.Tool, map[string]tools.Toolset, map[string]prompts.Prompt, map[string]prompts.Promptset, error,
 ) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
@@ -616,26 +648,98 @@ func validateReloadEdits(
 	defer span.End()
 
 	reloadedConfig := server.ServerConfig{
-		Version:            versionString,
-		SourceConfigs:      toolsFile.Sources,
-		AuthServiceConfigs: toolsFile.AuthServices,
-		ToolConfigs:        toolsFile.Tools,
-		ToolsetConfigs:     toolsFile.Toolsets,
-		PromptConfigs:      toolsFile.Prompts,
+		Version:               versionString,
+		SourceConfigs:         toolsFile.Sources,
+		AuthServiceConfigs:    toolsFile.AuthServices,
+		EmbeddingModelConfigs: toolsFile.EmbeddingModels,
+		ToolConfigs:           toolsFile.Tools,
+		ToolsetConfigs:        toolsFile.Toolsets,
+		PromptConfigs:         toolsFile.Prompts,
+		PromptsetConfigs:      toolsFile.Promptsets,
 	}
 
-	sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := server.InitializeConfigs(ctx, reloadedConfig)
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := server.ReinitializeConfigs(ctx, reloadedConfig, currentSources)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to initialize reloaded configs: %w", err)
 		logger.WarnContext(ctx, errMsg.Error())
-		return nil, nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, err
+	}
+
+	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, nil
+}
+
+// watchSecrets refreshes the secrets resolved so far every interval, and
+// signals on the returned channel when any of them changed.
+func watchSecrets(ctx context.Context, resolver *secrets.Resolver, interval time.Duration) <-chan struct{} {
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			changed, err := resolver.Refresh(ctx)
+			if err != nil {
+				logger.WarnContext(ctx, fmt.Sprintf("error refreshing secrets: %s", err))
+			}
+			if !changed {
+				continue
+			}
+			logger.InfoContext(ctx, "Secrets changed, reloading tool configuration.")
+			select {
+			case changes <- struct{}{}:
+			default:
+				// a reload is already pending
+			}
+		}
+	}()
+	return changes
+}
+
+// reloadOnSecretChanges reloads the tool configuration when secretChanges
+// signals that secrets it references have changed, for when the tool files
+// aren't watched. The files are parsed again from the contents loaded at
+// startup, so edits to them are still not picked up.
+func reloadOnSecretChanges(ctx context.Context, prebuilts []prebuiltFile, paths []string, files []loadedFile, s *server.Server, secretChanges <-chan struct{}) {
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		panic(err)
 	}
 
-	return sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, nil
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-secretChanges:
+		}
+		reloaded, err := reparseToolsFiles(ctx, prebuilts, paths, files)
+		if err != nil {
+			logger.WarnContext(ctx, fmt.Sprintf("error loading tools files %s", err))
+			continue
+		}
+		reloadedToolsFile, err := mergeLoadedFiles(reloaded)
+		if err != nil {
+			logger.WarnContext(ctx, fmt.Sprintf("error loading tools files %s", err))
+			continue
+		}
+		if err := handleDynamicReload(ctx, reloadedToolsFile, s); err != nil {
+			logger.WarnContext(ctx, fmt.Sprintf("unable to reload tools files: %s", err))
+		}
+	}
 }
 
-// watchChanges checks for changes in the provided yaml tools file(s) or folder.
-func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles map[string]bool, s *server.Server) {
+// watchChanges checks for changes in the provided yaml tools file(s) or folder,
+// along with the files they include, or signals on secretChanges that secrets
+// they reference have changed. files are the tool files loaded at startup.
+func watchChanges(ctx context.Context, opts toolsFileOptions, prebuilts []prebuiltFile, files []loadedFile, s *server.Server, secretChanges <-chan struct{}) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		panic(err)
@@ -649,25 +753,10 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 
 	defer w.Close()
 
-	watchingFolder := false
-	var folderToWatch string
-
-	// if watchedFiles is empty, indicates that user passed entire folder instead
-	if len(watchedFiles) == 0 {
-		watchingFolder = true
-
-		// validate that watchDirs only has single element
-		if len(watchDirs) > 1 {
-			logger.WarnContext(ctx, "error setting watcher, expected single tools folder if no file(s) are defined.")
-			return
-		}
-
-		for onlyKey := range watchDirs {
-			folderToWatch = onlyKey
-			break
-		}
-	}
+	watchingFolder := opts.toolsFolder != ""
+	folderToWatch := filepath.Clean(opts.toolsFolder)
 
+	watchDirs, watchedFiles := resolveWatcherInputs(opts.toolsFolder, files)
 	for dir := range watchDirs {
 		err := w.Add(dir)
 		if err != nil {
@@ -712,7 +801,7 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 			cleanedFilename := filepath.Clean(e.Name)
 			logger.DebugContext(ctx, fmt.Sprintf("%s event detected in %s", e.Op, cleanedFilename))
 
-			folderChanged := watchingFolder &&
+			folderChanged := watchingFolder && filepath.Dir(cleanedFilename) == folderToWatch &&
 				(strings.HasSuffix(cleanedFilename, ".yaml") || strings.HasSuffix(cleanedFilename, ".yml"))
 
 			if folderChanged || watchedFiles[cleanedFilename] {
@@ -720,24 +809,47 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 				debounce.Reset(debounceDelay)
 			}
 
+		case <-secretChanges:
+			debounce.Reset(debounceDelay)
+
 		case <-debounce.C:
 			debounce.Stop()
-			var reloadedToolsFile ToolsFile
 
 			if watchingFolder {
 				logger.DebugContext(ctx, "Reloading tools folder.")
-				reloadedToolsFile, err = loadAndMergeToolsFolder(ctx, folderToWatch)
-				if err != nil {
-					logger.WarnContext(ctx, "error loading tools folder %s", err)
-					continue
-				}
 			} else {
 				logger.DebugContext(ctx, "Reloading tools file(s).")
-				reloadedToolsFile, err = loadAndMergeToolsFiles(ctx, slices.Collect(maps.Keys(watchedFiles)))
-				if err != nil {
-					logger.WarnContext(ctx, "error loading tools files %s", err)
+			}
+			paths, err := opts.resolvePaths()
+			if err != nil {
+				logger.WarnContext(ctx, "error loading tools files %s", err)
+				continue
+			}
+			files, err := loadToolsFiles(ctx, prebuilts, paths)
+			if err != nil {
+				logger.WarnContext(ctx, "error loading tools files %s", err)
+				continue
+			}
+
+			// the files included may have changed
+			var newDirs map[string]bool
+			newDirs, watchedFiles = resolveWatcherInputs(opts.toolsFolder, files)
+			for dir := range newDirs {
+				if watchDirs[dir] {
+					continue
+				}
+				if err := w.Add(dir); err != nil {
+					logger.WarnContext(ctx, fmt.Sprintf("Error adding path %s to watcher: %s", dir, err))
 					continue
 				}
+				watchDirs[dir] = true
+				logger.DebugContext(ctx, fmt.Sprintf("Added directory %s to watcher.", dir))
+			}
+
+			reloadedToolsFile, err := mergeLoadedFiles(files)
+			if err != nil {
+				logger.WarnContext(ctx, "error loading tools files %s", err)
+				continue
 			}
 
 			err = handleDynamicReload(ctx, reloadedToolsFile, s)
@@ -750,26 +862,26 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 	}
 }
 
-func resolveWatcherInputs(toolsFile string, toolsFiles []string, toolsFolder string) (map[string]bool, map[string]bool) {
-	var relevantFiles []string
-
+// resolveWatcherInputs returns the directories to watch, and the files in
+// them that trigger a reload. These are the loaded tool files, including the
+// files they include, and the tools folder, if any.
+func resolveWatcherInputs(toolsFolder string, files []loadedFile) (map[string]bool, map[string]bool) {
 	// map for efficiently checking if a file is relevant
 	watchedFiles := make(map[string]bool)
 
 	// dirs that will be added to watcher (fsnotify prefers watching directory then filtering for file)
 	watchDirs := make(map[string]bool)
 
-	if len(toolsFiles) > 0 {
-		relevantFiles = toolsFiles
-	} else if toolsFolder != "" {
+	if toolsFolder != "" {
 		watchDirs[filepath.Clean(toolsFolder)] = true
-	} else {
-		relevantFiles = []string{toolsFile}
 	}
 
 	// extract parent dir for relevant files and dedup
-	for _, f := range relevantFiles {
-		cleanFile := filepath.Clean(f)
+	for _, f := range files {
+		if f.isPrebuilt() {
+			continue
+		}
+		cleanFile := filepath.Clean(f.path)
 		watchedFiles[cleanFile] = true
 		watchDirs[filepath.Dir(cleanFile)] = true
 	}
@@ -827,6 +939,10 @@ func run(cmd *Command) error {
 
 	ctx = util.WithLogger(ctx, cmd.logger)
 
+	// secrets are cached so they can be refreshed together
+	resolver := secrets.NewResolver(secrets.Providers())
+	ctx = secrets.WithResolver(ctx, resolver)
+
 	// Set up OpenTelemetry
 	otelShutdown, err := telemetry.SetupOTel(ctx, cmd.cfg.Version, cmd.cfg.TelemetryOTLP, cmd.cfg.TelemetryGCP, cmd.cfg.TelemetryServiceName)
 	if err != nil {
@@ -842,27 +958,21 @@ func run(cmd *Command) error {
 		}
 	}()
 
-	var allToolsFiles []ToolsFile
-
-	// Load Prebuilt Configuration
-	if cmd.prebuiltConfig != "" {
-		buf, err := prebuiltconfigs.Get(cmd.prebuiltConfig)
-		if err != nil {
-			cmd.logger.ErrorContext(ctx, err.Error())
-			return err
+	// Load Prebuilt Configurations
+	prebuilts, err := loadPrebuilts(cmd.prebuiltConfigs)
+	if err != nil {
+		cmd.logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if len(prebuilts) > 0 {
+		var names []string
+		for _, p := range prebuilts {
+			names = append(names, p.name)
 		}
-		logMsg := fmt.Sprint("Using prebuilt tool configuration for ", cmd.prebuiltConfig)
+		logMsg := fmt.Sprint("Using prebuilt tool configuration for ", strings.Join(names, ", "))
 		cmd.logger.InfoContext(ctx, logMsg)
 		// Append prebuilt.source to Version string for the User Agent
-		cmd.cfg.Version += "+prebuilt." + cmd.prebuiltConfig
-
-		parsed, err := parseToolsFile(ctx, buf)
-		if err != nil {
-			errMsg := fmt.Errorf("unable to parse prebuilt tool configuration: %w", err)
-			cmd.logger.ErrorContext(ctx, errMsg.Error())
-			return errMsg
-		}
-		allToolsFiles = append(allToolsFiles, parsed)
+		cmd.cfg.Version += "+prebuilt." + strings.Join(names, ".")
 	}
 
 	// Determine if Custom Files should be loaded
@@ -870,83 +980,53 @@ func run(cmd *Command) error {
 	isCustomConfigured := cmd.tools_file != "" || len(cmd.tools_files) > 0 || cmd.tools_folder != ""
 
 	// Determine if default 'tools.yaml' should be used (No prebuilt AND No custom flags)
-	useDefaultToolsFile := cmd.prebuiltConfig == "" && !isCustomConfigured
+	useDefaultToolsFile := len(prebuilts) == 0 && !isCustomConfigured
 
 	if useDefaultToolsFile {
 		cmd.tools_file = "tools.yaml"
 		isCustomConfigured = true
 	}
 
-	// Load Custom Configurations
-	if isCustomConfigured {
-		// Enforce exclusivity among custom flags (tools-file vs tools-files vs tools-folder)
-		if (cmd.tools_file != "" && len(cmd.tools_files) > 0) ||
-			(cmd.tools_file != "" && cmd.tools_folder != "") ||
-			(len(cmd.tools_files) > 0 && cmd.tools_folder != "") {
-			errMsg := fmt.Errorf("--tools-file, --tools-files, and --tools-folder flags cannot be used simultaneously")
-			cmd.logger.ErrorContext(ctx, errMsg.Error())
-			return errMsg
-		}
-
-		var customTools ToolsFile
-		var err error
-
-		if len(cmd.tools_files) > 0 {
-			// Use tools-files
-			cmd.logger.InfoContext(ctx, fmt.Sprintf("Loading and merging %d tool configuration files", len(cmd.tools_files)))
-			customTools, err = loadAndMergeToolsFiles(ctx, cmd.tools_files)
-		} else if cmd.tools_folder != "" {
-			// Use tools-folder
-			cmd.logger.InfoContext(ctx, fmt.Sprintf("Loading and merging all YAML files from directory: %s", cmd.tools_folder))
-			customTools, err = loadAndMergeToolsFolder(ctx, cmd.tools_folder)
-		} else {
-			// Use single file (tools-file or default `tools.yaml`)
-			buf, readFileErr := os.ReadFile(cmd.tools_file)
-			if readFileErr != nil {
-				errMsg := fmt.Errorf("unable to read tool file at %q: %w", cmd.tools_file, readFileErr)
-				cmd.logger.ErrorContext(ctx, errMsg.Error())
-				return errMsg
-			}
-			customTools, err = parseToolsFile(ctx, buf)
-			if err != nil {
-				err = fmt.Errorf("unable to parse tool file at %q: %w", cmd.tools_file, err)
-			}
-		}
-
-		if err != nil {
-			cmd.logger.ErrorContext(ctx, err.Error())
-			return err
-		}
-		allToolsFiles = append(allToolsFiles, customTools)
+	// Load and merge everything. Prebuilt configurations are loaded first, so
+	// that custom files can override their resources. This will error if
+	// custom tools collide with prebuilt tools
+	opts := toolsFileOptions{
+		toolsFile:       cmd.tools_file,
+		toolsFiles:      cmd.tools_files,
+		toolsFolder:     cmd.tools_folder,
+		prebuiltConfigs: cmd.prebuiltConfigs,
 	}
-
-	// Merge Everything
-	// This will error if custom tools collide with prebuilt tools
-	finalToolsFile, err := mergeToolsFiles(allToolsFiles...)
+	paths, err := opts.resolvePaths()
+	if err != nil {
+		cmd.logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if len(cmd.tools_files) > 0 {
+		cmd.logger.InfoContext(ctx, fmt.Sprintf("Loading and merging %d tool configuration files", len(cmd.tools_files)))
+	} else if cmd.tools_folder != "" {
+		cmd.logger.InfoContext(ctx, fmt.Sprintf("Loading and merging all YAML files from directory: %s", cmd.tools_folder))
+	}
+	files, err := loadToolsFiles(ctx, prebuilts, paths)
+	if err != nil {
+		cmd.logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+	finalToolsFile, err := mergeLoadedFiles(files)
 	if err != nil {
 		cmd.logger.ErrorContext(ctx, err.Error())
 		return err
 	}
+	if finalToolsFile.AuthSources != nil {
+		cmd.logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` instead")
+	}
 
 	cmd.cfg.SourceConfigs = finalToolsFile.Sources
 	cmd.cfg.AuthServiceConfigs = finalToolsFile.AuthServices
+	cmd.cfg.EmbeddingModelConfigs = finalToolsFile.EmbeddingModels
 	cmd.cfg.ToolConfigs = finalToolsFile.Tools
 	cmd.cfg.ToolsetConfigs = finalToolsFile.Toolsets
 	cmd.cfg.PromptConfigs = finalToolsFile.Prompts
-
-	authSourceConfigs := finalToolsFile.AuthSources
-	if authSourceConfigs != nil {
-		cmd.logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` instead")
-
-		for k, v := range authSourceConfigs {
-			if _, exists := cmd.cfg.AuthServiceConfigs[k]; exists {
-				errMsg := fmt.Errorf("resource conflict detected: authSource '%s' has the same name as an existing authService. Please rename your authSource", k)
-				cmd.logger.ErrorContext(ctx, errMsg.Error())
-				return errMsg
-			}
-			cmd.cfg.AuthServiceConfigs[k] = v
-		}
-	}
+	cmd.cfg.PromptsetConfigs = finalToolsFile.Promptsets
 
 	instrumentation, err := telemetry.CreateTelemetryInstrumentation(versionString)
 	if err != nil {
@@ -996,10 +1076,15 @@ func run(cmd *Command) error {
 		}()
 	}
 
+	var secretChanges <-chan struct{}
+	if cmd.secretRefreshInterval > 0 {
+		secretChanges = watchSecrets(ctx, resolver, cmd.secretRefreshInterval)
+	}
 	if isCustomConfigured && !cmd.cfg.DisableReload {
-		watchDirs, watchedFiles := resolveWatcherInputs(cmd.tools_file, cmd.tools_files, cmd.tools_folder)
 		// start watching the file(s) or folder for changes to trigger dynamic reloading
-		go watchChanges(ctx, watchDirs, watchedFiles, s)
+		go watchChanges(ctx, opts, prebuilts, files, s, secretChanges)
+	} else if secretChanges != nil {
+		go reloadOnSecretChanges(ctx, prebuilts, paths, files, s, secretChanges)
 	}
 
 	// wait for either the server to error out or the command's context to be canceled
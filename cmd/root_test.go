@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -36,6 +37,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/prebuiltconfigs"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/prompts/custom"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	cloudsqlpgsrc "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
@@ -45,6 +47,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/tools/http"
 	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgressql"
 	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/cache"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 	"github.com/spf13/cobra"
 )
@@ -284,7 +287,7 @@ func TestParseEnv(t *testing.T) {
 					t.Setenv(k, v)
 				}
 			}
-			got, err := parseEnv(tc.in)
+			got, err := parseEnv(context.Background(), tc.in)
 			if tc.err {
 				if err == nil {
 					t.Fatalf("expected error not found")
@@ -300,6 +303,66 @@ func TestParseEnv(t *testing.T) {
 	}
 }
 
+type fakeSecretProvider map[string]string
+
+func (p fakeSecretProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	if v, ok := p[ref]; ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("secret %q not found", ref)
+}
+
+func TestParseEnvSecrets(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(secretFile, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatalf("unable to write secret file: %s", err)
+	}
+	ctx := secrets.WithResolver(context.Background(), secrets.NewResolver(map[string]secrets.Provider{
+		secrets.FileScheme:   secrets.FileProvider{},
+		secrets.SecretScheme: fakeSecretProvider{"gcp/projects/p/secrets/user": "admin"},
+	}))
+
+	tcs := []struct {
+		desc      string
+		in        string
+		want      string
+		errString string
+	}{
+		{
+			desc: "file",
+			in:   fmt.Sprintf("password: ${file:%s}", secretFile),
+			want: "password: s3cret",
+		},
+		{
+			desc: "secret manager",
+			in:   "user: ${secret:gcp/projects/p/secrets/user}",
+			want: "user: admin",
+		},
+		{
+			desc:      "missing secret",
+			in:        "user: ${secret:gcp/projects/p/secrets/missing}",
+			errString: `unable to resolve ${secret:gcp/projects/p/secrets/missing}: secret "gcp/projects/p/secrets/missing" not found`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseEnv(ctx, tc.in)
+			if tc.errString != "" {
+				if err == nil || err.Error() != tc.errString {
+					t.Fatalf("incorrect error: got %v, want %s", err, tc.errString)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected result: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestToolFileFlag(t *testing.T) {
 	tcs := []struct {
 		desc string
@@ -556,6 +619,70 @@ func TestParseToolFile(t *testing.T) {
 				Prompts: nil,
 			},
 		},
+		{
+			description: "with tool cache",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-sql
+					source: my-pg-instance
+					description: some description
+					statement: |
+						SELECT * FROM SQL_STATEMENT;
+					cache:
+						ttl: 30s
+						maxSize: 50
+			`,
+			wantToolsFile: ToolsFile{
+				Tools: server.ToolConfigs{
+					"example_tool": tools.CachedToolConfig{
+						ToolConfig: postgressql.Config{
+							Name:         "example_tool",
+							Kind:         "postgres-sql",
+							Source:       "my-pg-instance",
+							Description:  "some description",
+							Statement:    "SELECT * FROM SQL_STATEMENT;\n",
+							AuthRequired: []string{},
+						},
+						Cache: cache.Config{TTL: "30s", MaxSize: 50},
+					},
+				},
+			},
+		},
+		{
+			description: "with tool rules",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-sql
+					source: my-pg-instance
+					description: some description
+					statement: |
+						SELECT * FROM SQL_STATEMENT;
+					rules:
+						- exactlyOneOf: [id, email]
+						- check: "{{ before .start .end }}"
+						  message: start must be before end
+			`,
+			wantToolsFile: ToolsFile{
+				Tools: server.ToolConfigs{
+					"example_tool": tools.RuledToolConfig{
+						ToolConfig: postgressql.Config{
+							Name:         "example_tool",
+							Kind:         "postgres-sql",
+							Source:       "my-pg-instance",
+							Description:  "some description",
+							Statement:    "SELECT * FROM SQL_STATEMENT;\n",
+							AuthRequired: []string{},
+						},
+						Rules: []parameters.Rule{
+							{ExactlyOneOf: []string{"id", "email"}},
+							{Check: "{{ before .start .end }}", Message: "start must be before end"},
+						},
+					},
+				},
+			},
+		},
 		{
 			description: "with prompts example",
 			in: `
@@ -567,6 +694,9 @@ func TestParseToolFile(t *testing.T) {
                           description: The country to analyze.
                     messages:
                         - content: Analyze the data for {{.country}}.
+            promptsets:
+                analysis:
+                    - my-prompt
             `,
 			wantToolsFile: ToolsFile{
 				Sources:      nil,
@@ -585,6 +715,9 @@ func TestParseToolFile(t *testing.T) {
 						},
 					},
 				},
+				Promptsets: server.PromptsetConfigs{
+					"analysis": prompts.PromptsetConfig{Name: "analysis", PromptNames: []string{"my-prompt"}},
+				},
 			},
 		},
 	}
@@ -609,6 +742,9 @@ func TestParseToolFile(t *testing.T) {
 			if diff := cmp.Diff(tc.wantToolsFile.Prompts, toolsFile.Prompts); diff != "" {
 				t.Fatalf("incorrect prompts parse: diff %v", diff)
 			}
+			if diff := cmp.Diff(tc.wantToolsFile.Promptsets, toolsFile.Promptsets); diff != "" {
+				t.Fatalf("incorrect promptsets parse: diff %v", diff)
+			}
 		})
 	}
 
@@ -1156,33 +1292,26 @@ func normalizeFilepaths(m map[string]bool) map[string]bool {
 func TestResolveWatcherInputs(t *testing.T) {
 	tcs := []struct {
 		description      string
-		toolsFile        string
-		toolsFiles       []string
+		files            []string
 		toolsFolder      string
 		wantWatchDirs    map[string]bool
 		wantWatchedFiles map[string]bool
 	}{
 		{
 			description:      "single tools file",
-			toolsFile:        "tools_folder/example_tools.yaml",
-			toolsFiles:       []string{},
-			toolsFolder:      "",
+			files:            []string{"tools_folder/example_tools.yaml"},
 			wantWatchDirs:    map[string]bool{"tools_folder": true},
 			wantWatchedFiles: map[string]bool{"tools_folder/example_tools.yaml": true},
 		},
 		{
 			description:      "default tools file (root dir)",
-			toolsFile:        "tools.yaml",
-			toolsFiles:       []string{},
-			toolsFolder:      "",
+			files:            []string{"tools.yaml"},
 			wantWatchDirs:    map[string]bool{".": true},
 			wantWatchedFiles: map[string]bool{"tools.yaml": true},
 		},
 		{
 			description:   "multiple files in different folders",
-			toolsFile:     "",
-			toolsFiles:    []string{"tools_folder/example_tools.yaml", "tools_folder2/example_tools.yaml"},
-			toolsFolder:   "",
+			files:         []string{"tools_folder/example_tools.yaml", "tools_folder2/example_tools.yaml"},
 			wantWatchDirs: map[string]bool{"tools_folder": true, "tools_folder2": true},
 			wantWatchedFiles: map[string]bool{
 				"tools_folder/example_tools.yaml":  true,
@@ -1191,9 +1320,7 @@ func TestResolveWatcherInputs(t *testing.T) {
 		},
 		{
 			description:   "multiple files in same folder",
-			toolsFile:     "",
-			toolsFiles:    []string{"tools_folder/example_tools.yaml", "tools_folder/example_tools2.yaml"},
-			toolsFolder:   "",
+			files:         []string{"tools_folder/example_tools.yaml", "tools_folder/example_tools2.yaml"},
 			wantWatchDirs: map[string]bool{"tools_folder": true},
 			wantWatchedFiles: map[string]bool{
 				"tools_folder/example_tools.yaml":  true,
@@ -1202,11 +1329,9 @@ func TestResolveWatcherInputs(t *testing.T) {
 		},
 		{
 			description: "multiple files in different levels",
-			toolsFile:   "",
-			toolsFiles: []string{
+			files: []string{
 				"tools_folder/example_tools.yaml",
 				"tools_folder/special_tools/example_tools2.yaml"},
-			toolsFolder:   "",
 			wantWatchDirs: map[string]bool{"tools_folder": true, "tools_folder/special_tools": true},
 			wantWatchedFiles: map[string]bool{
 				"tools_folder/example_tools.yaml":                true,
@@ -1215,16 +1340,34 @@ func TestResolveWatcherInputs(t *testing.T) {
 		},
 		{
 			description:      "tools folder",
-			toolsFile:        "",
-			toolsFiles:       []string{},
 			toolsFolder:      "tools_folder",
 			wantWatchDirs:    map[string]bool{"tools_folder": true},
 			wantWatchedFiles: map[string]bool{},
 		},
+		{
+			description:   "tools folder with an included file",
+			files:         []string{"shared/base.yaml", "tools_folder/example_tools.yaml"},
+			toolsFolder:   "tools_folder",
+			wantWatchDirs: map[string]bool{"tools_folder": true, "shared": true},
+			wantWatchedFiles: map[string]bool{
+				"shared/base.yaml":                true,
+				"tools_folder/example_tools.yaml": true,
+			},
+		},
+		{
+			description:      "prebuilt configurations are not watched",
+			files:            []string{prebuiltPathPrefix + "postgres", "tools.yaml"},
+			wantWatchDirs:    map[string]bool{".": true},
+			wantWatchedFiles: map[string]bool{"tools.yaml": true},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
-			gotWatchDirs, gotWatchedFiles := resolveWatcherInputs(tc.toolsFile, tc.toolsFiles, tc.toolsFolder)
+			var files []loadedFile
+			for _, f := range tc.files {
+				files = append(files, loadedFile{path: f})
+			}
+			gotWatchDirs, gotWatchedFiles := resolveWatcherInputs(tc.toolsFolder, files)
 
 			normalizedGotWatchDirs := normalizeFilepaths(gotWatchDirs)
 			normalizedGotWatchedFiles := normalizeFilepaths(gotWatchedFiles)
@@ -1240,6 +1383,30 @@ func TestResolveWatcherInputs(t *testing.T) {
 	}
 }
 
+func TestResolveWatcherInputsIncludes(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dir := writeToolsFiles(t, map[string]string{
+		"shared/sources.yaml": "sources:\n  my-sqlite:\n    kind: sqlite\n    database: dev.db\n",
+		"conf/tools.yaml":     "include: [../shared/sources.yaml]\n",
+	})
+	files, err := (&toolsFileOptions{toolsFile: filepath.Join(dir, "conf", "tools.yaml")}).loadFiles(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	watchDirs, watchedFiles := resolveWatcherInputs("", files)
+	included := filepath.Join(dir, "shared", "sources.yaml")
+	if !watchedFiles[included] {
+		t.Errorf("included file %q is not watched: %v", included, watchedFiles)
+	}
+	if !watchDirs[filepath.Dir(included)] {
+		t.Errorf("directory of included file %q is not watched: %v", included, watchDirs)
+	}
+}
+
 // helper function for testing file detection in dynamic reloading
 func tmpFileWithCleanup(content []byte) (string, func(), error) {
 	f, err := os.CreateTemp("", "*")
@@ -1290,10 +1457,10 @@ func TestSingleEdit(t *testing.T) {
 	cleanFileToWatch := filepath.Clean(fileToWatch)
 	watchDir := filepath.Dir(cleanFileToWatch)
 
-	watchedFiles := map[string]bool{cleanFileToWatch: true}
-	watchDirs := map[string]bool{watchDir: true}
+	opts := toolsFileOptions{toolsFile: cleanFileToWatch}
+	files := []loadedFile{{path: cleanFileToWatch}}
 
-	go watchChanges(ctx, watchDirs, watchedFiles, mockServer)
+	go watchChanges(ctx, opts, nil, files, mockServer, nil)
 
 	// escape backslash so regex doesn't fail on windows filepaths
 	regexEscapedPathFile := strings.ReplaceAll(cleanFileToWatch, `\`, `\\\\*\\`)
@@ -1838,6 +2005,7 @@ func TestMergeToolsFiles(t *testing.T) {
 		AuthServices: server.AuthServiceConfigs{"auth1": google.Config{Name: "auth1"}},
 		Tools:        server.ToolConfigs{"tool2": http.Config{Name: "tool2"}},
 		Toolsets:     server.ToolsetConfigs{"set2": tools.ToolsetConfig{Name: "set2"}},
+		Promptsets:   server.PromptsetConfigs{"pset1": prompts.PromptsetConfig{Name: "pset1"}},
 	}
 	fileWithConflicts := ToolsFile{
 		Sources: server.SourceConfigs{"source1": httpsrc.Config{Name: "source1"}},
@@ -1859,6 +2027,7 @@ func TestMergeToolsFiles(t *testing.T) {
 				Tools:        server.ToolConfigs{"tool1": http.Config{Name: "tool1"}, "tool2": http.Config{Name: "tool2"}},
 				Toolsets:     server.ToolsetConfigs{"set1": tools.ToolsetConfig{Name: "set1"}, "set2": tools.ToolsetConfig{Name: "set2"}},
 				Prompts:      server.PromptConfigs{},
+				Promptsets:   server.PromptsetConfigs{"pset1": prompts.PromptsetConfig{Name: "pset1"}},
 			},
 			wantErr: false,
 		},
@@ -1876,6 +2045,7 @@ func TestMergeToolsFiles(t *testing.T) {
 				Tools:        file1.Tools,
 				Toolsets:     file1.Toolsets,
 				Prompts:      server.PromptConfigs{},
+				Promptsets:   server.PromptsetConfigs{},
 			},
 		},
 		{
@@ -1887,6 +2057,7 @@ func TestMergeToolsFiles(t *testing.T) {
 				Tools:        make(server.ToolConfigs),
 				Toolsets:     make(server.ToolsetConfigs),
 				Prompts:      server.PromptConfigs{},
+				Promptsets:   server.PromptsetConfigs{},
 			},
 		},
 	}
@@ -1996,6 +2167,18 @@ toolsets:
 		t.Fatal(err)
 	}
 
+	// Override File
+	// Disables the 'list_tables' tool of the SQLite prebuilt
+	overrideContent := `
+overrides:
+  tools:
+    list_tables: null
+`
+	overrideFile := filepath.Join(t.TempDir(), "override.yaml")
+	if err := os.WriteFile(overrideFile, []byte(overrideContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
 	//Legacy Auth File
 	authContent := `
 authSources:
@@ -2047,6 +2230,20 @@ authSources:
 			wantErr:   true,
 			errString: "resource conflicts detected",
 		},
+		{
+			desc:    "prebuilt tool disabled",
+			args:    []string{"--prebuilt", "sqlite", "--tools-file", overrideFile},
+			wantErr: false,
+			cfgCheck: func(cfg server.ServerConfig) error {
+				if _, ok := cfg.ToolConfigs["list_tables"]; ok {
+					return fmt.Errorf("disabled prebuilt tool 'list_tables' found")
+				}
+				if slices.Contains(cfg.ToolsetConfigs["sqlite_database_tools"].ToolNames, "list_tables") {
+					return fmt.Errorf("disabled prebuilt tool 'list_tables' found in toolset")
+				}
+				return nil
+			},
+		},
 		{
 			desc:    "legacy auth additive",
 			args:    []string{"--prebuilt", "sqlite", "--tools-file", authFile},
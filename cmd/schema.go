@@ -0,0 +1,61 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/googleapis/genai-toolbox/internal/configschema"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+)
+
+func newSchemaCommand() *cobra.Command {
+	var outputFile string
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print a JSON Schema of tool configuration files",
+		Long: "Print a JSON Schema of tool configuration files.\n\n" +
+			"The schema covers every source, tool, auth service, and prompt kind built into " +
+			"this version of Toolbox, and can be used by editors to validate and complete " +
+			"tools.yaml.",
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			logger, err := log.NewStdLogger(c.ErrOrStderr(), c.ErrOrStderr(), "WARN")
+			if err != nil {
+				return fmt.Errorf("unable to initialize logger: %w", err)
+			}
+			ctx := util.WithLogger(c.Context(), logger)
+			out, err := json.MarshalIndent(configschema.Generate(ctx), "", "  ")
+			if err != nil {
+				return fmt.Errorf("unable to marshal schema: %w", err)
+			}
+			out = append(out, '\n')
+			if outputFile == "" {
+				_, err = c.OutOrStdout().Write(out)
+				return err
+			}
+			if err := os.WriteFile(outputFile, out, 0o644); err != nil {
+				return fmt.Errorf("unable to write %q: %w", outputFile, err)
+			}
+			return nil
+		},
+	}
+	schemaCmd.Flags().StringVar(&outputFile, "output-file", "", "File to write the schema to. Defaults to stdout.")
+	return schemaCmd
+}
@@ -0,0 +1,526 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/prebuiltconfigs"
+	"github.com/spf13/cobra"
+)
+
+// toolsFileOptions are the flags that select tool configuration files for
+// subcommands, which follow the same rules as the server.
+type toolsFileOptions struct {
+	toolsFile       string
+	toolsFiles      []string
+	toolsFolder     string
+	prebuiltConfigs []string
+}
+
+func (o *toolsFileOptions) addFlags(c *cobra.Command) {
+	flags := c.Flags()
+	flags.StringVar(&o.toolsFile, "tools-file", "", "File path specifying the tool configuration. Cannot be used with --tools-files, or --tools-folder.")
+	flags.StringSliceVar(&o.toolsFiles, "tools-files", []string{}, "Multiple file paths specifying tool configurations. Cannot be used with --tools-file, or --tools-folder.")
+	flags.StringVar(&o.toolsFolder, "tools-folder", "", "Directory path containing YAML tool configuration files. Cannot be used with --tools-file, or --tools-files.")
+	flags.StringSliceVar(&o.prebuiltConfigs, "prebuilt", []string{}, "Use prebuilt tool configurations by source type, along with any tool files.")
+}
+
+// resolvePaths returns the tool files to load, defaulting to tools.yaml when
+// neither files nor a prebuilt configuration are given.
+func (o *toolsFileOptions) resolvePaths() ([]string, error) {
+	if (o.toolsFile != "" && len(o.toolsFiles) > 0) ||
+		(o.toolsFile != "" && o.toolsFolder != "") ||
+		(len(o.toolsFiles) > 0 && o.toolsFolder != "") {
+		return nil, fmt.Errorf("--tools-file, --tools-files, and --tools-folder flags cannot be used simultaneously")
+	}
+	switch {
+	case len(o.toolsFiles) > 0:
+		return o.toolsFiles, nil
+	case o.toolsFolder != "":
+		info, err := os.Stat(o.toolsFolder)
+		if err != nil {
+			return nil, fmt.Errorf("unable to access tools folder at %q: %w", o.toolsFolder, err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("path %q is not a directory", o.toolsFolder)
+		}
+		yamlFiles, err := filepath.Glob(filepath.Join(o.toolsFolder, "*.yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("error finding YAML files in %q: %w", o.toolsFolder, err)
+		}
+		ymlFiles, err := filepath.Glob(filepath.Join(o.toolsFolder, "*.yml"))
+		if err != nil {
+			return nil, fmt.Errorf("error finding YML files in %q: %w", o.toolsFolder, err)
+		}
+		allFiles := append(yamlFiles, ymlFiles...)
+		if len(allFiles) == 0 {
+			return nil, fmt.Errorf("no YAML files found in directory %q", o.toolsFolder)
+		}
+		return allFiles, nil
+	case o.toolsFile != "":
+		return []string{o.toolsFile}, nil
+	case len(o.prebuiltConfigs) > 0:
+		return nil, nil
+	default:
+		return []string{"tools.yaml"}, nil
+	}
+}
+
+// load parses and merges the selected tool files, folding the deprecated
+// authSources into authServices.
+func (o *toolsFileOptions) load(ctx context.Context) (ToolsFile, error) {
+	files, err := o.loadFiles(ctx)
+	if err != nil {
+		return ToolsFile{}, err
+	}
+	return mergeLoadedFiles(files)
+}
+
+// loadFiles loads the prebuilt configurations, if any, and then the tool
+// files, so that overrides of tool files can patch prebuilt resources.
+func (o *toolsFileOptions) loadFiles(ctx context.Context) ([]loadedFile, error) {
+	paths, err := o.resolvePaths()
+	if err != nil {
+		return nil, err
+	}
+	prebuilts, err := loadPrebuilts(o.prebuiltConfigs)
+	if err != nil {
+		return nil, err
+	}
+	return loadToolsFiles(ctx, prebuilts, paths)
+}
+
+// loadToolsFiles loads prebuilt configurations and tool files, along with
+// the files they include, and applies their overrides.
+func loadToolsFiles(ctx context.Context, prebuilts []prebuiltFile, paths []string) ([]loadedFile, error) {
+	l := &toolsFileLoader{loaded: make(map[string]bool)}
+	return l.loadAll(ctx, prebuilts, paths)
+}
+
+// reparseToolsFiles loads the same files as loadToolsFiles did from the
+// contents read then, so that variables and secrets are replaced again without
+// picking up edits to the files.
+func reparseToolsFiles(ctx context.Context, prebuilts []prebuiltFile, paths []string, files []loadedFile) ([]loadedFile, error) {
+	bufs := make(map[string][]byte, len(files))
+	for _, f := range files {
+		bufs[f.path] = f.buf
+	}
+	readFile := func(path string) ([]byte, error) {
+		buf, ok := bufs[path]
+		if !ok {
+			return nil, fmt.Errorf("file was not loaded at startup")
+		}
+		return buf, nil
+	}
+	l := &toolsFileLoader{loaded: make(map[string]bool), readFile: readFile}
+	return l.loadAll(ctx, prebuilts, paths)
+}
+
+// loadAll adds the prebuilt configurations and then loads the tool files.
+func (l *toolsFileLoader) loadAll(ctx context.Context, prebuilts []prebuiltFile, paths []string) ([]loadedFile, error) {
+	for _, p := range prebuilts {
+		if err := l.addPrebuilt(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range paths {
+		if err := l.load(ctx, path); err != nil {
+			return nil, err
+		}
+	}
+	return l.files, nil
+}
+
+// mergeLoadedFiles merges loaded files, and moves the deprecated authSources
+// into authServices.
+func mergeLoadedFiles(files []loadedFile) (ToolsFile, error) {
+	toolsFiles := make([]ToolsFile, len(files))
+	for i, f := range files {
+		toolsFiles[i] = f.ToolsFile
+	}
+	merged, err := mergeToolsFiles(toolsFiles...)
+	if err != nil {
+		return ToolsFile{}, err
+	}
+	for k, v := range merged.AuthSources {
+		if _, exists := merged.AuthServices[k]; exists {
+			return ToolsFile{}, fmt.Errorf("resource conflict detected: authSource '%s' has the same name as an existing authService. Please rename your authSource", k)
+		}
+		merged.AuthServices[k] = v
+	}
+	return merged, nil
+}
+
+// resourceSections are the sections of a tool file that define resources by
+// name, and so can be overridden.
+var resourceSections = []string{"sources", "authSources", "authServices", "embeddingModels", "tools", "toolsets", "prompts", "promptsets"}
+
+// loadedFile is a parsed tool file, along with the fields of each of its
+// resources as written, by section and name.
+type loadedFile struct {
+	ToolsFile
+	path string
+	raw  map[string]map[string]any
+	// buf is the content of the file as read, before variables are replaced
+	buf []byte
+}
+
+// prebuiltPathPrefix prefixes the names of prebuilt configurations in the
+// paths of loaded files.
+const prebuiltPathPrefix = "prebuilt:"
+
+func (f loadedFile) isPrebuilt() bool {
+	return strings.HasPrefix(f.path, prebuiltPathPrefix)
+}
+
+// prebuiltFile is the tool file of a prebuilt configuration.
+type prebuiltFile struct {
+	name string
+	buf  []byte
+}
+
+// loadPrebuilts returns the tool files of prebuilt configurations in order,
+// so that they can be served together. A resource that more than one of them
+// defines the same way, such as a shared source, is kept in the first only.
+// Resources they define differently are renamed to "<prebuilt>_<name>" in
+// each, along with the references to them.
+func loadPrebuilts(names []string) ([]prebuiltFile, error) {
+	var files []prebuiltFile
+	var docs []map[string]any
+	for _, name := range names {
+		if slices.ContainsFunc(files, func(f prebuiltFile) bool { return f.name == name }) {
+			continue
+		}
+		buf, err := prebuiltconfigs.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		// environment variables are replaced later, as for any tool file
+		var doc map[string]any
+		if err := yaml.Unmarshal(buf, &doc); err != nil {
+			return nil, fmt.Errorf("unable to parse prebuilt tool configuration %q: %w", name, err)
+		}
+		files = append(files, prebuiltFile{name: name, buf: buf})
+		docs = append(docs, doc)
+	}
+
+	changed := make([]bool, len(files))
+	// sources are renamed first, so that tools using renamed sources differ
+	for _, section := range resourceSections {
+		definedBy := make(map[string][]int)
+		for i, doc := range docs {
+			entries, _ := doc[section].(map[string]any)
+			for name := range entries {
+				definedBy[name] = append(definedBy[name], i)
+			}
+		}
+		for name, in := range definedBy {
+			if len(in) < 2 {
+				continue
+			}
+			first := docs[in[0]][section].(map[string]any)[name]
+			same := true
+			for _, i := range in[1:] {
+				if !reflect.DeepEqual(docs[i][section].(map[string]any)[name], first) {
+					same = false
+				}
+			}
+			for j, i := range in {
+				entries := docs[i][section].(map[string]any)
+				switch {
+				case same && j == 0:
+					continue
+				case same:
+					delete(entries, name)
+				default:
+					renamed := files[i].name + "_" + name
+					entries[renamed] = entries[name]
+					delete(entries, name)
+					renameReferences(docs[i], section, name, renamed)
+				}
+				changed[i] = true
+			}
+		}
+	}
+
+	for i := range files {
+		if !changed[i] {
+			continue
+		}
+		buf, err := yaml.MarshalWithOptions(docs[i], yaml.UseLiteralStyleIfMultiline(true))
+		if err != nil {
+			return nil, fmt.Errorf("unable to combine prebuilt tool configuration %q: %w", files[i].name, err)
+		}
+		files[i].buf = buf
+	}
+	return files, nil
+}
+
+// renameReferences updates the references of a prebuilt tool file to a
+// renamed resource.
+func renameReferences(doc map[string]any, section, name, renamed string) {
+	switch section {
+	case "sources":
+		tools, _ := doc["tools"].(map[string]any)
+		for _, tool := range tools {
+			if tool, ok := tool.(map[string]any); ok && tool["source"] == name {
+				tool["source"] = renamed
+			}
+		}
+	case "tools", "prompts":
+		sets, _ := doc[strings.TrimSuffix(section, "s")+"sets"].(map[string]any)
+		for _, set := range sets {
+			items, _ := set.([]any)
+			for i, item := range items {
+				if item == name {
+					items[i] = renamed
+				}
+			}
+		}
+	}
+}
+
+// toolsFileLoader loads tool files in order, loading the files each one
+// includes before it and applying its overrides after it.
+type toolsFileLoader struct {
+	files  []loadedFile
+	loaded map[string]bool
+	// readFile reads tool files, and defaults to os.ReadFile
+	readFile func(string) ([]byte, error)
+	// stack of files being loaded, to detect include cycles
+	stack []string
+}
+
+// addPrebuilt adds a prebuilt configuration. Prebuilt configurations are
+// added before any tool file, so that overrides can patch their resources.
+func (l *toolsFileLoader) addPrebuilt(ctx context.Context, p prebuiltFile) error {
+	toolsFile, raw, err := parseLoadedFile(ctx, p.buf)
+	if err != nil {
+		return fmt.Errorf("unable to parse prebuilt tool configuration %q: %w", p.name, err)
+	}
+	l.files = append(l.files, loadedFile{ToolsFile: toolsFile, path: prebuiltPathPrefix + p.name, raw: raw})
+	return nil
+}
+
+func (l *toolsFileLoader) load(ctx context.Context, filePath string) error {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return fmt.Errorf("unable to resolve tool file path %q: %w", filePath, err)
+	}
+	if i := slices.Index(l.stack, abs); i >= 0 {
+		return fmt.Errorf("include cycle detected: %s", strings.Join(append(l.stack[i:], abs), " -> "))
+	}
+	// a file included more than once is only loaded the first time
+	if l.loaded[abs] {
+		return nil
+	}
+	l.loaded[abs] = true
+
+	readFile := l.readFile
+	if readFile == nil {
+		readFile = os.ReadFile
+	}
+	buf, err := readFile(filePath)
+	if err != nil {
+		return fmt.Errorf("unable to read tool file at %q: %w", filePath, err)
+	}
+	toolsFile, raw, err := parseLoadedFile(ctx, buf)
+	if err != nil {
+		return fmt.Errorf("unable to parse tool file at %q: %w", filePath, err)
+	}
+
+	l.stack = append(l.stack, abs)
+	for _, include := range toolsFile.Include {
+		if err := l.load(ctx, includePath(filePath, include)); err != nil {
+			return err
+		}
+	}
+	l.stack = l.stack[:len(l.stack)-1]
+
+	l.files = append(l.files, loadedFile{ToolsFile: toolsFile, path: filePath, raw: raw, buf: buf})
+	for _, section := range slices.Sorted(maps.Keys(toolsFile.Overrides)) {
+		entries := toolsFile.Overrides[section]
+		for _, name := range slices.Sorted(maps.Keys(entries)) {
+			if err := applyOverride(ctx, l.files, section, name, entries[name]); err != nil {
+				return fmt.Errorf("unable to apply overrides of %q: %w", filePath, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseLoadedFile parses a tool file along with its raw resources. Environment
+// variables and secrets are replaced once for both, so that they agree even if
+// a secret is rotated meanwhile.
+func parseLoadedFile(ctx context.Context, buf []byte) (ToolsFile, map[string]map[string]any, error) {
+	expanded, err := expandToolsFile(ctx, buf)
+	if err != nil {
+		return ToolsFile{}, nil, err
+	}
+	toolsFile, err := unmarshalToolsFile(ctx, expanded)
+	if err != nil {
+		return ToolsFile{}, nil, err
+	}
+	raw, err := rawResources(expanded)
+	if err != nil {
+		return ToolsFile{}, nil, err
+	}
+	return toolsFile, raw, nil
+}
+
+// includePath returns the path of a file included by another.
+func includePath(from, include string) string {
+	if filepath.IsAbs(include) {
+		return include
+	}
+	return filepath.Join(filepath.Dir(from), include)
+}
+
+// rawResources returns the fields of each resource of a tool file, after
+// environment variables are replaced, by section and name.
+func rawResources(buf []byte) (map[string]map[string]any, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(buf, &doc); err != nil {
+		return nil, err
+	}
+	raw := make(map[string]map[string]any)
+	for _, section := range resourceSections {
+		if entries, ok := doc[section].(map[string]any); ok {
+			raw[section] = entries
+		}
+	}
+	return raw, nil
+}
+
+// applyOverride patches the last definition of a resource in files, which is
+// then decoded again. The patch is a JSON merge patch (RFC 7396): mappings are
+// merged recursively, null removes a field, and any other value replaces the
+// field. A null patch removes the resource, and a tool or prompt removed from
+// a prebuilt configuration is also removed from prebuilt toolsets or
+// promptsets, which can't be edited otherwise.
+func applyOverride(ctx context.Context, files []loadedFile, section, name string, patch any) error {
+	if !slices.Contains(resourceSections, section) {
+		return fmt.Errorf("unable to override unknown section %q", section)
+	}
+	kind := strings.TrimSuffix(section, "s")
+	i := len(files) - 1
+	for ; i >= 0; i-- {
+		if _, ok := files[i].raw[section][name]; ok {
+			break
+		}
+	}
+	if i < 0 {
+		return fmt.Errorf("unable to override %s %q: it isn't defined in an earlier file", kind, name)
+	}
+
+	f := &files[i]
+	dst := sectionMap(&f.ToolsFile, section)
+	patched := mergePatch(f.raw[section][name], patch)
+	if patched == nil {
+		delete(f.raw[section], name)
+		if dst.IsValid() && !dst.IsNil() {
+			dst.SetMapIndex(reflect.ValueOf(name), reflect.Value{})
+		}
+		if f.isPrebuilt() {
+			for j := range files {
+				if files[j].isPrebuilt() {
+					dropReferences(&files[j], section, name)
+				}
+			}
+		}
+		return nil
+	}
+
+	b, err := yaml.Marshal(map[string]any{section: map[string]any{name: patched}})
+	if err != nil {
+		return fmt.Errorf("unable to override %s %q: %w", kind, name, err)
+	}
+	var partial ToolsFile
+	if err := yaml.UnmarshalContext(ctx, b, &partial, yaml.Strict()); err != nil {
+		return fmt.Errorf("unable to override %s %q: %w", kind, name, err)
+	}
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMap(dst.Type()))
+	}
+	dst.SetMapIndex(reflect.ValueOf(name), sectionMap(&partial, section).MapIndex(reflect.ValueOf(name)))
+	f.raw[section][name] = patched
+	return nil
+}
+
+// dropReferences removes a removed tool or prompt from the toolsets or
+// promptsets of a file.
+func dropReferences(f *loadedFile, section, name string) {
+	switch section {
+	case "tools":
+		for setName, set := range f.Toolsets {
+			set.ToolNames = slices.DeleteFunc(slices.Clone(set.ToolNames), func(n string) bool { return n == name })
+			f.Toolsets[setName] = set
+		}
+	case "prompts":
+		for setName, set := range f.Promptsets {
+			set.PromptNames = slices.DeleteFunc(slices.Clone(set.PromptNames), func(n string) bool { return n == name })
+			f.Promptsets[setName] = set
+		}
+	default:
+		return
+	}
+	sets := f.raw[strings.TrimSuffix(section, "s")+"sets"]
+	for setName, set := range sets {
+		if items, ok := set.([]any); ok {
+			sets[setName] = slices.DeleteFunc(slices.Clone(items), func(item any) bool { return item == name })
+		}
+	}
+}
+
+// sectionMap returns the map of a section of a tool file.
+func sectionMap(tf *ToolsFile, section string) reflect.Value {
+	v := reflect.ValueOf(tf).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if tag, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ","); tag == section {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// mergePatch applies a JSON merge patch to a value.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, _ := target.(map[string]any)
+	out := maps.Clone(t)
+	if out == nil {
+		out = make(map[string]any)
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		out[k] = mergePatch(out[k], v)
+	}
+	return out
+}
@@ -0,0 +1,344 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgreslisttables"
+)
+
+func writeToolsFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("unable to create directory for %q: %s", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("unable to write %q: %s", name, err)
+		}
+	}
+	return dir
+}
+
+func TestIncludeAndOverrides(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dir := writeToolsFiles(t, map[string]string{
+		"base/sources.yaml": `
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: dev.db
+  my-other-sqlite:
+    kind: sqlite
+    database: other.db
+`,
+		"base/tools.yaml": `
+include: [sources.yaml]
+tools:
+  search:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: search hotels
+    statement: SELECT * FROM hotels
+toolsets:
+  default: [search]
+`,
+		"prod.yaml": `
+include:
+  - base/tools.yaml
+  - base/sources.yaml
+overrides:
+  sources:
+    my-sqlite:
+      database: prod.db
+    my-other-sqlite: null
+  tools:
+    search:
+      description: search production hotels
+`,
+	})
+
+	got, err := (&toolsFileOptions{toolsFile: filepath.Join(dir, "prod.yaml")}).load(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantSources := server.SourceConfigs{
+		"my-sqlite": sqlite.Config{Name: "my-sqlite", Kind: sqlite.SourceKind, Database: "prod.db"},
+	}
+	if diff := cmp.Diff(wantSources, got.Sources); diff != "" {
+		t.Fatalf("incorrect sources (-want +got):\n%s", diff)
+	}
+	if got := got.Tools["search"].ToolConfigKind(); got != "sqlite-sql" {
+		t.Fatalf("incorrect tool kind: %q", got)
+	}
+	if _, ok := got.Toolsets["default"]; !ok {
+		t.Fatalf("toolset from included file is missing")
+	}
+
+	// files are loaded after the files they include, and only once
+	l := &toolsFileLoader{loaded: make(map[string]bool)}
+	if err := l.load(ctx, filepath.Join(dir, "prod.yaml")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := l.files[1].raw["tools"]["search"].(map[string]any)["description"]; got != "search production hotels" {
+		t.Fatalf("incorrect tool description: %v", got)
+	}
+	if got := len(l.files); got != 3 {
+		t.Fatalf("expected each file to be loaded once, got %d files", got)
+	}
+}
+
+func TestOverridesAcrossToolsFiles(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dir := writeToolsFiles(t, map[string]string{
+		"base.yaml": `
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: dev.db
+`,
+		"prod.yaml": `
+overrides:
+  sources:
+    my-sqlite:
+      database: prod.db
+`,
+	})
+	base, prod := filepath.Join(dir, "base.yaml"), filepath.Join(dir, "prod.yaml")
+
+	got, err := (&toolsFileOptions{toolsFiles: []string{base, prod}}).load(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := sqlite.Config{Name: "my-sqlite", Kind: sqlite.SourceKind, Database: "prod.db"}
+	if diff := cmp.Diff(want, got.Sources["my-sqlite"]); diff != "" {
+		t.Fatalf("incorrect source (-want +got):\n%s", diff)
+	}
+
+	// overrides only apply to files loaded before them
+	_, err = (&toolsFileOptions{toolsFiles: []string{prod, base}}).load(ctx)
+	if err == nil || !strings.Contains(err.Error(), `unable to override source "my-sqlite": it isn't defined in an earlier file`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCombinedPrebuilts(t *testing.T) {
+	t.Setenv("POSTGRES_DATABASE", "db")
+	t.Setenv("POSTGRES_USER", "user")
+	t.Setenv("POSTGRES_PASSWORD", "password")
+	t.Setenv("BIGQUERY_PROJECT", "project")
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dir := writeToolsFiles(t, map[string]string{
+		"tools.yaml": `
+overrides:
+  tools:
+    bigquery_execute_sql: null
+    list_tables:
+      description: Lists our tables.
+`,
+	})
+
+	opts := &toolsFileOptions{
+		toolsFile:       filepath.Join(dir, "tools.yaml"),
+		prebuiltConfigs: []string{"postgres", "bigquery", "cloud-sql-postgres-observability", "cloud-sql-mysql-observability"},
+	}
+	got, err := opts.load(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// tools defined differently by more than one prebuilt are renamed
+	for _, name := range []string{"postgres_execute_sql", "cloud-sql-postgres-observability_get_system_metrics", "cloud-sql-mysql-observability_get_system_metrics"} {
+		if _, ok := got.Tools[name]; !ok {
+			t.Errorf("tool %q not found", name)
+		}
+	}
+	for _, name := range []string{"execute_sql", "bigquery_execute_sql", "get_system_metrics"} {
+		if _, ok := got.Tools[name]; ok {
+			t.Errorf("unexpected tool %q", name)
+		}
+	}
+	if !slices.Contains(got.Toolsets["postgres_database_tools"].ToolNames, "postgres_execute_sql") {
+		t.Errorf("toolset references weren't renamed: %v", got.Toolsets["postgres_database_tools"].ToolNames)
+	}
+	// removed prebuilt tools are removed from prebuilt toolsets
+	if slices.ContainsFunc(got.Toolsets["bigquery_database_tools"].ToolNames, func(n string) bool { return strings.HasSuffix(n, "execute_sql") }) {
+		t.Errorf("removed tool is still in toolset: %v", got.Toolsets["bigquery_database_tools"].ToolNames)
+	}
+	// identical sources are shared
+	if _, ok := got.Sources["cloud-monitoring-source"]; !ok {
+		t.Errorf("shared source not found: %v", slices.Collect(maps.Keys(got.Sources)))
+	}
+	want := postgreslisttables.Config{Name: "list_tables", Kind: "postgres-list-tables", Source: "postgresql-source", Description: "Lists our tables.", AuthRequired: []string{}}
+	if diff := cmp.Diff(want, got.Tools["list_tables"]); diff != "" {
+		t.Fatalf("incorrect tool (-want +got):\n%s", diff)
+	}
+}
+
+func TestToolsFileLoaderErrors(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc  string
+		files map[string]string
+		want  string
+	}{
+		{
+			desc: "include cycle",
+			files: map[string]string{
+				"tools.yaml": "include: [a.yaml]\n",
+				"a.yaml":     "include: [b.yaml]\n",
+				"b.yaml":     "include: [a.yaml]\n",
+			},
+			want: "include cycle detected: ",
+		},
+		{
+			desc: "missing include",
+			files: map[string]string{
+				"tools.yaml": "include: [missing.yaml]\n",
+			},
+			want: "unable to read tool file at",
+		},
+		{
+			desc: "unknown section",
+			files: map[string]string{
+				"tools.yaml": "overrides:\n  widgets:\n    w:\n      size: 1\n",
+			},
+			want: `unable to override unknown section "widgets"`,
+		},
+		{
+			desc: "invalid field",
+			files: map[string]string{
+				"tools.yaml": "sources:\n  my-sqlite:\n    kind: sqlite\n    database: dev.db\noverrides:\n  sources:\n    my-sqlite:\n      host: localhost\n",
+			},
+			want: `unable to override source "my-sqlite"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := writeToolsFiles(t, tc.files)
+			_, err := (&toolsFileOptions{toolsFile: filepath.Join(dir, "tools.yaml")}).load(ctx)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tcs := []struct {
+		desc          string
+		target, patch any
+		want          any
+	}{
+		{
+			desc:   "replace scalar",
+			target: map[string]any{"host": "a", "port": "1"},
+			patch:  map[string]any{"host": "b"},
+			want:   map[string]any{"host": "b", "port": "1"},
+		},
+		{
+			desc:   "remove field",
+			target: map[string]any{"host": "a", "port": "1"},
+			patch:  map[string]any{"port": nil},
+			want:   map[string]any{"host": "a"},
+		},
+		{
+			desc:   "merge nested",
+			target: map[string]any{"headers": map[string]any{"A": "1", "B": "2"}},
+			patch:  map[string]any{"headers": map[string]any{"B": "3", "C": "4"}},
+			want:   map[string]any{"headers": map[string]any{"A": "1", "B": "3", "C": "4"}},
+		},
+		{
+			desc:   "replace list",
+			target: map[string]any{"tools": []any{"a", "b"}},
+			patch:  map[string]any{"tools": []any{"c"}},
+			want:   map[string]any{"tools": []any{"c"}},
+		},
+		{
+			desc:   "remove resource",
+			target: map[string]any{"host": "a"},
+			patch:  nil,
+			want:   nil,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, mergePatch(tc.target, tc.patch)); diff != "" {
+				t.Fatalf("incorrect result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReparseToolsFiles(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Setenv("REPARSE_DB", "dev.db")
+	dir := writeToolsFiles(t, map[string]string{
+		"sources.yaml": "sources:\n  my-sqlite:\n    kind: sqlite\n    database: ${REPARSE_DB}\n",
+		"tools.yaml":   "include: [sources.yaml]\ntoolsets:\n  default: []\n",
+	})
+	paths := []string{filepath.Join(dir, "tools.yaml")}
+	files, err := loadToolsFiles(ctx, nil, paths)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// variables are replaced again, but edits to the files aren't picked up
+	t.Setenv("REPARSE_DB", "prod.db")
+	if err := os.WriteFile(paths[0], []byte("include: [sources.yaml]\ntoolsets:\n  edited: []\n"), 0o644); err != nil {
+		t.Fatalf("unable to edit tools file: %s", err)
+	}
+	reparsed, err := reparseToolsFiles(ctx, nil, paths, files)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := mergeLoadedFiles(reparsed)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if db := got.Sources["my-sqlite"].(sqlite.Config).Database; db != "prod.db" {
+		t.Fatalf("incorrect database: got %q, want %q", db, "prod.db")
+	}
+	if got := slices.Sorted(maps.Keys(got.Toolsets)); !slices.Equal(got, []string{"default"}) {
+		t.Fatalf("incorrect toolsets: %v", got)
+	}
+}
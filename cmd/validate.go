@@ -0,0 +1,690 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/spf13/cobra"
+)
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// diagnostic is a single problem found by `toolbox validate`.
+type diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (d diagnostic) String() string {
+	loc := d.File
+	if d.Line > 0 {
+		loc = fmt.Sprintf("%s:%d:%d", loc, d.Line, d.Column)
+	}
+	return fmt.Sprintf("%s: %s: %s", loc, d.Severity, d.Message)
+}
+
+type validateOptions struct {
+	toolsFileOptions
+	format string
+}
+
+func newValidateCommand() *cobra.Command {
+	opts := &validateOptions{}
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check tool configuration files without connecting to any source",
+		Long: "Check tool configuration files without connecting to any source.\n\n" +
+			"Reports schema errors, unresolved environment variables, duplicate names, and " +
+			"references between resources that can't be resolved. Exits with a non-zero " +
+			"status if any errors are found.",
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			err := runValidate(c, opts)
+			if err != nil {
+				fmt.Fprintf(c.ErrOrStderr(), "Error: %s\n", err)
+			}
+			return err
+		},
+	}
+	opts.addFlags(validateCmd)
+	flags := validateCmd.Flags()
+	flags.StringVar(&opts.format, "format", "text", "Output format of the diagnostics. Allowed: 'text' or 'json'.")
+	return validateCmd
+}
+
+func runValidate(c *cobra.Command, opts *validateOptions) error {
+	if opts.format != "text" && opts.format != "json" {
+		return usageError(fmt.Errorf("format invalid: must be 'text' or 'json'"))
+	}
+	paths, err := opts.resolvePaths()
+	if err != nil {
+		return usageError(err)
+	}
+
+	// decoding may log deprecation warnings, which are diagnostics of their own
+	logger, err := log.NewStdLogger(c.ErrOrStderr(), c.ErrOrStderr(), "WARN")
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	ctx := util.WithLogger(c.Context(), logger)
+	// secret files are read, but secret managers aren't contacted
+	ctx = secrets.WithResolver(ctx, secrets.NewResolver(map[string]secrets.Provider{
+		secrets.FileScheme: secrets.FileProvider{},
+		secrets.SecretScheme: secrets.NewManagerProvider(map[string]secrets.Provider{
+			secrets.GCPManager: secrets.NewGCPSecretManager(offlineSecretAccessor{}),
+		}),
+	}))
+
+	v := &validator{loaded: make(map[string]bool)}
+	prebuilts, err := loadPrebuilts(opts.prebuiltConfigs)
+	if err != nil {
+		return usageError(err)
+	}
+	for _, p := range prebuilts {
+		v.addFile(ctx, prebuiltPathPrefix+p.name, p.buf)
+	}
+	for _, path := range paths {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			v.report(diagnostic{File: path, Severity: severityError, Message: fmt.Sprintf("unable to read tool file: %s", err)})
+			continue
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			if v.loaded[abs] {
+				continue
+			}
+			v.loaded[abs] = true
+		}
+		v.addFile(ctx, path, buf)
+	}
+	v.checkReferences(ctx)
+
+	if err := writeDiagnostics(c.OutOrStdout(), opts.format, v.diagnostics); err != nil {
+		return err
+	}
+	if n := v.errorCount(); n == 1 {
+		return fmt.Errorf("found 1 error in tool configuration")
+	} else if n > 1 {
+		return fmt.Errorf("found %d errors in tool configuration", n)
+	}
+	return nil
+}
+
+func writeDiagnostics(w io.Writer, format string, diags []diagnostic) error {
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].File != diags[j].File {
+			return diags[i].File < diags[j].File
+		}
+		if diags[i].Line != diags[j].Line {
+			return diags[i].Line < diags[j].Line
+		}
+		return diags[i].Message < diags[j].Message
+	})
+	if format == "json" {
+		if diags == nil {
+			diags = []diagnostic{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diags)
+	}
+	for _, d := range diags {
+		if _, err := fmt.Fprintln(w, d.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// location is where a resource is defined.
+type location struct {
+	file  string
+	entry *ast.MappingValueNode
+}
+
+// validator collects the diagnostics of a set of tool files.
+type validator struct {
+	diagnostics []diagnostic
+	files       []loadedFile
+	// loaded files, and the stack of files being loaded, to follow includes
+	loaded map[string]bool
+	stack  []string
+	// locations of each resource, by section and name
+	locations map[string]map[string]location
+}
+
+func (v *validator) report(d diagnostic) {
+	v.diagnostics = append(v.diagnostics, d)
+}
+
+func (v *validator) errorCount() int {
+	n := 0
+	for _, d := range v.diagnostics {
+		if d.Severity == severityError {
+			n++
+		}
+	}
+	return n
+}
+
+// defined reports whether a resource is defined, even if it's invalid.
+func (v *validator) defined(section, name string) bool {
+	_, ok := v.locations[section][name]
+	return ok
+}
+
+// reportAt reports a diagnostic at the given field of a resource, or at the
+// resource itself if the field isn't found.
+func (v *validator) reportAt(section, name, field, severity, msg string) {
+	loc := v.locations[section][name]
+	node := ast.Node(loc.entry.Key)
+	if n := fieldNode(loc.entry, field); n != nil {
+		node = n
+	}
+	pos := node.GetToken().Position
+	v.report(diagnostic{File: loc.file, Line: pos.Line, Column: pos.Column, Severity: severity, Message: msg})
+}
+
+// fieldNode returns the key node of a field of a resource.
+func fieldNode(entry *ast.MappingValueNode, field string) ast.Node {
+	if field == "" {
+		return nil
+	}
+	for _, mv := range mappingValues(entry.Value) {
+		if mv.Key.GetToken().Value == field {
+			return mv.Key
+		}
+	}
+	return nil
+}
+
+func mappingValues(node ast.Node) []*ast.MappingValueNode {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		return n.Values
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{n}
+	}
+	return nil
+}
+
+// offlineSecretAccessor returns a placeholder for every secret, so that
+// references to Secret Manager are checked without contacting it.
+type offlineSecretAccessor struct{}
+
+func (offlineSecretAccessor) AccessSecretVersion(ctx context.Context, name string) ([]byte, error) {
+	return []byte("secret"), nil
+}
+
+var envVarPattern = regexp.MustCompile(`\$\{(\w+)(:([^}]*))?\}`)
+
+// addFile decodes each resource of a tool file on its own, so that every
+// broken resource is reported rather than only the first.
+func (v *validator) addFile(ctx context.Context, path string, raw []byte) {
+	// report unresolved environment variables and secrets where they're used,
+	// then parse the file as the server would
+	v.checkEnvVars(ctx, path, raw)
+	output, _ := parseEnv(ctx, string(raw))
+
+	f, err := parser.ParseBytes([]byte(output), 0)
+	if err != nil {
+		v.reportYAMLError(path, nil, err)
+		return
+	}
+	var sections []*ast.MappingValueNode
+	for _, doc := range f.Docs {
+		sections = append(sections, mappingValues(doc.Body)...)
+	}
+	// included files are loaded first, and overrides are applied last
+	for _, section := range sections {
+		if section.Key.GetToken().Value == "include" {
+			v.addIncludes(ctx, path, section)
+		}
+	}
+	var toolsFile ToolsFile
+	for _, section := range sections {
+		v.addSection(ctx, path, section, &toolsFile)
+	}
+	rawFile, _ := rawResources([]byte(output))
+	v.files = append(v.files, loadedFile{ToolsFile: toolsFile, path: path, raw: rawFile})
+	for _, section := range sections {
+		if section.Key.GetToken().Value == "overrides" {
+			v.applyOverrides(ctx, path, section)
+		}
+	}
+}
+
+// checkEnvVars reports the environment variables and secrets referenced by a
+// file that can't be resolved. Only scalars are checked, so that references in
+// comments are ignored, unless the file only parses once they're replaced.
+func (v *validator) checkEnvVars(ctx context.Context, path string, raw []byte) {
+	type reference struct {
+		line, column int
+		text         string
+	}
+	var refs []reference
+	lines := strings.Split(string(raw), "\n")
+	if f, err := parser.ParseBytes(raw, 0); err == nil {
+		// the values of block scalars are positioned at their end
+		starts := make(map[ast.Node]*token.Position)
+		for _, n := range ast.FilterFile(ast.LiteralType, f) {
+			lit := n.(*ast.LiteralNode)
+			starts[lit.Value] = lit.Start.Position
+		}
+		for _, n := range ast.FilterFile(ast.StringType, f) {
+			pos := n.GetToken().Position
+			if start, ok := starts[n]; ok {
+				pos = start
+			}
+			line, column := pos.Line, pos.Column
+			for _, text := range envVarPattern.FindAllString(n.(*ast.StringNode).Value, -1) {
+				line, column = locate(lines, line, column, text)
+				refs = append(refs, reference{line: line, column: column, text: text})
+				column += len(text)
+			}
+		}
+	} else {
+		for i, line := range lines {
+			for _, m := range envVarPattern.FindAllStringIndex(line, -1) {
+				refs = append(refs, reference{line: i + 1, column: m[0] + 1, text: line[m[0]:m[1]]})
+			}
+		}
+	}
+
+	resolver := secrets.ResolverFromContext(ctx)
+	for _, ref := range refs {
+		m := envVarPattern.FindStringSubmatch(ref.text)
+		name, hasArg := m[1], m[2] != ""
+		if hasArg && resolver.Handles(name) {
+			if _, err := resolver.Resolve(ctx, name, m[3]); err != nil {
+				v.report(diagnostic{File: path, Line: ref.line, Column: ref.column, Severity: severityError, Message: err.Error()})
+			}
+			continue
+		}
+		if _, found := os.LookupEnv(name); !found && !hasArg {
+			v.report(diagnostic{File: path, Line: ref.line, Column: ref.column, Severity: severityError, Message: fmt.Sprintf("environment variable not found: %q", name)})
+		}
+	}
+}
+
+// locate returns the position of the first occurrence of text in lines,
+// starting from the given 1-based line and column. Text that can't be found,
+// such as in scalars with escapes, is located at the starting position.
+func locate(lines []string, line, column int, text string) (int, int) {
+	for i := line - 1; i < len(lines); i++ {
+		start := 0
+		if i == line-1 {
+			start = min(max(column-1, 0), len(lines[i]))
+		}
+		if j := strings.Index(lines[i][start:], text); j >= 0 {
+			return i + 1, start + j + 1
+		}
+	}
+	return line, column
+}
+
+// addIncludes adds the files included by a file.
+func (v *validator) addIncludes(ctx context.Context, path string, section *ast.MappingValueNode) {
+	seq, ok := section.Value.(*ast.SequenceNode)
+	if !ok {
+		pos := section.Key.GetToken().Position
+		v.report(diagnostic{File: path, Line: pos.Line, Column: pos.Column, Severity: severityError, Message: "include must be a list of file paths"})
+		return
+	}
+	abs, _ := filepath.Abs(path)
+	v.stack = append(v.stack, abs)
+	defer func() { v.stack = v.stack[:len(v.stack)-1] }()
+	for _, item := range seq.Values {
+		pos := item.GetToken().Position
+		report := func(msg string) {
+			v.report(diagnostic{File: path, Line: pos.Line, Column: pos.Column, Severity: severityError, Message: msg})
+		}
+		include := includePath(path, strings.TrimSpace(item.GetToken().Value))
+		includeAbs, err := filepath.Abs(include)
+		if err != nil {
+			report(fmt.Sprintf("unable to resolve included file %q: %s", include, err))
+			continue
+		}
+		if i := slices.Index(v.stack, includeAbs); i >= 0 {
+			report(fmt.Sprintf("include cycle detected: %s", strings.Join(append(slices.Clone(v.stack[i:]), includeAbs), " -> ")))
+			continue
+		}
+		if v.loaded[includeAbs] {
+			continue
+		}
+		v.loaded[includeAbs] = true
+		buf, err := os.ReadFile(include)
+		if err != nil {
+			report(fmt.Sprintf("unable to read included file: %s", err))
+			continue
+		}
+		v.addFile(ctx, include, buf)
+	}
+}
+
+// applyOverrides applies the overrides of a file to the files loaded so far.
+func (v *validator) applyOverrides(ctx context.Context, path string, section *ast.MappingValueNode) {
+	for _, sectionEntry := range mappingValues(section.Value) {
+		sectionName := sectionEntry.Key.GetToken().Value
+		for _, entry := range mappingValues(sectionEntry.Value) {
+			var patch any
+			if err := yaml.NodeToValue(entry.Value, &patch); err != nil {
+				v.reportYAMLError(path, entry, err)
+				continue
+			}
+			if err := applyOverride(ctx, v.files, sectionName, entry.Key.GetToken().Value, patch); err != nil {
+				pos := entry.Key.GetToken().Position
+				v.report(diagnostic{File: path, Line: pos.Line, Column: pos.Column, Severity: severityError, Message: err.Error()})
+			}
+		}
+	}
+}
+
+func (v *validator) addSection(ctx context.Context, path string, section *ast.MappingValueNode, toolsFile *ToolsFile) {
+	name := section.Key.GetToken().Value
+	var dst reflect.Value
+	switch name {
+	case "sources":
+		dst = reflect.ValueOf(&toolsFile.Sources)
+	case "authSources":
+		dst = reflect.ValueOf(&toolsFile.AuthSources)
+	case "authServices":
+		dst = reflect.ValueOf(&toolsFile.AuthServices)
+	case "embeddingModels":
+		dst = reflect.ValueOf(&toolsFile.EmbeddingModels)
+	case "tools":
+		dst = reflect.ValueOf(&toolsFile.Tools)
+	case "toolsets":
+		dst = reflect.ValueOf(&toolsFile.Toolsets)
+	case "prompts":
+		dst = reflect.ValueOf(&toolsFile.Prompts)
+	case "promptsets":
+		dst = reflect.ValueOf(&toolsFile.Promptsets)
+	case "include", "overrides":
+		// handled by addFile
+		return
+	default:
+		pos := section.Key.GetToken().Position
+		v.report(diagnostic{File: path, Line: pos.Line, Column: pos.Column, Severity: severityError, Message: fmt.Sprintf("unknown field %q", name)})
+		return
+	}
+	if dst.Elem().IsNil() {
+		dst.Elem().Set(reflect.MakeMap(dst.Elem().Type()))
+	}
+
+	if v.locations == nil {
+		v.locations = make(map[string]map[string]location)
+	}
+	if v.locations[name] == nil {
+		v.locations[name] = make(map[string]location)
+	}
+	for _, entry := range mappingValues(section.Value) {
+		resource := entry.Key.GetToken().Value
+		if prev, ok := v.locations[name][resource]; ok {
+			pos := entry.Key.GetToken().Position
+			prevPos := prev.entry.Key.GetToken().Position
+			v.report(diagnostic{File: path, Line: pos.Line, Column: pos.Column, Severity: severityError, Message: fmt.Sprintf("%s %q is already defined at %s:%d", strings.TrimSuffix(name, "s"), resource, prev.file, prevPos.Line)})
+			continue
+		}
+		v.locations[name][resource] = location{file: path, entry: entry}
+
+		// decode the resource on its own
+		single := reflect.New(dst.Elem().Type())
+		node := ast.Mapping(entry.GetToken(), false, entry)
+		dec := yaml.NewDecoder(bytes.NewReader(nil), yaml.Strict())
+		if err := dec.DecodeFromNodeContext(ctx, node, single.Interface()); err != nil {
+			v.reportYAMLError(path, entry, err)
+			continue
+		}
+		iter := single.Elem().MapRange()
+		for iter.Next() {
+			dst.Elem().SetMapIndex(iter.Key(), iter.Value())
+		}
+	}
+}
+
+var unknownFieldPattern = regexp.MustCompile(`unknown field "(\w+)"`)
+
+// reportYAMLError reports a parse or decode error. Resources are decoded from
+// a copy, so errors within a resource are reported at the field they name, if
+// any, or else at the resource.
+func (v *validator) reportYAMLError(path string, entry *ast.MappingValueNode, err error) {
+	var yamlErr yaml.Error
+	if entry == nil {
+		d := diagnostic{File: path, Severity: severityError, Message: err.Error()}
+		if errors.As(err, &yamlErr) && yamlErr.GetToken() != nil {
+			d.Line = yamlErr.GetToken().Position.Line
+			d.Column = yamlErr.GetToken().Position.Column
+			d.Message = yamlErr.GetMessage()
+		}
+		v.report(d)
+		return
+	}
+
+	msg := err.Error()
+	if errors.As(err, &yamlErr) {
+		// drop the position within the copy
+		msg = strings.Replace(msg, yamlErr.Error(), yamlErr.GetMessage(), 1)
+	}
+	node := ast.Node(entry.Key)
+	if m := unknownFieldPattern.FindStringSubmatch(msg); m != nil {
+		if n := fieldNode(entry, m[1]); n != nil {
+			node = n
+		}
+	}
+	pos := node.GetToken().Position
+	v.report(diagnostic{File: path, Line: pos.Line, Column: pos.Column, Severity: severityError, Message: msg})
+}
+
+// checkReferences merges the decoded files and checks the references between
+// resources, initializing tools against placeholder sources.
+func (v *validator) checkReferences(ctx context.Context) {
+	files := make([]ToolsFile, len(v.files))
+	for i, f := range v.files {
+		files[i] = f.ToolsFile
+	}
+	merged, err := mergeToolsFiles(files...)
+	if err != nil {
+		// duplicates were already reported and skipped
+		v.report(diagnostic{Severity: severityError, Message: err.Error()})
+		return
+	}
+	for name, a := range merged.AuthSources {
+		if _, exists := merged.AuthServices[name]; exists {
+			v.reportAt("authSources", name, "", severityError, fmt.Sprintf("authSource %q has the same name as an existing authService", name))
+			continue
+		}
+		v.reportAt("authSources", name, "", severityWarning, "`authSources` is deprecated, use `authServices` instead")
+		merged.AuthServices[name] = a
+	}
+
+	for name, tc := range merged.Tools {
+		v.checkTool(ctx, name, tc, merged)
+	}
+	for name, tsc := range merged.Toolsets {
+		for _, toolName := range tsc.ToolNames {
+			if _, ok := merged.Tools[toolName]; !ok && !v.defined("tools", toolName) {
+				v.reportAt("toolsets", name, "", severityError, fmt.Sprintf("toolset %q references unknown tool %q", name, toolName))
+			}
+		}
+	}
+	for name, psc := range merged.Promptsets {
+		for _, promptName := range psc.PromptNames {
+			if _, ok := merged.Prompts[promptName]; !ok && !v.defined("prompts", promptName) {
+				v.reportAt("promptsets", name, "", severityError, fmt.Sprintf("promptset %q references unknown prompt %q", name, promptName))
+			}
+		}
+	}
+}
+
+func (v *validator) checkTool(ctx context.Context, name string, tc tools.ToolConfig, merged ToolsFile) {
+	cfg := reflect.ValueOf(tools.BaseConfig(tc))
+	field := func(f string) reflect.Value {
+		if cfg.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		return cfg.FieldByName(f)
+	}
+
+	sourceName, sourceOK := "", true
+	if f := field("Source"); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+		sourceName = f.String()
+		if _, ok := merged.Sources[sourceName]; !ok {
+			sourceOK = false
+			if !v.defined("sources", sourceName) {
+				v.reportAt("tools", name, "source", severityError, fmt.Sprintf("tool %q references unknown source %q", name, sourceName))
+			}
+		}
+	}
+
+	if f := field("AuthRequired"); f.IsValid() {
+		authRequired, _ := f.Interface().([]string)
+		for _, a := range authRequired {
+			if _, ok := merged.AuthServices[a]; !ok && !v.defined("authServices", a) && !v.defined("authSources", a) {
+				v.reportAt("tools", name, "authRequired", severityError, fmt.Sprintf("tool %q requires unknown authService %q", name, a))
+			}
+		}
+	}
+	var params, templateParams parameters.Parameters
+	if f := field("Parameters"); f.IsValid() {
+		params, _ = f.Interface().(parameters.Parameters)
+	}
+	if f := field("TemplateParameters"); f.IsValid() {
+		templateParams, _ = f.Interface().(parameters.Parameters)
+	}
+	for _, p := range slices.Concat(params, templateParams) {
+		for _, a := range p.GetAuthServices() {
+			if _, ok := merged.AuthServices[a.Name]; !ok && !v.defined("authServices", a.Name) && !v.defined("authSources", a.Name) {
+				v.reportAt("tools", name, "parameters", severityError, fmt.Sprintf("parameter %q of tool %q references unknown authService %q", p.GetName(), name, a.Name))
+			}
+		}
+	}
+	for _, m := range parameters.EmbeddingModelNames(params) {
+		if _, ok := merged.EmbeddingModels[m]; !ok && !v.defined("embeddingModels", m) {
+			v.reportAt("tools", name, "parameters", severityError, fmt.Sprintf("tool %q references unknown embeddingModel %q", name, m))
+		}
+	}
+	v.checkTemplateParameters(name, field, templateParams)
+	if !sourceOK {
+		return
+	}
+
+	// tools are initialized against a placeholder of their source, which
+	// catches invalid parameters and statements, and sources of the wrong kind
+	// for tools that check their source when initialized
+	srcs := make(map[string]sources.Source)
+	if p, ok := merged.Sources[sourceName].(sources.PlaceholderConfig); ok {
+		srcs[sourceName] = p.Placeholder()
+	}
+	t, err := func() (t tools.Tool, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				// the tool needs a live source to initialize
+				t, err = nil, nil
+			}
+		}()
+		return tc.Initialize(srcs)
+	}()
+	if errors.As(err, new(tools.SourceNotFoundError)) {
+		// the source has no placeholder to initialize the tool with
+		return
+	}
+	if err != nil {
+		v.reportAt("tools", name, "", severityError, fmt.Sprintf("invalid tool %q: %s", name, err))
+		return
+	}
+	src, ok := srcs[sourceName]
+	if t == nil || !ok {
+		return
+	}
+	// other tools check their source when invoked
+	if compatible, checked := tools.CheckSourceCompatibility(ctx, t, sourceName, src); checked && !compatible {
+		v.reportAt("tools", name, "source", severityError, fmt.Sprintf("source %q of kind %q is not compatible with tool %q of kind %q", sourceName, src.SourceKind(), name, tc.ToolConfigKind()))
+	}
+}
+
+// checkTemplateParameters checks that the tool's statements only use declared
+// template parameters, and that every template parameter is used.
+func (v *validator) checkTemplateParameters(name string, field func(string) reflect.Value, templateParams parameters.Parameters) {
+	var statements []string
+	if f := field("Statement"); f.IsValid() && f.Kind() == reflect.String {
+		statements = append(statements, f.String())
+	}
+	if f := field("Statements"); f.IsValid() && f.Kind() == reflect.Slice {
+		for i := 0; i < f.Len(); i++ {
+			if s := reflect.Indirect(f.Index(i)); s.Kind() == reflect.Struct {
+				if stmt := s.FieldByName("Statement"); stmt.IsValid() && stmt.Kind() == reflect.String {
+					statements = append(statements, stmt.String())
+				}
+			}
+		}
+	}
+	if len(statements) == 0 {
+		return
+	}
+
+	used := make(map[string]bool)
+	for _, stmt := range statements {
+		names, err := parameters.TemplateParamNames(stmt)
+		if err != nil {
+			v.reportAt("tools", name, "statement", severityError, fmt.Sprintf("invalid statement for tool %q: %s", name, err))
+			return
+		}
+		for _, n := range names {
+			used[n] = true
+		}
+	}
+	declared := make(map[string]bool)
+	for _, p := range templateParams {
+		declared[p.GetName()] = true
+		if !used[p.GetName()] {
+			v.reportAt("tools", name, "templateParameters", severityWarning, fmt.Sprintf("template parameter %q of tool %q is not used in its statement", p.GetName(), name))
+		}
+	}
+	for n := range used {
+		if !declared[n] {
+			v.reportAt("tools", name, "statement", severityError, fmt.Sprintf("statement of tool %q uses undeclared template parameter %q", name, n))
+		}
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func invokeValidate(t *testing.T, files map[string]string, args ...string) ([]diagnostic, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("unable to create directory for %q: %s", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("unable to write %q: %s", name, err)
+		}
+	}
+	c := NewCommand()
+	out := new(bytes.Buffer)
+	c.SetOut(out)
+	c.SetErr(new(bytes.Buffer))
+	c.SetArgs(append([]string{"validate", "--tools-folder", dir, "--format", "json"}, args...))
+	err := c.Execute()
+
+	var diags []diagnostic
+	if jsonErr := json.Unmarshal(out.Bytes(), &diags); jsonErr != nil {
+		t.Fatalf("unable to parse diagnostics %q: %s", out.String(), jsonErr)
+	}
+	for i := range diags {
+		diags[i].File = filepath.Base(diags[i].File)
+	}
+	return diags, err
+}
+
+func TestValidate(t *testing.T) {
+	t.Setenv("VALIDATE_DB", "test.db")
+
+	tcs := []struct {
+		desc  string
+		files map[string]string
+		want  []diagnostic
+	}{
+		{
+			desc: "valid",
+			files: map[string]string{
+				"tools.yaml": `
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: ${VALIDATE_DB}
+tools:
+  search:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: search a table
+    statement: SELECT * FROM {{.table}}
+    templateParameters:
+      - name: table
+        type: identifier
+        description: the table
+toolsets:
+  default: [search]
+`,
+			},
+			want: []diagnostic{},
+		},
+		{
+			desc: "schema errors and unresolved references",
+			files: map[string]string{
+				"tools.yaml": `
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: ${VALIDATE_MISSING_DB}
+  my-other-sqlite:
+    kind: sqlite
+    database: test.db
+tools:
+  typo:
+    kind: sqlite-sql
+    source: my-other-sqlite
+    descripton: oops
+    statement: SELECT 1
+  wrong-kind:
+    kind: postgres-sql
+    source: my-other-sqlite
+    description: d
+    statement: SELECT 1
+  missing:
+    kind: sqlite-sql
+    source: nowhere
+    description: d
+    statement: SELECT * FROM {{.tbl}}
+    authRequired: [google]
+  wrong-http:
+    kind: http
+    source: my-other-sqlite
+    description: d
+    method: GET
+    path: /search
+toolsets:
+  default: [typo, ghost]
+`,
+			},
+			want: []diagnostic{
+				{File: "tools.yaml", Line: 3, Column: 3, Severity: "error", Message: `unable to parse source "my-sqlite" as "sqlite": Key: 'Config.Database' Error:Field validation for 'Database' failed on the 'required' tag`},
+				{File: "tools.yaml", Line: 5, Column: 15, Severity: "error", Message: `environment variable not found: "VALIDATE_MISSING_DB"`},
+				{File: "tools.yaml", Line: 13, Column: 5, Severity: "error", Message: `unable to parse tool "typo" as kind "sqlite-sql": unknown field "descripton"`},
+				{File: "tools.yaml", Line: 17, Column: 5, Severity: "error", Message: `source "my-other-sqlite" of kind "sqlite" is not compatible with tool "wrong-kind" of kind "postgres-sql"`},
+				{File: "tools.yaml", Line: 22, Column: 5, Severity: "error", Message: `tool "missing" references unknown source "nowhere"`},
+				{File: "tools.yaml", Line: 24, Column: 5, Severity: "error", Message: `statement of tool "missing" uses undeclared template parameter "tbl"`},
+				{File: "tools.yaml", Line: 25, Column: 5, Severity: "error", Message: `tool "missing" requires unknown authService "google"`},
+				{File: "tools.yaml", Line: 26, Column: 3, Severity: "error", Message: "invalid tool \"wrong-http\": invalid source for \"http\" tool: source kind must be `http`"},
+				{File: "tools.yaml", Line: 33, Column: 3, Severity: "error", Message: `toolset "default" references unknown tool "ghost"`},
+			},
+		},
+		{
+			desc: "embedding models",
+			files: map[string]string{
+				"tools.yaml": `
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: test.db
+embeddingModels:
+  my-embeddings:
+    kind: openai
+    model: text-embedding-3-small
+tools:
+  search:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: search by meaning
+    statement: SELECT * FROM docs ORDER BY vec_distance_cosine(embedding, ?)
+    parameters:
+      - name: query
+        type: string
+        description: the text to search for
+        embeddedBy: my-embeddings
+  search-other:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: search by meaning
+    statement: SELECT * FROM docs ORDER BY vec_distance_cosine(embedding, ?)
+    parameters:
+      - name: query
+        type: string
+        description: the text to search for
+        embeddedBy: other-embeddings
+`,
+			},
+			want: []diagnostic{
+				{File: "tools.yaml", Line: 26, Column: 5, Severity: "error", Message: `tool "search-other" references unknown embeddingModel "other-embeddings"`},
+			},
+		},
+		{
+			desc: "environment variables in comments and block scalars",
+			files: map[string]string{
+				"tools.yaml": `
+# set ${VALIDATE_COMMENTED_DB} to use another database
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: test.db # or ${VALIDATE_COMMENTED_DB}
+tools:
+  count:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: d
+    statement: |
+      SELECT COUNT(*)
+      FROM ${VALIDATE_MISSING_TABLE}
+`,
+			},
+			want: []diagnostic{
+				{File: "tools.yaml", Line: 14, Column: 12, Severity: "error", Message: `environment variable not found: "VALIDATE_MISSING_TABLE"`},
+			},
+		},
+		{
+			desc: "unresolved secrets",
+			files: map[string]string{
+				"tools.yaml": `
+sources:
+  my-http:
+    kind: http
+    baseUrl: https://example.com
+    headers:
+      Authorization: ${file:/nonexistent/token}
+      X-Api-Key: ${secret:gcp/projects/p/secrets/key}
+      X-Other-Key: ${secret:gcp/key}
+`,
+			},
+			want: []diagnostic{
+				{File: "tools.yaml", Line: 7, Column: 22, Severity: "error", Message: "unable to resolve ${file:/nonexistent/token}: unable to read secret file: open /nonexistent/token: no such file or directory"},
+				{File: "tools.yaml", Line: 9, Column: 20, Severity: "error", Message: `unable to resolve ${secret:gcp/key}: secret name "key" must be of the form projects/<project>/secrets/<secret>[/versions/<version>]`},
+			},
+		},
+		{
+			desc: "duplicates across files",
+			files: map[string]string{
+				"a.yaml": `
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: test.db
+`,
+				"b.yaml": `
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: other.db
+`,
+			},
+			want: []diagnostic{
+				{File: "b.yaml", Line: 3, Column: 3, Severity: "error"},
+			},
+		},
+		{
+			desc: "includes and overrides",
+			files: map[string]string{
+				"tools.yaml": `
+include: [base/sources.yaml, base/sources.yaml]
+overrides:
+  sources:
+    my-sqlite:
+      database: prod.db
+    ghost:
+      database: prod.db
+  tools:
+    search:
+      descripton: oops
+`,
+				"base/sources.yaml": `
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: dev.db
+`,
+			},
+			want: []diagnostic{
+				{File: "tools.yaml", Line: 7, Column: 5, Severity: "error", Message: `unable to override source "ghost": it isn't defined in an earlier file`},
+				{File: "tools.yaml", Line: 10, Column: 5, Severity: "error", Message: `unable to override tool "search": it isn't defined in an earlier file`},
+			},
+		},
+		{
+			desc: "promptsets",
+			files: map[string]string{
+				"tools.yaml": `
+prompts:
+  summarize:
+    messages:
+      - content: Summarize the data.
+promptsets:
+  analysis: [summarize, explain]
+`,
+			},
+			want: []diagnostic{
+				{File: "tools.yaml", Line: 7, Column: 3, Severity: "error", Message: `promptset "analysis" references unknown prompt "explain"`},
+			},
+		},
+		{
+			desc: "syntax error",
+			files: map[string]string{
+				"tools.yaml": "tools:\n  x: [\n",
+			},
+			want: []diagnostic{
+				{File: "tools.yaml", Line: 2, Column: 6, Severity: "error", Message: "sequence end token ']' not found"},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := invokeValidate(t, tc.files)
+			if len(tc.want) == 0 && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(tc.want) > 0 && err == nil {
+				t.Fatalf("expected an error")
+			}
+			if len(tc.want) == 1 && err.Error() != "found 1 error in tool configuration" {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			// the duplicate's message names the temporary directory
+			for i := range got {
+				if i < len(tc.want) && tc.want[i].Message == "" {
+					got[i].Message = ""
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect diagnostics (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateTemplateParameterWarning(t *testing.T) {
+	diags, err := invokeValidate(t, map[string]string{
+		"tools.yaml": `
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: test.db
+tools:
+  search:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: d
+    statement: SELECT 1
+    templateParameters:
+      - name: table
+        type: identifier
+        description: the table
+`,
+	})
+	// warnings don't fail validation
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []diagnostic{
+		{File: "tools.yaml", Line: 12, Column: 5, Severity: "warning", Message: `template parameter "table" of tool "search" is not used in its statement`},
+	}
+	if diff := cmp.Diff(want, diags); diff != "" {
+		t.Fatalf("incorrect diagnostics (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateInvalidFlags(t *testing.T) {
+	tcs := []struct {
+		desc string
+		args []string
+		want string
+	}{
+		{
+			desc: "invalid format",
+			args: []string{"--tools-file", "tools.yaml", "--format", "xml"},
+			want: "format invalid: must be 'text' or 'json'",
+		},
+		{
+			desc: "conflicting flags",
+			args: []string{"--tools-file", "tools.yaml", "--tools-folder", "configs"},
+			want: "--tools-file",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			errOut := new(bytes.Buffer)
+			c := NewCommand()
+			c.SetOut(new(bytes.Buffer))
+			c.SetErr(errOut)
+			c.SetArgs(append([]string{"validate"}, tc.args...))
+			err := c.Execute()
+
+			var exitErr *exitError
+			if !errors.As(err, &exitErr) || exitErr.code != 2 {
+				t.Fatalf("expected a usage error, got %v", err)
+			}
+			if !strings.Contains(errOut.String(), "Error: ") || !strings.Contains(errOut.String(), tc.want) {
+				t.Fatalf("expected the error to be printed, got %q", errOut.String())
+			}
+		})
+	}
+}
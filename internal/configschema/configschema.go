@@ -0,0 +1,301 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configschema generates a JSON Schema of tool configuration files
+// from the config structs of the registered source, tool, auth service, and
+// prompt kinds.
+package configschema
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/auth/google"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/openai"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/vertexai"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/cache"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// Draft is the JSON Schema dialect of generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Sections are the sections of a tool file that define resources by name.
+var Sections = []string{"sources", "authSources", "authServices", "embeddingModels", "tools", "toolsets", "prompts", "promptsets"}
+
+var (
+	parametersType = reflect.TypeOf(parameters.Parameters{})
+	parameterType  = reflect.TypeOf((*parameters.Parameter)(nil)).Elem()
+	argumentsType  = reflect.TypeOf(prompts.Arguments{})
+)
+
+// Generate returns a JSON Schema of tool configuration files. Only kinds
+// registered by the packages imported so far are included.
+func Generate(ctx context.Context) map[string]any {
+	g := &generator{defs: make(map[string]any)}
+
+	var sourceKinds []string
+	for _, kind := range sources.Kinds() {
+		cfg, err := sources.DecodeConfig(ctx, kind, "", emptyDecoder())
+		g.addKind("source", kind, cfg, err)
+		sourceKinds = append(sourceKinds, kind)
+	}
+	g.defs["source"] = discriminated("kind", "source", sourceKinds, "")
+
+	var toolKinds []string
+	cacheSchema := g.structSchema(reflect.TypeOf(cache.Config{}), nil)
+	rulesField, _ := reflect.TypeOf(tools.RuledToolConfig{}).FieldByName("Rules")
+	rulesSchema := g.typeSchema(rulesField.Type)
+	for _, kind := range tools.Kinds() {
+		cfg, err := tools.DecodeConfig(ctx, kind, "", emptyDecoder())
+		g.addKind("tool", kind, cfg, err)
+		if def, ok := g.defs["tool."+kind].(map[string]any); ok {
+			def["properties"].(map[string]any)["cache"] = cacheSchema
+			def["properties"].(map[string]any)["rules"] = rulesSchema
+		}
+		toolKinds = append(toolKinds, kind)
+	}
+	g.defs["tool"] = discriminated("kind", "tool", toolKinds, "")
+
+	g.addKind("authService", google.AuthServiceKind, google.Config{}, nil)
+	g.defs["authService"] = discriminated("kind", "authService", []string{google.AuthServiceKind}, "")
+
+	g.addKind("embeddingModel", openai.EmbeddingModelKind, openai.Config{}, nil)
+	g.addKind("embeddingModel", vertexai.EmbeddingModelKind, vertexai.Config{}, nil)
+	g.defs["embeddingModel"] = discriminated("kind", "embeddingModel", []string{openai.EmbeddingModelKind, vertexai.EmbeddingModelKind}, "")
+
+	var promptKinds []string
+	for _, kind := range prompts.Kinds() {
+		cfg, err := prompts.DecodeConfig(ctx, kind, "", emptyDecoder())
+		g.addKind("prompt", kind, cfg, err)
+		promptKinds = append(promptKinds, kind)
+	}
+	// prompts without a kind are custom prompts
+	g.defs["prompt"] = discriminated("kind", "prompt", promptKinds, "custom")
+
+	var paramTypes []string
+	for typ, p := range parameters.Types() {
+		def := g.structSchema(reflect.TypeOf(p), nil)
+		def["properties"].(map[string]any)["type"] = map[string]any{"const": typ}
+		g.defs["parameter."+typ] = def
+		paramTypes = append(paramTypes, typ)
+	}
+	slices.Sort(paramTypes)
+	g.defs["parameter"] = discriminated("type", "parameter", paramTypes, "")
+	// prompt arguments default to strings
+	g.defs["argument"] = discriminated("type", "parameter", paramTypes, parameters.TypeString)
+
+	return map[string]any{
+		"$schema":              Draft,
+		"title":                "Toolbox tools file",
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"sources":         namedMap(ref("source")),
+			"authSources":     withDeprecated(namedMap(ref("authService"))),
+			"authServices":    namedMap(ref("authService")),
+			"embeddingModels": namedMap(ref("embeddingModel")),
+			"tools":           namedMap(ref("tool")),
+			"toolsets":        namedMap(map[string]any{"type": "array", "items": map[string]any{"type": "string"}}),
+			"prompts":         namedMap(ref("prompt")),
+			"promptsets":      namedMap(map[string]any{"type": "array", "items": map[string]any{"type": "string"}}),
+			"include":         map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"overrides": map[string]any{
+				"type":                 "object",
+				"propertyNames":        map[string]any{"enum": Sections},
+				"additionalProperties": namedMap(map[string]any{"type": []string{"object", "null"}}),
+			},
+		},
+		"$defs": g.defs,
+	}
+}
+
+// emptyDecoder returns a decoder of an empty mapping, which decodes into the
+// zero config of a kind. It doesn't validate, so required fields can be left
+// empty.
+func emptyDecoder() *yaml.Decoder {
+	return yaml.NewDecoder(strings.NewReader("{}"))
+}
+
+type generator struct {
+	defs map[string]any
+}
+
+// addKind adds the definition of a kind of resource, from its config. Kinds
+// whose config can't be decoded empty get a definition that allows any field.
+func (g *generator) addKind(family, kind string, cfg any, err error) {
+	def := map[string]any{"type": "object", "properties": map[string]any{}}
+	if err == nil && cfg != nil {
+		t := reflect.TypeOf(cfg)
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			def = g.structSchema(t, []string{"name"})
+		}
+	}
+	def["properties"].(map[string]any)["kind"] = map[string]any{"const": kind}
+	g.defs[family+"."+kind] = def
+}
+
+// typeSchema returns the schema of values decoded into t.
+func (g *generator) typeSchema(t reflect.Type) map[string]any {
+	switch t {
+	case parametersType:
+		return map[string]any{"type": "array", "items": ref("parameter")}
+	case parameterType:
+		return ref("parameter")
+	case argumentsType:
+		return map[string]any{"type": "array", "items": ref("argument")}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.String:
+		// the decoder accepts any scalar for strings, e.g. `port: 5432`
+		return map[string]any{"type": []string{"string", "number", "boolean"}}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t, nil)
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema returns the schema of a struct, following the field naming
+// rules of the YAML decoder. Fields in skip are left out.
+func (g *generator) structSchema(t reflect.Type, skip []string) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	properties := make(map[string]any)
+	var required []string
+	g.addFields(t, skip, properties, &required)
+	s := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (g *generator) addFields(t reflect.Type, skip []string, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("yaml")
+		if tag == "" {
+			tag = f.Tag.Get("json")
+		}
+		if tag == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if slices.Contains(strings.Split(opts, ","), "inline") {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			g.addFields(ft, skip, properties, required)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		if slices.Contains(skip, name) {
+			continue
+		}
+		s := g.typeSchema(f.Type)
+		for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+			key, value, _ := strings.Cut(rule, "=")
+			switch key {
+			case "required":
+				// discriminators are required by the family instead
+				if name != "kind" && name != "type" {
+					*required = append(*required, name)
+				}
+			case "oneof":
+				s["enum"] = strings.Fields(value)
+			case "gte", "min":
+				if n, err := strconv.ParseFloat(value, 64); err == nil && isNumber(s) {
+					s["minimum"] = n
+				}
+			case "lte", "max":
+				if n, err := strconv.ParseFloat(value, 64); err == nil && isNumber(s) {
+					s["maximum"] = n
+				}
+			}
+		}
+		properties[name] = s
+	}
+}
+
+func isNumber(s map[string]any) bool {
+	return s["type"] == "integer" || s["type"] == "number"
+}
+
+// discriminated returns the schema of a family of definitions, picked by the
+// value of field. If defaultValue is empty the field is required, otherwise
+// values without it use the definition of defaultValue.
+func discriminated(field, family string, values []string, defaultValue string) map[string]any {
+	var allOf []any
+	for _, v := range values {
+		cond := map[string]any{"properties": map[string]any{field: map[string]any{"const": v}}}
+		if v != defaultValue {
+			cond["required"] = []string{field}
+		}
+		allOf = append(allOf, map[string]any{"if": cond, "then": ref(family + "." + v)})
+	}
+	s := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{field: map[string]any{"enum": values}},
+		"allOf":      allOf,
+	}
+	if defaultValue == "" {
+		s["required"] = []string{field}
+	}
+	return s
+}
+
+func ref(def string) map[string]any {
+	return map[string]any{"$ref": "#/$defs/" + def}
+}
+
+func namedMap(value map[string]any) map[string]any {
+	return map[string]any{"type": "object", "additionalProperties": value}
+}
+
+func withDeprecated(s map[string]any) map[string]any {
+	s["deprecated"] = true
+	return s
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/configschema"
+	_ "github.com/googleapis/genai-toolbox/internal/prompts/custom"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/sqlite/sqlitesql"
+)
+
+func TestGenerate(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// round trip through JSON to compare plain values
+	b, err := json.Marshal(configschema.Generate(ctx))
+	if err != nil {
+		t.Fatalf("unable to marshal schema: %s", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatalf("unable to unmarshal schema: %s", err)
+	}
+	defs := schema["$defs"].(map[string]any)
+	scalar := map[string]any{"type": []any{"string", "number", "boolean"}}
+
+	tcs := []struct {
+		desc string
+		def  string
+		want any
+	}{
+		{
+			desc: "source kind",
+			def:  "source.sqlite",
+			want: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"kind":     map[string]any{"const": "sqlite"},
+					"database": scalar,
+				},
+				"required":             []any{"database"},
+				"additionalProperties": false,
+			},
+		},
+		{
+			desc: "sources by kind",
+			def:  "source",
+			want: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"kind": map[string]any{"enum": []any{"sqlite"}}},
+				"required":   []any{"kind"},
+				"allOf": []any{
+					map[string]any{
+						"if": map[string]any{
+							"properties": map[string]any{"kind": map[string]any{"const": "sqlite"}},
+							"required":   []any{"kind"},
+						},
+						"then": map[string]any{"$ref": "#/$defs/source.sqlite"},
+					},
+				},
+			},
+		},
+		{
+			desc: "prompts default to custom",
+			def:  "prompt",
+			want: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"kind": map[string]any{"enum": []any{"custom"}}},
+				"allOf": []any{
+					map[string]any{
+						"if":   map[string]any{"properties": map[string]any{"kind": map[string]any{"const": "custom"}}},
+						"then": map[string]any{"$ref": "#/$defs/prompt.custom"},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, defs[tc.def]); diff != "" {
+				t.Fatalf("incorrect definition (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	tool := defs["tool.sqlite-sql"].(map[string]any)
+	properties := tool["properties"].(map[string]any)
+	for _, field := range []string{"source", "description", "statement", "parameters", "templateParameters", "authRequired", "cache", "rules"} {
+		if _, ok := properties[field]; !ok {
+			t.Errorf("tool definition is missing field %q", field)
+		}
+	}
+	if _, ok := properties["name"]; ok {
+		t.Errorf("tool definition has the name field, which is set from the key")
+	}
+	wantParams := map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/parameter"}}
+	if diff := cmp.Diff(wantParams, properties["parameters"]); diff != "" {
+		t.Errorf("incorrect parameters (-want +got):\n%s", diff)
+	}
+	// a tool with rules is allowed by the tool definition
+	var withRules map[string]any
+	err = json.Unmarshal([]byte(`{
+		"kind": "sqlite-sql",
+		"source": "my-sqlite",
+		"description": "finds a user",
+		"statement": "SELECT 1",
+		"rules": [{"exactlyOneOf": ["id", "email"], "message": "give an id or an email"}, {"check": "true"}]
+	}`), &withRules)
+	if err != nil {
+		t.Fatalf("unable to unmarshal tool: %s", err)
+	}
+	if err := checkProperties(tool, withRules, "tool"); err != nil {
+		t.Errorf("tool with rules doesn't match the schema: %s", err)
+	}
+
+	for _, def := range []string{"parameter.string", "parameter.integer", "parameter.float", "parameter.boolean", "parameter.array", "parameter.map", "argument"} {
+		if _, ok := defs[def]; !ok {
+			t.Errorf("missing definition %q", def)
+		}
+	}
+}
+
+// checkProperties checks that v only has the fields a schema allows, following
+// properties and array items. It's enough to check that fields aren't rejected
+// by `additionalProperties: false`.
+func checkProperties(schema map[string]any, v any, path string) error {
+	switch v := v.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		for k, fv := range v {
+			fs, ok := properties[k].(map[string]any)
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: field %q is not allowed", path, k)
+				}
+				continue
+			}
+			if err := checkProperties(fs, fv, path+"."+k); err != nil {
+				return err
+			}
+		}
+	case []any:
+		items, _ := schema["items"].(map[string]any)
+		for i, iv := range v {
+			if err := checkProperties(items, iv, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+)
+
+const EmbeddingModelKind string = "openai"
+
+// DefaultEndpoint is the base URL of the OpenAI API.
+const DefaultEndpoint = "https://api.openai.com/v1"
+
+// validate interface
+var _ embeddingmodels.EmbeddingModelConfig = Config{}
+
+// Config is the configuration of a model served by an OpenAI-compatible
+// embeddings API, e.g. OpenAI, Ollama or vLLM.
+type Config struct {
+	Name       string `yaml:"name" validate:"required"`
+	Kind       string `yaml:"kind" validate:"required"`
+	Model      string `yaml:"model" validate:"required"`
+	Endpoint   string `yaml:"endpoint"`
+	ApiKey     string `yaml:"apiKey"`
+	Dimensions int    `yaml:"dimensions"`
+}
+
+// Returns the embedding model kind
+func (cfg Config) EmbeddingModelConfigKind() string {
+	return EmbeddingModelKind
+}
+
+// Initialize an OpenAI-compatible embedding model
+func (cfg Config) Initialize(ctx context.Context) (embeddingmodels.EmbeddingModel, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	m := &EmbeddingModel{
+		Config: cfg,
+		url:    strings.TrimSuffix(endpoint, "/") + "/embeddings",
+		client: &http.Client{},
+	}
+	return m, nil
+}
+
+// validate interface
+var _ embeddingmodels.EmbeddingModel = &EmbeddingModel{}
+
+type EmbeddingModel struct {
+	Config
+	url    string
+	client *http.Client
+}
+
+// Returns the embedding model kind
+func (m *EmbeddingModel) EmbeddingModelKind() string {
+	return EmbeddingModelKind
+}
+
+func (m *EmbeddingModel) ToConfig() embeddingmodels.EmbeddingModelConfig {
+	return m.Config
+}
+
+type embeddingsRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (m *EmbeddingModel) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	header := make(http.Header)
+	if m.ApiKey != "" {
+		header.Set("Authorization", "Bearer "+m.ApiKey)
+	}
+	var resp embeddingsResponse
+	req := embeddingsRequest{Model: m.Model, Input: texts, Dimensions: m.Dimensions}
+	if err := embeddingmodels.PostJSON(ctx, m.client, m.url, header, req, &resp); err != nil {
+		return nil, fmt.Errorf("unable to embed with %q: %w", m.Name, err)
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("unable to embed with %q: got %d embeddings for %d texts", m.Name, len(resp.Data), len(texts))
+	}
+	vectors := make([][]float64, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("unable to embed with %q: invalid index %d", m.Name, d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
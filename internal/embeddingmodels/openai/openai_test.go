@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/openai"
+)
+
+func TestEmbed(t *testing.T) {
+	var gotReq map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer my-key" {
+			t.Errorf("unexpected authorization header %q", got)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotReq); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		// embeddings may be returned out of order
+		_, _ = w.Write([]byte(`{"data": [{"index": 1, "embedding": [0.3, 0.4]}, {"index": 0, "embedding": [0.1, 0.2]}]}`))
+	}))
+	defer srv.Close()
+
+	cfg := openai.Config{
+		Name:       "my-embeddings",
+		Kind:       openai.EmbeddingModelKind,
+		Model:      "text-embedding-3-small",
+		Endpoint:   srv.URL + "/v1/",
+		ApiKey:     "my-key",
+		Dimensions: 2,
+	}
+	m, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	got, err := m.Embed(context.Background(), []string{"cat", "dog"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([][]float64{{0.1, 0.2}, {0.3, 0.4}}, got); diff != "" {
+		t.Fatalf("incorrect vectors (-want +got):\n%s", diff)
+	}
+	wantReq := map[string]any{"model": "text-embedding-3-small", "input": []any{"cat", "dog"}, "dimensions": float64(2)}
+	if diff := cmp.Diff(wantReq, gotReq); diff != "" {
+		t.Fatalf("incorrect request (-want +got):\n%s", diff)
+	}
+}
+
+func TestEmbedError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid model", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	cfg := openai.Config{Name: "my-embeddings", Kind: openai.EmbeddingModelKind, Model: "missing", Endpoint: srv.URL}
+	m, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	if _, err := m.Embed(context.Background(), []string{"cat"}); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vertexai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"golang.org/x/oauth2/google"
+)
+
+const EmbeddingModelKind string = "vertexai"
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// validate interface
+var _ embeddingmodels.EmbeddingModelConfig = Config{}
+
+// Config is the configuration of a text embedding model served by Vertex AI,
+// e.g. text-embedding-005.
+type Config struct {
+	Name                 string `yaml:"name" validate:"required"`
+	Kind                 string `yaml:"kind" validate:"required"`
+	Project              string `yaml:"project" validate:"required"`
+	Location             string `yaml:"location" validate:"required"`
+	Model                string `yaml:"model" validate:"required"`
+	TaskType             string `yaml:"taskType"`
+	OutputDimensionality int    `yaml:"outputDimensionality"`
+	// Endpoint replaces the regional Vertex AI endpoint, e.g. to use a
+	// private endpoint or a local server. Requests to a custom endpoint are
+	// not authenticated.
+	Endpoint string `yaml:"endpoint"`
+}
+
+// Returns the embedding model kind
+func (cfg Config) EmbeddingModelConfigKind() string {
+	return EmbeddingModelKind
+}
+
+// Initialize a Vertex AI embedding model. Requests to the Vertex AI endpoint
+// are authenticated with Application Default Credentials.
+func (cfg Config) Initialize(ctx context.Context) (embeddingmodels.EmbeddingModel, error) {
+	endpoint := cfg.Endpoint
+	client := &http.Client{}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s-aiplatform.googleapis.com", cfg.Location)
+		if cfg.Location == "global" {
+			endpoint = "https://aiplatform.googleapis.com"
+		}
+		var err error
+		client, err = google.DefaultClient(ctx, cloudPlatformScope)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find default credentials: %w", err)
+		}
+	}
+	m := &EmbeddingModel{
+		Config: cfg,
+		url: fmt.Sprintf("%s/v1/projects/%s/locations/%s/publishers/google/models/%s:predict",
+			strings.TrimSuffix(endpoint, "/"), cfg.Project, cfg.Location, cfg.Model),
+		client: client,
+	}
+	return m, nil
+}
+
+// validate interface
+var _ embeddingmodels.EmbeddingModel = &EmbeddingModel{}
+
+type EmbeddingModel struct {
+	Config
+	url    string
+	client *http.Client
+}
+
+// Returns the embedding model kind
+func (m *EmbeddingModel) EmbeddingModelKind() string {
+	return EmbeddingModelKind
+}
+
+func (m *EmbeddingModel) ToConfig() embeddingmodels.EmbeddingModelConfig {
+	return m.Config
+}
+
+type instance struct {
+	Content  string `json:"content"`
+	TaskType string `json:"task_type,omitempty"`
+}
+
+type predictParameters struct {
+	OutputDimensionality int `json:"outputDimensionality,omitempty"`
+}
+
+type predictRequest struct {
+	Instances  []instance        `json:"instances"`
+	Parameters predictParameters `json:"parameters"`
+}
+
+type predictResponse struct {
+	Predictions []struct {
+		Embeddings struct {
+			Values []float64 `json:"values"`
+		} `json:"embeddings"`
+	} `json:"predictions"`
+}
+
+func (m *EmbeddingModel) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	req := predictRequest{
+		Instances:  make([]instance, len(texts)),
+		Parameters: predictParameters{OutputDimensionality: m.OutputDimensionality},
+	}
+	for i, text := range texts {
+		req.Instances[i] = instance{Content: text, TaskType: m.TaskType}
+	}
+	var resp predictResponse
+	if err := embeddingmodels.PostJSON(ctx, m.client, m.url, nil, req, &resp); err != nil {
+		return nil, fmt.Errorf("unable to embed with %q: %w", m.Name, err)
+	}
+	if len(resp.Predictions) != len(texts) {
+		return nil, fmt.Errorf("unable to embed with %q: got %d embeddings for %d texts", m.Name, len(resp.Predictions), len(texts))
+	}
+	vectors := make([][]float64, len(texts))
+	for i, p := range resp.Predictions {
+		vectors[i] = p.Embeddings.Values
+	}
+	return vectors, nil
+}
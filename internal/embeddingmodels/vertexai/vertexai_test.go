@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vertexai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/vertexai"
+)
+
+func TestEmbed(t *testing.T) {
+	var gotReq map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wantPath := "/v1/projects/my-project/locations/us-central1/publishers/google/models/text-embedding-005:predict"
+		if r.URL.Path != wantPath {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotReq); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		_, _ = w.Write([]byte(`{"predictions": [{"embeddings": {"values": [0.1, 0.2]}}, {"embeddings": {"values": [0.3, 0.4]}}]}`))
+	}))
+	defer srv.Close()
+
+	cfg := vertexai.Config{
+		Name:                 "my-embeddings",
+		Kind:                 vertexai.EmbeddingModelKind,
+		Project:              "my-project",
+		Location:             "us-central1",
+		Model:                "text-embedding-005",
+		TaskType:             "RETRIEVAL_QUERY",
+		OutputDimensionality: 2,
+		Endpoint:             srv.URL,
+	}
+	m, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	got, err := m.Embed(context.Background(), []string{"cat", "dog"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([][]float64{{0.1, 0.2}, {0.3, 0.4}}, got); diff != "" {
+		t.Fatalf("incorrect vectors (-want +got):\n%s", diff)
+	}
+	wantReq := map[string]any{
+		"instances": []any{
+			map[string]any{"content": "cat", "task_type": "RETRIEVAL_QUERY"},
+			map[string]any{"content": "dog", "task_type": "RETRIEVAL_QUERY"},
+		},
+		"parameters": map[string]any{"outputDimensionality": float64(2)},
+	}
+	if diff := cmp.Diff(wantReq, gotReq); diff != "" {
+		t.Fatalf("incorrect request (-want +got):\n%s", diff)
+	}
+}
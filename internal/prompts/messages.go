@@ -62,7 +62,7 @@ func SubstituteMessages(messages []Message, arguments Arguments, argValues param
 	}
 
 	for _, msg := range messages {
-		substitutedContent, err := parameters.ResolveTemplateParams(params, msg.Content, argsMap)
+		substitutedContent, err := parameters.ResolveTemplateParams(params, msg.Content, argsMap, parameters.QuoteNone)
 		if err != nil {
 			return nil, fmt.Errorf("error substituting params for message: %w", err)
 		}
@@ -17,6 +17,8 @@ package prompts
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
@@ -42,6 +44,11 @@ func Register(kind string, factory PromptConfigFactory) bool {
 	return true
 }
 
+// Kinds returns the registered prompt kinds, sorted.
+func Kinds() []string {
+	return slices.Sorted(maps.Keys(promptRegistry))
+}
+
 // DecodeConfig looks up the registered factory for the given kind and uses it
 // to decode the prompt configuration.
 func DecodeConfig(ctx context.Context, kind, name string, decoder *yaml.Decoder) (PromptConfig, error) {
@@ -0,0 +1,257 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaffold
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	"cloud.google.com/go/spanner"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/api/iterator"
+)
+
+// Table is a table read from a database's catalog.
+type Table struct {
+	Schema  string
+	Name    string
+	Comment string
+	Columns []Column
+}
+
+// Column is a column of a Table.
+type Column struct {
+	Name       string
+	Type       string
+	Comment    string
+	PrimaryKey bool
+}
+
+// PrimaryKey returns the table's primary key columns, in order.
+func (t Table) PrimaryKey() []Column {
+	var pk []Column
+	for _, c := range t.Columns {
+		if c.PrimaryKey {
+			pk = append(pk, c)
+		}
+	}
+	return pk
+}
+
+// catalogQueries read one row per column: schema, table, column, type,
+// column comment, table comment, and whether the column is part of the
+// primary key. They take the schema as their only parameter.
+const (
+	postgresCatalog = `SELECT n.nspname, c.relname, a.attname, format_type(a.atttypid, a.atttypmod),
+	COALESCE(col_description(c.oid, a.attnum), ''), COALESCE(obj_description(c.oid, 'pg_class'), ''),
+	COALESCE(a.attnum = ANY(i.indkey), false)
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+LEFT JOIN pg_index i ON i.indrelid = c.oid AND i.indisprimary
+WHERE c.relkind IN ('r', 'p') AND n.nspname = $1
+ORDER BY c.relname, a.attnum`
+
+	mysqlCatalog = `SELECT c.TABLE_SCHEMA, c.TABLE_NAME, c.COLUMN_NAME, c.DATA_TYPE, c.COLUMN_COMMENT, t.TABLE_COMMENT,
+	c.COLUMN_KEY = 'PRI'
+FROM information_schema.COLUMNS c
+JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+WHERE t.TABLE_TYPE = 'BASE TABLE' AND c.TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())
+ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`
+
+	mssqlCatalog = `SELECT s.name, t.name, c.name, ty.name,
+	CAST(COALESCE(cp.value, '') AS NVARCHAR(MAX)), CAST(COALESCE(tp.value, '') AS NVARCHAR(MAX)),
+	CAST(CASE WHEN ic.column_id IS NULL THEN 0 ELSE 1 END AS BIT)
+FROM sys.tables t
+JOIN sys.schemas s ON s.schema_id = t.schema_id
+JOIN sys.columns c ON c.object_id = t.object_id
+JOIN sys.types ty ON ty.user_type_id = c.user_type_id
+LEFT JOIN sys.extended_properties cp ON cp.class = 1 AND cp.major_id = t.object_id AND cp.minor_id = c.column_id AND cp.name = 'MS_Description'
+LEFT JOIN sys.extended_properties tp ON tp.class = 1 AND tp.major_id = t.object_id AND tp.minor_id = 0 AND tp.name = 'MS_Description'
+LEFT JOIN sys.indexes i ON i.object_id = t.object_id AND i.is_primary_key = 1
+LEFT JOIN sys.index_columns ic ON ic.object_id = t.object_id AND ic.index_id = i.index_id AND ic.column_id = c.column_id
+WHERE s.name = @schema
+ORDER BY t.name, c.column_id`
+
+	sqliteCatalog = `SELECT 'main', m.name, p.name, p.type, '', '', p.pk > 0
+FROM sqlite_master m
+JOIN pragma_table_info(m.name) p
+WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' AND ? IN ('', 'main')
+ORDER BY m.name, p.cid`
+
+	spannerCatalog = `SELECT c.TABLE_SCHEMA, c.TABLE_NAME, c.COLUMN_NAME, c.SPANNER_TYPE, '', '',
+	EXISTS (
+		SELECT 1 FROM INFORMATION_SCHEMA.INDEX_COLUMNS ic
+		WHERE ic.TABLE_SCHEMA = c.TABLE_SCHEMA AND ic.TABLE_NAME = c.TABLE_NAME
+			AND ic.INDEX_NAME = 'PRIMARY_KEY' AND ic.COLUMN_NAME = c.COLUMN_NAME
+	)
+FROM INFORMATION_SCHEMA.COLUMNS c
+JOIN INFORMATION_SCHEMA.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+WHERE t.TABLE_TYPE = 'BASE TABLE' AND c.TABLE_SCHEMA = @schema
+ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`
+)
+
+// readCatalog reads the tables of a schema from a source, keeping only the
+// named tables if any are given.
+func readCatalog(ctx context.Context, d dialect, src sources.Source, schema string, tables []string) ([]Table, error) {
+	var all []Table
+	var err error
+	switch s := src.(type) {
+	case interface{ PostgresPool() *pgxpool.Pool }:
+		all, err = readPgx(ctx, s.PostgresPool(), schema)
+	case interface{ MySQLPool() *sql.DB }:
+		all, err = readDB(ctx, s.MySQLPool(), mysqlCatalog, schema)
+	case interface{ MSSQLDB() *sql.DB }:
+		all, err = readDB(ctx, s.MSSQLDB(), mssqlCatalog, sql.Named("schema", schema))
+	case interface{ SQLiteDB() *sql.DB }:
+		all, err = readDB(ctx, s.SQLiteDB(), sqliteCatalog, schema)
+	case interface {
+		SpannerClient() *spanner.Client
+		DatabaseDialect() string
+	}:
+		if s.DatabaseDialect() != "googlesql" {
+			return nil, fmt.Errorf("only the googlesql dialect of Spanner is supported")
+		}
+		all, err = readSpanner(ctx, s.SpannerClient(), schema)
+	case interface{ BigQueryClient() *bigqueryapi.Client }:
+		all, err = readBigQuery(ctx, s.BigQueryClient(), schema)
+	default:
+		return nil, fmt.Errorf("unable to read the catalog of source kind %q", d.sourceKind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read catalog: %w", err)
+	}
+	if len(tables) == 0 {
+		return all, nil
+	}
+
+	selected := make([]Table, 0, len(tables))
+	for _, name := range tables {
+		i := slices.IndexFunc(all, func(t Table) bool { return t.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("table %q not found in schema %q", name, schema)
+		}
+		selected = append(selected, all[i])
+	}
+	return selected, nil
+}
+
+// catalog groups catalog rows, which are ordered by table, into tables.
+type catalog []Table
+
+func (c *catalog) add(schema, table, column, typ, columnComment, tableComment string, pk bool) {
+	if n := len(*c); n == 0 || (*c)[n-1].Schema != schema || (*c)[n-1].Name != table {
+		*c = append(*c, Table{Schema: schema, Name: table, Comment: tableComment})
+	}
+	t := &(*c)[len(*c)-1]
+	t.Columns = append(t.Columns, Column{Name: column, Type: typ, Comment: columnComment, PrimaryKey: pk})
+}
+
+func readPgx(ctx context.Context, pool *pgxpool.Pool, schema string) ([]Table, error) {
+	rows, err := pool.Query(ctx, postgresCatalog, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var c catalog
+	for rows.Next() {
+		var s, t, col, typ, colComment, tableComment string
+		var pk bool
+		if err := rows.Scan(&s, &t, &col, &typ, &colComment, &tableComment, &pk); err != nil {
+			return nil, err
+		}
+		c.add(s, t, col, typ, colComment, tableComment, pk)
+	}
+	return c, rows.Err()
+}
+
+func readDB(ctx context.Context, db *sql.DB, query string, schema any) ([]Table, error) {
+	rows, err := db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var c catalog
+	for rows.Next() {
+		var s, t, col, typ, colComment, tableComment string
+		var pk bool
+		if err := rows.Scan(&s, &t, &col, &typ, &colComment, &tableComment, &pk); err != nil {
+			return nil, err
+		}
+		c.add(s, t, col, typ, colComment, tableComment, pk)
+	}
+	return c, rows.Err()
+}
+
+func readSpanner(ctx context.Context, client *spanner.Client, schema string) ([]Table, error) {
+	stmt := spanner.Statement{SQL: spannerCatalog, Params: map[string]any{"schema": schema}}
+	iter := client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+	var c catalog
+	for {
+		row, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return c, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var s, t, col, typ, colComment, tableComment string
+		var pk bool
+		if err := row.Columns(&s, &t, &col, &typ, &colComment, &tableComment, &pk); err != nil {
+			return nil, err
+		}
+		c.add(s, t, col, typ, colComment, tableComment, pk)
+	}
+}
+
+// readBigQuery reads the tables of a dataset through the API, since
+// INFORMATION_SCHEMA doesn't include descriptions.
+func readBigQuery(ctx context.Context, client *bigqueryapi.Client, dataset string) ([]Table, error) {
+	if dataset == "" {
+		return nil, fmt.Errorf("a dataset must be given as the schema for BigQuery")
+	}
+	var c catalog
+	it := client.Dataset(dataset).Tables(ctx)
+	for {
+		tbl, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return c, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		md, err := tbl.Metadata(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if md.Type != bigqueryapi.RegularTable {
+			continue
+		}
+		var pk []string
+		if md.TableConstraints != nil && md.TableConstraints.PrimaryKey != nil {
+			pk = md.TableConstraints.PrimaryKey.Columns
+		}
+		schema := fmt.Sprintf("%s.%s", tbl.ProjectID, tbl.DatasetID)
+		for _, f := range md.Schema {
+			c.add(schema, tbl.TableID, f.Name, string(f.Type), f.Description, md.Description, slices.Contains(pk, f.Name))
+		}
+	}
+}
@@ -0,0 +1,512 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaffold
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// openAPIDocument is the subset of an OpenAPI 3 document used to generate
+// tools.
+type openAPIDocument struct {
+	OpenAPI    string                     `yaml:"openapi"`
+	Servers    []openAPIServer            `yaml:"servers"`
+	Paths      map[string]openAPIPathItem `yaml:"paths"`
+	Components struct {
+		Schemas       map[string]*openAPISchema      `yaml:"schemas"`
+		Parameters    map[string]*openAPIParameter   `yaml:"parameters"`
+		RequestBodies map[string]*openAPIRequestBody `yaml:"requestBodies"`
+	} `yaml:"components"`
+}
+
+type openAPIServer struct {
+	URL string `yaml:"url"`
+}
+
+type openAPIPathItem struct {
+	Parameters []*openAPIParameter `yaml:"parameters"`
+	Get        *openAPIOperation   `yaml:"get"`
+	Put        *openAPIOperation   `yaml:"put"`
+	Post       *openAPIOperation   `yaml:"post"`
+	Delete     *openAPIOperation   `yaml:"delete"`
+	Patch      *openAPIOperation   `yaml:"patch"`
+}
+
+type openAPIOperation struct {
+	OperationID string              `yaml:"operationId"`
+	Summary     string              `yaml:"summary"`
+	Description string              `yaml:"description"`
+	Tags        []string            `yaml:"tags"`
+	Parameters  []*openAPIParameter `yaml:"parameters"`
+	RequestBody *openAPIRequestBody `yaml:"requestBody"`
+}
+
+type openAPIParameter struct {
+	Ref         string         `yaml:"$ref"`
+	Name        string         `yaml:"name"`
+	In          string         `yaml:"in"`
+	Description string         `yaml:"description"`
+	Required    bool           `yaml:"required"`
+	Schema      *openAPISchema `yaml:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Ref         string `yaml:"$ref"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+	Content     map[string]struct {
+		Schema *openAPISchema `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `yaml:"$ref"`
+	Type                 any                       `yaml:"type"`
+	Description          string                    `yaml:"description"`
+	Enum                 []any                     `yaml:"enum"`
+	Default              any                       `yaml:"default"`
+	Minimum              *float64                  `yaml:"minimum"`
+	Maximum              *float64                  `yaml:"maximum"`
+	Items                *openAPISchema            `yaml:"items"`
+	Properties           map[string]*openAPISchema `yaml:"properties"`
+	Required             []string                  `yaml:"required"`
+	AllOf                []*openAPISchema          `yaml:"allOf"`
+	AdditionalProperties any                       `yaml:"additionalProperties"`
+}
+
+// typeName returns the schema's type. OpenAPI 3.1 allows a list of types,
+// in which case the first one other than "null" is used.
+func (s *openAPISchema) typeName() string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if name, ok := v.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	if len(s.Properties) > 0 {
+		return "object"
+	}
+	return ""
+}
+
+// maxRefDepth bounds chains of references, which may be cyclic.
+const maxRefDepth = 16
+
+func (d *openAPIDocument) schema(s *openAPISchema) (*openAPISchema, error) {
+	for i := 0; s != nil && s.Ref != ""; i++ {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		if !ok || i == maxRefDepth {
+			return nil, fmt.Errorf("unable to resolve schema %q", s.Ref)
+		}
+		if s, ok = d.Components.Schemas[name]; !ok {
+			return nil, fmt.Errorf("schema %q not found", name)
+		}
+	}
+	if s == nil || len(s.AllOf) == 0 {
+		return s, nil
+	}
+	// merge the properties of allOf into a single object
+	merged := *s
+	merged.Properties = make(map[string]*openAPISchema)
+	for k, v := range s.Properties {
+		merged.Properties[k] = v
+	}
+	for _, sub := range s.AllOf {
+		sub, err := d.schema(sub)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range sub.Properties {
+			merged.Properties[k] = v
+		}
+		merged.Required = append(merged.Required, sub.Required...)
+		if merged.Description == "" {
+			merged.Description = sub.Description
+		}
+	}
+	merged.AllOf = nil
+	merged.Type = "object"
+	return &merged, nil
+}
+
+func (d *openAPIDocument) parameter(p *openAPIParameter) (*openAPIParameter, error) {
+	for i := 0; p.Ref != ""; i++ {
+		name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/")
+		if !ok || i == maxRefDepth {
+			return nil, fmt.Errorf("unable to resolve parameter %q", p.Ref)
+		}
+		if p, ok = d.Components.Parameters[name]; !ok || p == nil {
+			return nil, fmt.Errorf("parameter %q not found", name)
+		}
+	}
+	return p, nil
+}
+
+func (d *openAPIDocument) requestBody(b *openAPIRequestBody) (*openAPIRequestBody, error) {
+	for i := 0; b.Ref != ""; i++ {
+		name, ok := strings.CutPrefix(b.Ref, "#/components/requestBodies/")
+		if !ok || i == maxRefDepth {
+			return nil, fmt.Errorf("unable to resolve request body %q", b.Ref)
+		}
+		if b, ok = d.Components.RequestBodies[name]; !ok || b == nil {
+			return nil, fmt.Errorf("request body %q not found", name)
+		}
+	}
+	return b, nil
+}
+
+// OpenAPIOptions selects what to generate tools for.
+type OpenAPIOptions struct {
+	// SourceName is the name of the http source in the generated tools.
+	SourceName string
+	// IncludeSource adds the http source to the generated tools, with the
+	// base URL of the document's first server unless BaseURL is given.
+	IncludeSource bool
+	BaseURL       string
+	// Operations to generate tools for, by operationId. Defaults to every
+	// operation.
+	Operations []string
+	// Tags limits the operations to those with any of the tags.
+	Tags []string
+}
+
+// GenerateOpenAPI returns a tools file with an http tool for each operation
+// of an OpenAPI 3 document, and a toolset for each tag. The output only
+// depends on the document and options, so it can be regenerated when the
+// document changes.
+func GenerateOpenAPI(spec []byte, opts OpenAPIOptions) ([]byte, error) {
+	var doc openAPIDocument
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q: only 3.x documents are supported", doc.OpenAPI)
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var toolsSection yaml.MapSlice
+	toolsets := make(map[string][]string)
+	found := make(map[string]bool)
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for _, op := range []struct {
+			method string
+			*openAPIOperation
+		}{
+			{"GET", item.Get}, {"PUT", item.Put}, {"POST", item.Post}, {"DELETE", item.Delete}, {"PATCH", item.Patch},
+		} {
+			if op.openAPIOperation == nil {
+				continue
+			}
+			if len(opts.Operations) > 0 && !slices.Contains(opts.Operations, op.OperationID) {
+				continue
+			}
+			if len(opts.Tags) > 0 && !slices.ContainsFunc(op.Tags, func(t string) bool { return slices.Contains(opts.Tags, t) }) {
+				continue
+			}
+			found[op.OperationID] = true
+
+			name := op.OperationID
+			if name == "" {
+				name = toolName(op.method, path)
+			}
+			name = invalidNameChars.ReplaceAllString(name, "_")
+			tool, err := doc.tool(opts.SourceName, op.method, path, item.Parameters, op.openAPIOperation)
+			if err != nil {
+				return nil, fmt.Errorf("unable to generate tool for %s %s: %w", op.method, path, err)
+			}
+			toolsSection = append(toolsSection, yaml.MapItem{Key: name, Value: tool})
+			for _, tag := range op.Tags {
+				tag = invalidNameChars.ReplaceAllString(tag, "_")
+				toolsets[tag] = append(toolsets[tag], name)
+			}
+		}
+	}
+	for _, id := range opts.Operations {
+		if !found[id] {
+			return nil, fmt.Errorf("operation %q not found", id)
+		}
+	}
+	if len(toolsSection) == 0 {
+		return nil, fmt.Errorf("no operations found")
+	}
+
+	var out yaml.MapSlice
+	if opts.IncludeSource {
+		baseURL := opts.BaseURL
+		if baseURL == "" && len(doc.Servers) > 0 {
+			baseURL = doc.Servers[0].URL
+		}
+		if baseURL == "" {
+			return nil, fmt.Errorf("the document has no servers, so a base URL must be given")
+		}
+		out = append(out, yaml.MapItem{Key: "sources", Value: yaml.MapSlice{{Key: opts.SourceName, Value: yaml.MapSlice{
+			{Key: "kind", Value: "http"},
+			{Key: "baseUrl", Value: strings.TrimSuffix(baseURL, "/")},
+		}}}})
+	}
+	out = append(out, yaml.MapItem{Key: "tools", Value: toolsSection})
+	if len(toolsets) > 0 {
+		tags := make([]string, 0, len(toolsets))
+		for t := range toolsets {
+			tags = append(tags, t)
+		}
+		sort.Strings(tags)
+		var section yaml.MapSlice
+		for _, t := range tags {
+			section = append(section, yaml.MapItem{Key: t, Value: toolsets[t]})
+		}
+		out = append(out, yaml.MapItem{Key: "toolsets", Value: section})
+	}
+	return yaml.MarshalWithOptions(out, yaml.IndentSequence(true), yaml.UseLiteralStyleIfMultiline(true))
+}
+
+// invalidNameChars are characters not allowed in tool and toolset names.
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// identifier matches names that can be used as fields in templates.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// templateField returns the template expression for a parameter's value.
+func templateField(name string) string {
+	if identifier.MatchString(name) {
+		return "." + name
+	}
+	return fmt.Sprintf("(index . %q)", name)
+}
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+func (d *openAPIDocument) tool(sourceName, method, path string, common []*openAPIParameter, op *openAPIOperation) (yaml.MapSlice, error) {
+	description := strings.TrimSpace(strings.Join(slices.DeleteFunc([]string{op.Summary, op.Description}, func(s string) bool { return s == "" }), "\n\n"))
+	if description == "" {
+		description = fmt.Sprintf("Calls %s %s.", method, path)
+	}
+	cfg := yaml.MapSlice{
+		{Key: "kind", Value: "http"},
+		{Key: "source", Value: sourceName},
+		{Key: "method", Value: method},
+		{Key: "path", Value: pathParam.ReplaceAllStringFunc(path, func(m string) string {
+			return "{{" + templateField(m[1:len(m)-1]) + "}}"
+		})},
+		{Key: "description", Value: description},
+	}
+
+	// operation parameters override those of the path with the same name
+	// and location
+	var params []*openAPIParameter
+	for _, p := range slices.Concat(common, op.Parameters) {
+		p, err := d.parameter(p)
+		if err != nil {
+			return nil, err
+		}
+		i := slices.IndexFunc(params, func(q *openAPIParameter) bool { return q.Name == p.Name && q.In == p.In })
+		if i >= 0 {
+			params[i] = p
+			continue
+		}
+		params = append(params, p)
+	}
+	sections := map[string]string{"path": "pathParams", "query": "queryParams", "header": "headerParams"}
+	grouped := make(map[string][]yaml.MapSlice)
+	for _, p := range params {
+		section, ok := sections[p.In]
+		if !ok {
+			// cookies aren't supported by the http tool
+			continue
+		}
+		s, err := d.schema(p.Schema)
+		if err != nil {
+			return nil, err
+		}
+		if p.In == "header" {
+			// the http tool only sends string headers
+			s = &openAPISchema{Type: "string", Enum: s.stringEnum()}
+		}
+		param, err := d.param(p.Name, p.Description, s, p.Required || p.In == "path")
+		if err != nil {
+			return nil, err
+		}
+		grouped[section] = append(grouped[section], param)
+	}
+	for _, section := range []string{"pathParams", "queryParams", "headerParams"} {
+		if len(grouped[section]) > 0 {
+			cfg = append(cfg, yaml.MapItem{Key: section, Value: grouped[section]})
+		}
+	}
+
+	if op.RequestBody == nil {
+		return cfg, nil
+	}
+	body, err := d.requestBody(op.RequestBody)
+	if err != nil {
+		return nil, err
+	}
+	content, ok := body.Content["application/json"]
+	if !ok {
+		return nil, fmt.Errorf("only application/json request bodies are supported")
+	}
+	s, err := d.schema(content.Schema)
+	if err != nil {
+		return nil, err
+	}
+	var bodyParams []yaml.MapSlice
+	var fields []string
+	if s.typeName() == "object" && len(s.Properties) > 0 {
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, err := d.schema(s.Properties[name])
+			if err != nil {
+				return nil, err
+			}
+			param, err := d.param(name, "", prop, body.Required && slices.Contains(s.Required, name))
+			if err != nil {
+				return nil, err
+			}
+			bodyParams = append(bodyParams, param)
+			fields = append(fields, fmt.Sprintf("  %q: {{json %s}}", name, templateField(name)))
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "requestBody", Value: "{\n" + strings.Join(fields, ",\n") + "\n}\n"})
+	} else {
+		param, err := d.param("body", body.Description, s, body.Required)
+		if err != nil {
+			return nil, err
+		}
+		bodyParams = append(bodyParams, param)
+		cfg = append(cfg, yaml.MapItem{Key: "requestBody", Value: "{{json .body}}\n"})
+	}
+	for _, p := range bodyParams {
+		name := p[0].Value.(string)
+		for _, section := range grouped {
+			if slices.ContainsFunc(section, func(q yaml.MapSlice) bool { return q[0].Value == name }) {
+				return nil, fmt.Errorf("body property %q has the same name as another parameter", name)
+			}
+		}
+	}
+	cfg = append(cfg,
+		yaml.MapItem{Key: "bodyParams", Value: bodyParams},
+		yaml.MapItem{Key: "headers", Value: yaml.MapSlice{{Key: "Content-Type", Value: "application/json"}}},
+	)
+	return cfg, nil
+}
+
+// param returns the parameter for a value described by a schema.
+func (d *openAPIDocument) param(name, description string, s *openAPISchema, required bool) (yaml.MapSlice, error) {
+	if s == nil {
+		s = &openAPISchema{}
+	}
+	if description == "" {
+		description = s.Description
+	}
+	if description == "" {
+		description = fmt.Sprintf("The %s parameter.", name)
+	}
+
+	typ := parameters.TypeString
+	var extra yaml.MapSlice
+	switch s.typeName() {
+	case "integer":
+		typ = parameters.TypeInt
+		if s.Minimum != nil {
+			extra = append(extra, yaml.MapItem{Key: "minValue", Value: int(*s.Minimum)})
+		}
+		if s.Maximum != nil {
+			extra = append(extra, yaml.MapItem{Key: "maxValue", Value: int(*s.Maximum)})
+		}
+	case "number":
+		typ = parameters.TypeFloat
+		if s.Minimum != nil {
+			extra = append(extra, yaml.MapItem{Key: "minValue", Value: *s.Minimum})
+		}
+		if s.Maximum != nil {
+			extra = append(extra, yaml.MapItem{Key: "maxValue", Value: *s.Maximum})
+		}
+	case "boolean":
+		typ = parameters.TypeBool
+	case "array":
+		typ = parameters.TypeArray
+		items, err := d.schema(s.Items)
+		if err != nil {
+			return nil, err
+		}
+		item, err := d.param(name+"_item", "", items, true)
+		if err != nil {
+			return nil, err
+		}
+		extra = append(extra, yaml.MapItem{Key: "items", Value: item})
+	case "object":
+		typ = parameters.TypeMap
+		if values, ok := s.AdditionalProperties.(map[string]any); ok {
+			switch values["type"] {
+			case "string":
+				extra = append(extra, yaml.MapItem{Key: "valueType", Value: parameters.TypeString})
+			case "integer":
+				extra = append(extra, yaml.MapItem{Key: "valueType", Value: parameters.TypeInt})
+			case "number":
+				extra = append(extra, yaml.MapItem{Key: "valueType", Value: parameters.TypeFloat})
+			case "boolean":
+				extra = append(extra, yaml.MapItem{Key: "valueType", Value: parameters.TypeBool})
+			}
+		}
+	}
+
+	p := yaml.MapSlice{
+		{Key: "name", Value: name},
+		{Key: "type", Value: typ},
+		{Key: "description", Value: description},
+	}
+	if !required {
+		p = append(p, yaml.MapItem{Key: "required", Value: false})
+	}
+	if len(s.Enum) > 0 {
+		p = append(p, yaml.MapItem{Key: "allowedValues", Value: s.Enum})
+	}
+	if s.Default != nil && typ != parameters.TypeArray && typ != parameters.TypeMap {
+		p = append(p, yaml.MapItem{Key: "default", Value: s.Default})
+	}
+	return append(p, extra...), nil
+}
+
+// stringEnum returns the enum of a schema as strings.
+func (s *openAPISchema) stringEnum() []any {
+	if s == nil || len(s.Enum) == 0 {
+		return nil
+	}
+	out := make([]any, len(s.Enum))
+	for i, v := range s.Enum {
+		out[i] = fmt.Sprint(v)
+	}
+	return out
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaffold
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const petstore = `
+openapi: 3.0.3
+servers:
+  - url: https://petstore.example.com/v1/
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List all pets
+      tags: [pets]
+      parameters:
+        - $ref: '#/components/parameters/limit'
+        - name: status
+          in: query
+          schema:
+            type: string
+            enum: [available, sold]
+            default: available
+    post:
+      operationId: createPet
+      summary: Create a pet
+      tags: [pets, admin]
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/NewPet'
+  /pets/{pet-id}:
+    parameters:
+      - name: pet-id
+        in: path
+        description: The id of the pet.
+        schema:
+          type: integer
+    delete:
+      operationId: deletePet
+      parameters:
+        - name: X-Reason
+          in: header
+          schema:
+            type: string
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      description: How many pets to return.
+      schema:
+        type: integer
+        minimum: 1
+        maximum: 100
+  schemas:
+    NewPet:
+      allOf:
+        - $ref: '#/components/schemas/Named'
+        - type: object
+          properties:
+            tags:
+              type: array
+              items:
+                type: string
+    Named:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          description: The name of the pet.
+`
+
+func TestGenerateOpenAPI(t *testing.T) {
+	got, err := GenerateOpenAPI([]byte(petstore), OpenAPIOptions{SourceName: "petstore", IncludeSource: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `sources:
+  petstore:
+    kind: http
+    baseUrl: https://petstore.example.com/v1
+tools:
+  listPets:
+    kind: http
+    source: petstore
+    method: GET
+    path: /pets
+    description: List all pets
+    queryParams:
+      - name: limit
+        type: integer
+        description: How many pets to return.
+        required: false
+        minValue: 1
+        maxValue: 100
+      - name: status
+        type: string
+        description: The status parameter.
+        required: false
+        allowedValues:
+          - available
+          - sold
+        default: available
+  createPet:
+    kind: http
+    source: petstore
+    method: POST
+    path: /pets
+    description: Create a pet
+    requestBody: |
+      {
+        "name": {{json .name}},
+        "tags": {{json .tags}}
+      }
+    bodyParams:
+      - name: name
+        type: string
+        description: The name of the pet.
+      - name: tags
+        type: array
+        description: The tags parameter.
+        required: false
+        items:
+          name: tags_item
+          type: string
+          description: The tags_item parameter.
+    headers:
+      Content-Type: application/json
+  deletePet:
+    kind: http
+    source: petstore
+    method: DELETE
+    path: /pets/{{(index . "pet-id")}}
+    description: Calls DELETE /pets/{pet-id}.
+    pathParams:
+      - name: pet-id
+        type: integer
+        description: The id of the pet.
+    headerParams:
+      - name: X-Reason
+        type: string
+        description: The X-Reason parameter.
+        required: false
+toolsets:
+  admin:
+    - createPet
+  pets:
+    - listPets
+    - createPet
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Fatalf("unexpected tools (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateOpenAPISelection(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		opts    OpenAPIOptions
+		want    []string
+		wantErr string
+	}{
+		{
+			desc: "operations",
+			opts: OpenAPIOptions{SourceName: "petstore", Operations: []string{"deletePet"}},
+			want: []string{"deletePet:"},
+		},
+		{
+			desc: "tags",
+			opts: OpenAPIOptions{SourceName: "petstore", Tags: []string{"admin"}},
+			want: []string{"createPet:"},
+		},
+		{
+			desc:    "unknown operation",
+			opts:    OpenAPIOptions{SourceName: "petstore", Operations: []string{"feedPet"}},
+			wantErr: `operation "feedPet" not found`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := GenerateOpenAPI([]byte(petstore), tc.opts)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var names []string
+			for _, line := range strings.Split(string(got), "\n") {
+				if strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "   ") && strings.HasSuffix(line, ":") {
+					names = append(names, strings.TrimSpace(line))
+				}
+			}
+			// toolsets are listed after tools
+			if diff := cmp.Diff(tc.want, names[:len(tc.want)]); diff != "" {
+				t.Fatalf("unexpected tools (-want +got):\n%s", diff)
+			}
+			if strings.Contains(string(got), "sources:") {
+				t.Fatalf("unexpected source in output")
+			}
+		})
+	}
+}
+
+func TestGenerateOpenAPIUnsupportedVersion(t *testing.T) {
+	_, err := GenerateOpenAPI([]byte("swagger: '2.0'\npaths: {}\n"), OpenAPIOptions{SourceName: "petstore"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported OpenAPI version") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
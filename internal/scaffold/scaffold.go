@@ -0,0 +1,335 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scaffold generates a starter tool configuration from the catalog of
+// a database.
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// DefaultLimit is the default number of rows returned by generated list and
+// search tools.
+const DefaultLimit = 50
+
+// dialect describes how to write statements for a kind of source.
+type dialect struct {
+	sourceKind    string
+	toolKind      string
+	defaultSchema string
+	quote         func(string) string
+	// placeholder returns the placeholder of the i-th parameter, starting at
+	// 1, named name
+	placeholder func(i int, name string) string
+	// selectRows returns a query for at most limit rows
+	selectRows func(from, where, orderBy, limit string) string
+	// contains returns a condition that any of the columns contains value
+	contains func(columns []string, value string) string
+}
+
+func quoteWith(open, close string) func(string) string {
+	return func(s string) string {
+		return open + strings.ReplaceAll(s, close, close+close) + close
+	}
+}
+
+func selectLimit(from, where, orderBy, limit string) string {
+	return "SELECT * FROM " + from + where + orderBy + " LIMIT " + limit
+}
+
+func positional(i int, name string) string { return "?" }
+
+func named(i int, name string) string { return "@" + name }
+
+var (
+	postgresDialect = dialect{
+		toolKind:      "postgres-sql",
+		defaultSchema: "public",
+		quote:         quoteWith(`"`, `"`),
+		placeholder:   func(i int, name string) string { return fmt.Sprintf("$%d", i) },
+		selectRows:    selectLimit,
+		contains: func(columns []string, value string) string {
+			return fmt.Sprintf("concat_ws(' ', %s) ILIKE '%%' || %s || '%%'", strings.Join(columns, ", "), value)
+		},
+	}
+	mysqlDialect = dialect{
+		toolKind:    "mysql-sql",
+		quote:       quoteWith("`", "`"),
+		placeholder: positional,
+		selectRows:  selectLimit,
+		contains: func(columns []string, value string) string {
+			return fmt.Sprintf("CONCAT_WS(' ', %s) LIKE CONCAT('%%', %s, '%%')", strings.Join(columns, ", "), value)
+		},
+	}
+	mssqlDialect = dialect{
+		toolKind:      "mssql-sql",
+		defaultSchema: "dbo",
+		quote:         quoteWith("[", "]"),
+		placeholder:   named,
+		selectRows: func(from, where, orderBy, limit string) string {
+			return "SELECT TOP (" + limit + ") * FROM " + from + where + orderBy
+		},
+		contains: func(columns []string, value string) string {
+			return fmt.Sprintf("CONCAT_WS(' ', %s) LIKE '%%' + %s + '%%'", strings.Join(columns, ", "), value)
+		},
+	}
+	sqliteDialect = dialect{
+		toolKind:    "sqlite-sql",
+		quote:       quoteWith(`"`, `"`),
+		placeholder: func(i int, name string) string { return fmt.Sprintf("?%d", i) },
+		selectRows:  selectLimit,
+		contains: func(columns []string, value string) string {
+			conds := make([]string, len(columns))
+			for i, c := range columns {
+				conds[i] = fmt.Sprintf("%s LIKE '%%' || %s || '%%'", c, value)
+			}
+			return strings.Join(conds, " OR ")
+		},
+	}
+	spannerDialect = dialect{
+		toolKind:    "spanner-sql",
+		quote:       quoteWith("`", "`"),
+		placeholder: named,
+		selectRows:  selectLimit,
+		contains: func(columns []string, value string) string {
+			conds := make([]string, len(columns))
+			for i, c := range columns {
+				conds[i] = fmt.Sprintf("STRPOS(LOWER(%s), LOWER(%s)) > 0", c, value)
+			}
+			return strings.Join(conds, " OR ")
+		},
+	}
+	bigqueryDialect = dialect{
+		toolKind:    "bigquery-sql",
+		quote:       quoteWith("`", "`"),
+		placeholder: named,
+		selectRows:  selectLimit,
+		contains: func(columns []string, value string) string {
+			conds := make([]string, len(columns))
+			for i, c := range columns {
+				conds[i] = fmt.Sprintf("CONTAINS_SUBSTR(%s, %s)", c, value)
+			}
+			return strings.Join(conds, " OR ")
+		},
+	}
+)
+
+// dialects are the dialects of each supported source kind.
+var dialects = map[string]dialect{
+	"postgres":           postgresDialect,
+	"cloud-sql-postgres": postgresDialect,
+	"alloydb-postgres":   postgresDialect,
+	"mysql":              mysqlDialect,
+	"cloud-sql-mysql":    mysqlDialect,
+	"mssql":              mssqlDialect,
+	"cloud-sql-mssql":    mssqlDialect,
+	"sqlite":             sqliteDialect,
+	"spanner":            spannerDialect,
+	"bigquery":           bigqueryDialect,
+}
+
+// Options selects what to generate tools for.
+type Options struct {
+	// SourceName is the name of the source in the generated tools.
+	SourceName string
+	// Schema to read tables from. Defaults to the source's default schema.
+	Schema string
+	// Tables to generate tools for. Defaults to every table in the schema.
+	Tables []string
+}
+
+// Generate reads the catalog of src and returns a tools file with list,
+// get-by-primary-key, and search tools for each table, along with a toolset
+// of all of them.
+func Generate(ctx context.Context, src sources.Source, opts Options) ([]byte, error) {
+	d, ok := dialects[src.SourceKind()]
+	if !ok {
+		return nil, fmt.Errorf("source kind %q is not supported", src.SourceKind())
+	}
+	d.sourceKind = src.SourceKind()
+	schema := opts.Schema
+	if schema == "" {
+		schema = d.defaultSchema
+	}
+	tables, err := readCatalog(ctx, d, src, schema, opts.Tables)
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables found in schema %q", schema)
+	}
+
+	var toolsSection yaml.MapSlice
+	var toolNames []string
+	for _, t := range tables {
+		for _, tool := range d.tools(opts.SourceName, schema != "", t) {
+			toolsSection = append(toolsSection, tool)
+			toolNames = append(toolNames, tool.Key.(string))
+		}
+	}
+	out := yaml.MapSlice{
+		{Key: "tools", Value: toolsSection},
+		{Key: "toolsets", Value: yaml.MapSlice{{Key: opts.SourceName + "-tables", Value: toolNames}}},
+	}
+	return yaml.MarshalWithOptions(out, yaml.IndentSequence(true))
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// toolName returns a snake_case tool name, e.g. get_hotels_by_id.
+func toolName(parts ...string) string {
+	for i, p := range parts {
+		parts[i] = strings.Trim(nonIdentifier.ReplaceAllString(strings.ToLower(p), "_"), "_")
+	}
+	return strings.Join(parts, "_")
+}
+
+// tools returns the tools generated for a table.
+func (d dialect) tools(sourceName string, qualify bool, t Table) []yaml.MapItem {
+	from := d.quote(t.Name)
+	if qualify {
+		from = d.quote(t.Schema) + "." + from
+		if d.toolKind == "bigquery-sql" {
+			from = d.quote(t.Schema + "." + t.Name)
+		}
+	}
+	subject := fmt.Sprintf("the %s table", t.Name)
+	if t.Comment != "" {
+		subject = fmt.Sprintf("the %s table (%s)", t.Name, strings.TrimSuffix(t.Comment, "."))
+	}
+
+	pk := t.PrimaryKey()
+	orderBy := ""
+	if len(pk) > 0 {
+		cols := make([]string, len(pk))
+		for i, c := range pk {
+			cols[i] = d.quote(c.Name)
+		}
+		orderBy = " ORDER BY " + strings.Join(cols, ", ")
+	}
+	limit := yaml.MapSlice{
+		{Key: "name", Value: "limit"},
+		{Key: "type", Value: parameters.TypeInt},
+		{Key: "description", Value: "The maximum number of rows to return."},
+		{Key: "default", Value: DefaultLimit},
+	}
+
+	var out []yaml.MapItem
+	out = append(out, d.tool(toolName("list", t.Name), sourceName,
+		fmt.Sprintf("Lists rows of %s.", subject),
+		d.selectRows(from, "", orderBy, d.placeholder(1, "limit")),
+		limit))
+
+	if len(pk) > 0 {
+		conds := make([]string, len(pk))
+		params := make([]yaml.MapSlice, len(pk))
+		names := make([]string, len(pk))
+		for i, c := range pk {
+			conds[i] = fmt.Sprintf("%s = %s", d.quote(c.Name), d.placeholder(i+1, c.Name))
+			params[i] = columnParameter(c)
+			names[i] = c.Name
+		}
+		statement := "SELECT * FROM " + from + " WHERE " + strings.Join(conds, " AND ")
+		out = append(out, d.tool(toolName(append([]string{"get", t.Name, "by"}, names...)...), sourceName,
+			fmt.Sprintf("Gets a row of %s by its primary key.", subject), statement, params...))
+	}
+
+	var text []string
+	for _, c := range t.Columns {
+		if isText(c.Type) {
+			text = append(text, d.quote(c.Name))
+		}
+	}
+	if len(text) > 0 {
+		where := " WHERE " + d.contains(text, d.placeholder(1, "query"))
+		query := yaml.MapSlice{
+			{Key: "name", Value: "query"},
+			{Key: "type", Value: parameters.TypeString},
+			{Key: "description", Value: "Text to search for in the text columns of the table."},
+		}
+		out = append(out, d.tool(toolName("search", t.Name), sourceName,
+			fmt.Sprintf("Searches rows of %s whose text columns contain the query.", subject),
+			d.selectRows(from, where, orderBy, d.placeholder(2, "limit")),
+			query, limit))
+	}
+	return out
+}
+
+func (d dialect) tool(name, sourceName, description, statement string, params ...yaml.MapSlice) yaml.MapItem {
+	cfg := yaml.MapSlice{
+		{Key: "kind", Value: d.toolKind},
+		{Key: "source", Value: sourceName},
+		{Key: "description", Value: description},
+		{Key: "statement", Value: statement},
+	}
+	if len(params) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "parameters", Value: params})
+	}
+	return yaml.MapItem{Key: name, Value: cfg}
+}
+
+func columnParameter(c Column) yaml.MapSlice {
+	description := fmt.Sprintf("The %s column.", c.Name)
+	if c.Comment != "" {
+		description = c.Comment
+	}
+	return yaml.MapSlice{
+		{Key: "name", Value: c.Name},
+		{Key: "type", Value: ParameterType(c.Type)},
+		{Key: "description", Value: description},
+	}
+}
+
+// baseType returns the lower-cased name of a column type without its
+// arguments, e.g. "varchar" for "VARCHAR(255)".
+func baseType(columnType string) string {
+	t := strings.ToLower(strings.TrimSpace(columnType))
+	if i := strings.IndexAny(t, "( <"); i >= 0 {
+		t = t[:i]
+	}
+	return t
+}
+
+// ParameterType returns the type of the parameter for a column type.
+func ParameterType(columnType string) string {
+	switch baseType(columnType) {
+	case "int", "integer", "int2", "int4", "int8", "int64", "smallint", "bigint", "tinyint", "mediumint",
+		"serial", "smallserial", "bigserial":
+		return parameters.TypeInt
+	case "float", "float4", "float8", "float32", "float64", "double", "real", "numeric", "bignumeric",
+		"decimal", "money", "smallmoney", "number":
+		return parameters.TypeFloat
+	case "bool", "boolean", "bit":
+		return parameters.TypeBool
+	default:
+		return parameters.TypeString
+	}
+}
+
+func isText(columnType string) bool {
+	switch baseType(columnType) {
+	case "text", "varchar", "char", "character", "nvarchar", "nchar", "ntext", "string", "citext",
+		"tinytext", "mediumtext", "longtext", "bpchar", "clob":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaffold
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func newSQLiteSource(t *testing.T, ctx context.Context, schema string) sources.Source {
+	t.Helper()
+	cfg := sqlite.Config{Name: "my-sqlite", Kind: "sqlite", Database: filepath.Join(t.TempDir(), "test.db")}
+	src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	t.Cleanup(func() { _ = src.(*sqlite.Source).SQLiteDB().Close() })
+	if _, err := src.(*sqlite.Source).SQLiteDB().ExecContext(ctx, schema); err != nil {
+		t.Fatalf("unable to create tables: %s", err)
+	}
+	return src
+}
+
+func TestGenerate(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unable to create context: %s", err)
+	}
+	src := newSQLiteSource(t, ctx, `
+CREATE TABLE hotels (id INTEGER PRIMARY KEY, name VARCHAR(255), rating REAL, booked BOOLEAN);
+CREATE TABLE "order items" (order_id INTEGER, line INTEGER, qty INTEGER, PRIMARY KEY (order_id, line));
+CREATE TABLE logs (message TEXT);
+`)
+
+	got, err := Generate(ctx, src, Options{SourceName: "my-sqlite", Tables: []string{"hotels", "order items", "logs"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `tools:
+  list_hotels:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: Lists rows of the hotels table.
+    statement: SELECT * FROM "hotels" ORDER BY "id" LIMIT ?1
+    parameters:
+      - name: limit
+        type: integer
+        description: The maximum number of rows to return.
+        default: 50
+  get_hotels_by_id:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: Gets a row of the hotels table by its primary key.
+    statement: SELECT * FROM "hotels" WHERE "id" = ?1
+    parameters:
+      - name: id
+        type: integer
+        description: The id column.
+  search_hotels:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: Searches rows of the hotels table whose text columns contain the query.
+    statement: SELECT * FROM "hotels" WHERE "name" LIKE '%' || ?1 || '%' ORDER BY "id" LIMIT ?2
+    parameters:
+      - name: query
+        type: string
+        description: Text to search for in the text columns of the table.
+      - name: limit
+        type: integer
+        description: The maximum number of rows to return.
+        default: 50
+  list_order_items:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: Lists rows of the order items table.
+    statement: SELECT * FROM "order items" ORDER BY "order_id", "line" LIMIT ?1
+    parameters:
+      - name: limit
+        type: integer
+        description: The maximum number of rows to return.
+        default: 50
+  get_order_items_by_order_id_line:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: Gets a row of the order items table by its primary key.
+    statement: SELECT * FROM "order items" WHERE "order_id" = ?1 AND "line" = ?2
+    parameters:
+      - name: order_id
+        type: integer
+        description: The order_id column.
+      - name: line
+        type: integer
+        description: The line column.
+  list_logs:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: Lists rows of the logs table.
+    statement: SELECT * FROM "logs" LIMIT ?1
+    parameters:
+      - name: limit
+        type: integer
+        description: The maximum number of rows to return.
+        default: 50
+  search_logs:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: Searches rows of the logs table whose text columns contain the query.
+    statement: SELECT * FROM "logs" WHERE "message" LIKE '%' || ?1 || '%' LIMIT ?2
+    parameters:
+      - name: query
+        type: string
+        description: Text to search for in the text columns of the table.
+      - name: limit
+        type: integer
+        description: The maximum number of rows to return.
+        default: 50
+toolsets:
+  my-sqlite-tables:
+    - list_hotels
+    - get_hotels_by_id
+    - search_hotels
+    - list_order_items
+    - get_order_items_by_order_id_line
+    - list_logs
+    - search_logs
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Fatalf("unexpected tools (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unable to create context: %s", err)
+	}
+	src := newSQLiteSource(t, ctx, `CREATE TABLE hotels (id INTEGER PRIMARY KEY)`)
+
+	tcs := []struct {
+		desc string
+		opts Options
+		want string
+	}{
+		{
+			desc: "unknown table",
+			opts: Options{SourceName: "my-sqlite", Tables: []string{"flights"}},
+			want: `table "flights" not found in schema ""`,
+		},
+		{
+			desc: "empty schema",
+			opts: Options{SourceName: "my-sqlite", Schema: "other"},
+			want: `no tables found in schema "other"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := Generate(ctx, src, tc.opts)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParameterType(t *testing.T) {
+	tcs := map[string]string{
+		"INTEGER":                  "integer",
+		"bigint":                   "integer",
+		"INT64":                    "integer",
+		"numeric(10,2)":            "float",
+		"double precision":         "float",
+		"FLOAT64":                  "float",
+		"boolean":                  "boolean",
+		"character varying(255)":   "string",
+		"timestamp with time zone": "string",
+		"ARRAY<STRING(MAX)>":       "string",
+	}
+	for in, want := range tcs {
+		if got := ParameterType(in); got != want {
+			t.Errorf("ParameterType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
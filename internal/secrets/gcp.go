@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+// GCPManager is the name of Google Cloud Secret Manager in references, e.g.
+// ${secret:gcp/projects/my-project/secrets/pg-password}.
+const GCPManager = "gcp"
+
+// SecretAccessor accesses versions of secrets in Google Cloud Secret Manager.
+type SecretAccessor interface {
+	// AccessSecretVersion returns the payload of a secret version, named
+	// projects/*/secrets/*/versions/*.
+	AccessSecretVersion(ctx context.Context, name string) ([]byte, error)
+}
+
+// GCPSecretManager resolves names of secrets in Google Cloud Secret Manager.
+// Names without a version resolve to the latest version.
+type GCPSecretManager struct {
+	mu       sync.Mutex
+	accessor SecretAccessor
+}
+
+// NewGCPSecretManager returns a GCPSecretManager using accessor. If accessor
+// is nil, a client using Application Default Credentials is created when a
+// secret is first resolved.
+func NewGCPSecretManager(accessor SecretAccessor) *GCPSecretManager {
+	return &GCPSecretManager{accessor: accessor}
+}
+
+func (m *GCPSecretManager) Resolve(ctx context.Context, name string) (string, error) {
+	parts := strings.Split(name, "/")
+	switch {
+	case len(parts) == 4 && parts[0] == "projects" && parts[2] == "secrets":
+		name += "/versions/latest"
+	case len(parts) == 6 && parts[0] == "projects" && parts[2] == "secrets" && parts[4] == "versions":
+	default:
+		return "", fmt.Errorf("secret name %q must be of the form projects/<project>/secrets/<secret>[/versions/<version>]", name)
+	}
+
+	accessor, err := m.getAccessor(ctx)
+	if err != nil {
+		return "", err
+	}
+	b, err := accessor.AccessSecretVersion(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("unable to access secret version %q: %w", name, err)
+	}
+	return string(b), nil
+}
+
+func (m *GCPSecretManager) getAccessor(ctx context.Context) (SecretAccessor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.accessor != nil {
+		return m.accessor, nil
+	}
+	opts := []option.ClientOption{}
+	if ua, err := util.UserAgentFromContext(ctx); err == nil {
+		opts = append(opts, option.WithUserAgent(ua))
+	}
+	// the client outlives the call that creates it
+	svc, err := secretmanager.NewService(context.WithoutCancel(ctx), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Secret Manager client: %w", err)
+	}
+	m.accessor = gcpAccessor{svc}
+	return m.accessor, nil
+}
+
+type gcpAccessor struct {
+	svc *secretmanager.Service
+}
+
+func (a gcpAccessor) AccessSecretVersion(ctx context.Context, name string) ([]byte, error) {
+	resp, err := a.svc.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	if resp.Payload == nil {
+		return nil, fmt.Errorf("secret version has no payload")
+	}
+	return base64.StdEncoding.DecodeString(resp.Payload.Data)
+}
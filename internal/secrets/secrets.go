@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secrets resolves references to secrets, such as ${file:/path}, in
+// tool configuration files.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// FileScheme reads a secret from a local file, e.g. ${file:/run/secrets/pg}.
+	FileScheme = "file"
+	// SecretScheme reads a secret from a secret manager, e.g.
+	// ${secret:gcp/projects/p/secrets/s}.
+	SecretScheme = "secret"
+)
+
+// Provider fetches the value of a secret from a reference.
+// Implementations must be safe for concurrent use.
+type Provider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+var (
+	providerRegistryMu sync.RWMutex
+	providerRegistry   = map[string]Provider{
+		FileScheme:   FileProvider{},
+		SecretScheme: NewManagerProvider(map[string]Provider{GCPManager: NewGCPSecretManager(nil)}),
+	}
+)
+
+// RegisterProvider allows additional providers to be made available by
+// scheme. It returns false if a provider with the same scheme is already
+// registered.
+func RegisterProvider(scheme string, p Provider) bool {
+	providerRegistryMu.Lock()
+	defer providerRegistryMu.Unlock()
+	if _, exists := providerRegistry[scheme]; exists {
+		return false
+	}
+	providerRegistry[scheme] = p
+	return true
+}
+
+// Providers returns the registered providers by scheme.
+func Providers() map[string]Provider {
+	providerRegistryMu.RLock()
+	defer providerRegistryMu.RUnlock()
+	return maps.Clone(providerRegistry)
+}
+
+// FileProvider reads secrets from local files. A single trailing newline is
+// removed, since most tools that write secret files add one.
+type FileProvider struct{}
+
+func (FileProvider) Resolve(ctx context.Context, path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read secret file: %w", err)
+	}
+	s := strings.TrimSuffix(string(b), "\n")
+	return strings.TrimSuffix(s, "\r"), nil
+}
+
+// ManagerProvider dispatches references of the form <manager>/<name> to the
+// provider of a secret manager.
+type ManagerProvider struct {
+	managers map[string]Provider
+}
+
+// NewManagerProvider returns a ManagerProvider for the given managers, by
+// the name used as the first element of references.
+func NewManagerProvider(managers map[string]Provider) *ManagerProvider {
+	return &ManagerProvider{managers: managers}
+}
+
+func (p *ManagerProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	manager, name, _ := strings.Cut(ref, "/")
+	m, ok := p.managers[manager]
+	if !ok {
+		return "", fmt.Errorf("unknown secret manager %q: must be one of %q", manager, slices.Sorted(maps.Keys(p.managers)))
+	}
+	return m.Resolve(ctx, name)
+}
+
+// Resolver resolves references with the registered providers, caching their
+// values until they're refreshed. It's safe for concurrent use.
+type Resolver struct {
+	providers map[string]Provider
+
+	mu    sync.Mutex
+	cache map[reference]string
+}
+
+type reference struct {
+	scheme, ref string
+}
+
+func (r reference) String() string {
+	return fmt.Sprintf("${%s:%s}", r.scheme, r.ref)
+}
+
+// NewResolver returns a Resolver using the given providers, by scheme.
+func NewResolver(providers map[string]Provider) *Resolver {
+	return &Resolver{providers: providers, cache: make(map[reference]string)}
+}
+
+// Handles reports whether scheme is the scheme of a provider.
+func (r *Resolver) Handles(scheme string) bool {
+	_, ok := r.providers[scheme]
+	return ok
+}
+
+// Resolve returns the value of a reference, fetching it only if it isn't
+// cached.
+func (r *Resolver) Resolve(ctx context.Context, scheme, ref string) (string, error) {
+	key := reference{scheme, ref}
+	r.mu.Lock()
+	v, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok {
+		return v, nil
+	}
+
+	p, ok := r.providers[scheme]
+	if !ok {
+		return "", fmt.Errorf("unknown secret provider %q", scheme)
+	}
+	v, err := p.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve %s: %w", key, err)
+	}
+	r.mu.Lock()
+	r.cache[key] = v
+	r.mu.Unlock()
+	return v, nil
+}
+
+// Refresh fetches every cached reference again, and reports whether any of
+// their values changed. References that fail to resolve keep their cached
+// value, and their errors are returned.
+func (r *Resolver) Refresh(ctx context.Context) (bool, error) {
+	r.mu.Lock()
+	keys := slices.Collect(maps.Keys(r.cache))
+	r.mu.Unlock()
+
+	changed := false
+	var errs []error
+	for _, key := range keys {
+		v, err := r.providers[key.scheme].Resolve(ctx, key.ref)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to refresh %s: %w", key, err))
+			continue
+		}
+		r.mu.Lock()
+		if r.cache[key] != v {
+			r.cache[key] = v
+			changed = true
+		}
+		r.mu.Unlock()
+	}
+	if len(errs) > 0 {
+		return changed, fmt.Errorf("%d secrets failed to refresh: %v", len(errs), errs)
+	}
+	return changed, nil
+}
+
+type contextKey string
+
+// resolverKey is the key used to store the resolver within context
+const resolverKey contextKey = "secretResolver"
+
+// WithResolver adds a resolver into the context as a value
+func WithResolver(ctx context.Context, r *Resolver) context.Context {
+	return context.WithValue(ctx, resolverKey, r)
+}
+
+// ResolverFromContext retrieves the resolver from the context, or a new
+// resolver with the registered providers if there's none.
+func ResolverFromContext(ctx context.Context) *Resolver {
+	if r, ok := ctx.Value(resolverKey).(*Resolver); ok {
+		return r
+	}
+	return NewResolver(Providers())
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	tcs := map[string]string{
+		"plain":   "s3cret",
+		"newline": "s3cret\n",
+		"crlf":    "s3cret\r\n",
+	}
+	for name, content := range tcs {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("unable to write secret file: %s", err)
+		}
+		got, err := FileProvider{}.Resolve(context.Background(), path)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != "s3cret" {
+			t.Errorf("%s: got %q, want %q", name, got, "s3cret")
+		}
+	}
+	if _, err := (FileProvider{}).Resolve(context.Background(), filepath.Join(dir, "missing")); err == nil {
+		t.Fatalf("expected an error for a missing file")
+	}
+}
+
+// fakeAccessor counts accesses to secret versions.
+type fakeAccessor struct {
+	values   map[string]string
+	accessed map[string]int
+}
+
+func (a *fakeAccessor) AccessSecretVersion(ctx context.Context, name string) ([]byte, error) {
+	a.accessed[name]++
+	v, ok := a.values[name]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return []byte(v), nil
+}
+
+func TestGCPSecretManager(t *testing.T) {
+	accessor := &fakeAccessor{
+		values: map[string]string{
+			"projects/p/secrets/s/versions/latest": "latest",
+			"projects/p/secrets/s/versions/2":      "second",
+		},
+		accessed: map[string]int{},
+	}
+	secret := NewManagerProvider(map[string]Provider{GCPManager: NewGCPSecretManager(accessor)})
+
+	tcs := []struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{ref: "gcp/projects/p/secrets/s", want: "latest"},
+		{ref: "gcp/projects/p/secrets/s/versions/2", want: "second"},
+		{ref: "gcp/p/s", wantErr: "must be of the form"},
+		{ref: "gcp/projects/p/secrets/missing", wantErr: `unable to access secret version "projects/p/secrets/missing/versions/latest"`},
+		{ref: "vault/kv/pg", wantErr: `unknown secret manager "vault"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.ref, func(t *testing.T) {
+			got, err := secret.Resolve(context.Background(), tc.ref)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolverRefresh(t *testing.T) {
+	ctx := context.Background()
+	accessor := &fakeAccessor{
+		values:   map[string]string{"projects/p/secrets/s/versions/latest": "old"},
+		accessed: map[string]int{},
+	}
+	r := NewResolver(map[string]Provider{
+		SecretScheme: NewManagerProvider(map[string]Provider{GCPManager: NewGCPSecretManager(accessor)}),
+	})
+
+	for i := 0; i < 2; i++ {
+		got, err := r.Resolve(ctx, SecretScheme, "gcp/projects/p/secrets/s")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != "old" {
+			t.Fatalf("got %q, want %q", got, "old")
+		}
+	}
+	if n := accessor.accessed["projects/p/secrets/s/versions/latest"]; n != 1 {
+		t.Fatalf("expected the secret to be cached, but it was accessed %d times", n)
+	}
+
+	changed, err := r.Refresh(ctx)
+	if err != nil || changed {
+		t.Fatalf("unexpected refresh result: changed %t, error %v", changed, err)
+	}
+
+	// rotate the secret
+	accessor.values["projects/p/secrets/s/versions/latest"] = "new"
+	changed, err = r.Refresh(ctx)
+	if err != nil || !changed {
+		t.Fatalf("unexpected refresh result: changed %t, error %v", changed, err)
+	}
+	got, err := r.Resolve(ctx, SecretScheme, "gcp/projects/p/secrets/s")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "new" {
+		t.Fatalf("got %q, want %q", got, "new")
+	}
+
+	// failed refreshes keep the last value
+	delete(accessor.values, "projects/p/secrets/s/versions/latest")
+	if _, err := r.Refresh(ctx); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, _ := r.Resolve(ctx, SecretScheme, "gcp/projects/p/secrets/s"); got != "new" {
+		t.Fatalf("got %q, want %q", got, "new")
+	}
+
+	if _, err := r.Resolve(ctx, "vault", "kv/pg"); err == nil {
+		t.Fatalf("expected an error for an unknown provider")
+	}
+}
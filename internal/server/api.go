@@ -41,6 +41,8 @@ func apiRouter(s *Server) (chi.Router, error) {
 
 	r.Get("/toolset", func(w http.ResponseWriter, r *http.Request) { toolsetHandler(s, w, r) })
 	r.Get("/toolset/{toolsetName}", func(w http.ResponseWriter, r *http.Request) { toolsetHandler(s, w, r) })
+	r.Get("/promptset", func(w http.ResponseWriter, r *http.Request) { promptsetHandler(s, w, r) })
+	r.Get("/promptset/{promptsetName}", func(w http.ResponseWriter, r *http.Request) { promptsetHandler(s, w, r) })
 
 	r.Route("/tool/{toolName}", func(r chi.Router) {
 		r.Get("/", func(w http.ResponseWriter, r *http.Request) { toolGetHandler(s, w, r) })
@@ -87,6 +89,43 @@ func toolsetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, toolset.Manifest)
 }
 
+// promptsetHandler handles the request for information about a Promptset.
+func promptsetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/promptset/get")
+	r = r.WithContext(ctx)
+
+	promptsetName := chi.URLParam(r, "promptsetName")
+	s.logger.DebugContext(ctx, fmt.Sprintf("promptset name: %s", promptsetName))
+	span.SetAttributes(attribute.String("promptset_name", promptsetName))
+	var err error
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		s.instrumentation.PromptsetGet.Add(
+			r.Context(),
+			1,
+			metric.WithAttributes(attribute.String("toolbox.name", promptsetName)),
+			metric.WithAttributes(attribute.String("toolbox.operation.status", status)),
+		)
+	}()
+
+	promptset, ok := s.ResourceMgr.GetPromptset(promptsetName)
+	if !ok {
+		err = fmt.Errorf("promptset %q does not exist", promptsetName)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	render.JSON(w, r, promptset.Manifest)
+}
+
 // toolGetHandler handles requests for a single Tool.
 func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/get")
@@ -136,6 +175,7 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/invoke")
 	r = r.WithContext(ctx)
 	ctx = util.WithLogger(r.Context(), s.logger)
+	ctx = util.WithInstrumentation(ctx, s.instrumentation)
 
 	toolName := chi.URLParam(r, "toolName")
 	s.logger.DebugContext(ctx, fmt.Sprintf("tool name: %s", toolName))
@@ -221,6 +261,8 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	s.logger.DebugContext(ctx, "tool invocation authorized")
+	ctx = util.WithVerifiedAuthServices(ctx, verifiedAuthServices)
+	ctx = util.WithClaims(ctx, claimsFromAuth)
 
 	var data map[string]any
 	if err = util.DecodeJSON(r.Body, &data); err != nil {
@@ -246,7 +288,7 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 	s.logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
 
-	res, err := tool.Invoke(ctx, s.ResourceMgr, params, accessToken)
+	res, err := tools.Invoke(ctx, tool, s.ResourceMgr, params, accessToken)
 
 	// Determine what error to return to the users.
 	if err != nil {
@@ -255,6 +297,10 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 
 		// Upstream API auth error propagation
 		switch {
+		case errors.Is(err, tools.ErrEmbedding):
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+			return
 		case strings.Contains(errStr, "Error 401"):
 			statusCode = http.StatusUnauthorized
 		case strings.Contains(errStr, "Error 403"):
@@ -20,9 +20,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 )
 
@@ -123,6 +126,71 @@ func TestToolsetEndpoint(t *testing.T) {
 	}
 }
 
+func TestPromptsetEndpoint(t *testing.T) {
+	mockPrompts := []MockPrompt{prompt1, prompt2}
+	_, _, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool2}, mockPrompts)
+	r, shutdown := setUpServer(t, "api", nil, nil, promptsMap, promptsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name          string
+		promptsetName string
+		wantStatus    int
+		wantPrompts   []string
+	}{
+		{
+			name:          "'default' manifest",
+			promptsetName: "",
+			wantStatus:    http.StatusOK,
+			wantPrompts:   []string{prompt1.Name, prompt2.Name},
+		},
+		{
+			name:          "single promptset",
+			promptsetName: "prompt1_only",
+			wantStatus:    http.StatusOK,
+			wantPrompts:   []string{prompt1.Name},
+		},
+		{
+			name:          "invalid promptset name",
+			promptsetName: "some_imaginary_promptset",
+			wantStatus:    http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodGet, fmt.Sprintf("/promptset/%s", tc.promptsetName), nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Logf("response body: %s", body)
+				t.Fatalf("unexpected status code: want %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var m prompts.PromptsetManifest
+			if err := json.Unmarshal(body, &m); err != nil {
+				t.Fatalf("unable to parse PromptsetManifest: %s", err)
+			}
+			if m.ServerVersion != fakeVersionString {
+				t.Fatalf("unexpected ServerVersion: want %q, got %q", fakeVersionString, m.ServerVersion)
+			}
+			var got []string
+			for name := range m.PromptsManifest {
+				got = append(got, name)
+			}
+			slices.Sort(got)
+			if diff := cmp.Diff(tc.wantPrompts, got); diff != "" {
+				t.Fatalf("incorrect prompts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestToolGetEndpoint(t *testing.T) {
 	mockTools := []MockTool{tool1, tool2}
 	toolsMap, toolsets, _, _ := setUpResources(t, mockTools, nil)
@@ -244,12 +244,17 @@ func setUpResources(t *testing.T, mockTools []MockTool, mockPrompts []MockPrompt
 
 	promptsets := make(map[string]prompts.Promptset)
 	if len(allPrompts) > 0 {
-		psc := prompts.PromptsetConfig{Name: "", PromptNames: allPrompts}
-		ps, err := psc.Initialize(fakeVersionString, promptsMap)
-		if err != nil {
-			t.Fatalf("unable to initialize default promptset: %s", err)
+		for name, l := range map[string][]string{
+			"":             allPrompts,
+			"prompt1_only": {allPrompts[0]},
+		} {
+			psc := prompts.PromptsetConfig{Name: name, PromptNames: l}
+			ps, err := psc.Initialize(fakeVersionString, promptsMap)
+			if err != nil {
+				t.Fatalf("unable to initialize promptset %q: %s", name, err)
+			}
+			promptsets[name] = ps
 		}
-		promptsets[""] = ps
 	}
 
 	return toolsMap, toolsets, promptsMap, promptsets
@@ -276,7 +281,7 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 
 	sseManager := newSseManager(ctx)
 
-	resourceManager := resources.NewResourceManager(nil, nil, tools, toolsets, prompts, promptsets)
+	resourceManager := resources.NewResourceManager(nil, nil, nil, tools, toolsets, prompts, promptsets)
 
 	server := Server{
 		version:         fakeVersionString,
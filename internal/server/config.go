@@ -21,10 +21,15 @@ import (
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/auth/google"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/openai"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/vertexai"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/cache"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 )
 
 type ServerConfig struct {
@@ -38,6 +43,8 @@ type ServerConfig struct {
 	SourceConfigs SourceConfigs
 	// AuthServiceConfigs defines what sources of authentication are available for tools.
 	AuthServiceConfigs AuthServiceConfigs
+	// EmbeddingModelConfigs defines what models are available to embed parameters.
+	EmbeddingModelConfigs EmbeddingModelConfigs
 	// ToolConfigs defines what tools are available.
 	ToolConfigs ToolConfigs
 	// ToolsetConfigs defines what tools are available.
@@ -205,6 +212,55 @@ func (c *AuthServiceConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(i
 	return nil
 }
 
+// EmbeddingModelConfigs is a type used to allow unmarshal of the embedding model configs
+type EmbeddingModelConfigs map[string]embeddingmodels.EmbeddingModelConfig
+
+// validate interface
+var _ yaml.InterfaceUnmarshalerContext = &EmbeddingModelConfigs{}
+
+func (c *EmbeddingModelConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	*c = make(EmbeddingModelConfigs)
+	// Parse the 'kind' fields for each embedding model
+	var raw map[string]util.DelayedUnmarshaler
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	for name, u := range raw {
+		var v map[string]any
+		if err := u.Unmarshal(&v); err != nil {
+			return fmt.Errorf("unable to unmarshal %q: %w", name, err)
+		}
+
+		kind, ok := v["kind"]
+		if !ok {
+			return fmt.Errorf("missing 'kind' field for embedding model %q", name)
+		}
+
+		dec, err := util.NewStrictDecoder(v)
+		if err != nil {
+			return fmt.Errorf("error creating decoder: %w", err)
+		}
+		switch kind {
+		case openai.EmbeddingModelKind:
+			actual := openai.Config{Name: name}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return fmt.Errorf("unable to parse as %q: %w", kind, err)
+			}
+			(*c)[name] = actual
+		case vertexai.EmbeddingModelKind:
+			actual := vertexai.Config{Name: name}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return fmt.Errorf("unable to parse as %q: %w", kind, err)
+			}
+			(*c)[name] = actual
+		default:
+			return fmt.Errorf("%q is not a valid kind of embedding model", kind)
+		}
+	}
+	return nil
+}
+
 // ToolConfigs is a type used to allow unmarshal of the tool configs
 type ToolConfigs map[string]tools.ToolConfig
 
@@ -235,6 +291,14 @@ func (c *ToolConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interfac
 			v["authRequired"] = []string{}
 		}
 
+		// `cache` is handled for every tool kind, so it is removed before the
+		// kind-specific config is decoded
+		rawCache, hasCache := v["cache"]
+		delete(v, "cache")
+		// `rules` are also checked for every tool kind
+		rawRules, hasRules := v["rules"]
+		delete(v, "rules")
+
 		kindVal, ok := v["kind"]
 		if !ok {
 			return fmt.Errorf("missing 'kind' field for tool %q", name)
@@ -253,6 +317,28 @@ func (c *ToolConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interfac
 		if err != nil {
 			return err
 		}
+		if hasRules {
+			rulesDecoder, err := util.NewStrictDecoder(rawRules)
+			if err != nil {
+				return fmt.Errorf("error creating YAML decoder for rules of tool %q: %w", name, err)
+			}
+			var rules []parameters.Rule
+			if err := rulesDecoder.DecodeContext(ctx, &rules); err != nil {
+				return fmt.Errorf("unable to parse rules for tool %q: %w", name, err)
+			}
+			toolCfg = tools.RuledToolConfig{ToolConfig: toolCfg, Rules: rules}
+		}
+		if hasCache {
+			cacheDecoder, err := util.NewStrictDecoder(rawCache)
+			if err != nil {
+				return fmt.Errorf("error creating YAML decoder for cache of tool %q: %w", name, err)
+			}
+			var cacheCfg cache.Config
+			if err := cacheDecoder.DecodeContext(ctx, &cacheCfg); err != nil {
+				return fmt.Errorf("unable to parse cache for tool %q: %w", name, err)
+			}
+			toolCfg = tools.CachedToolConfig{ToolConfig: toolCfg, Cache: cacheCfg}
+		}
 		(*c)[name] = toolCfg
 	}
 	return nil
@@ -36,6 +36,7 @@ import (
 	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	v20241105 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20241105"
 	v20250326 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20250326"
+	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -375,8 +376,9 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		protocolVersion = headerProtocolVersion
 	}
 
+	// the toolset and promptset are picked by the same name
 	toolsetName := chi.URLParam(r, "toolsetName")
-	promptsetName := chi.URLParam(r, "promptsetName")
+	promptsetName := toolsetName
 	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s", toolsetName))
 	span.SetAttributes(attribute.String("toolset_name", toolsetName))
 
@@ -463,6 +465,7 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 
 // processMcpMessage process the messages received from clients
 func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVersion string, toolsetName string, promptsetName string, header http.Header) (string, any, error) {
+	ctx = util.WithInstrumentation(ctx, s.instrumentation)
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		return "", jsonrpc.NewError("", jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
@@ -512,15 +515,22 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 		}
 		return v, res, err
 	default:
-		toolset, ok := s.ResourceMgr.GetToolset(toolsetName)
-		if !ok {
+		toolset, hasToolset := s.ResourceMgr.GetToolset(toolsetName)
+		promptset, hasPromptset := s.ResourceMgr.GetPromptset(promptsetName)
+		switch {
+		case !hasToolset && !hasPromptset:
 			err = fmt.Errorf("toolset does not exist")
 			return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
-		}
-		promptset, ok := s.ResourceMgr.GetPromptset(promptsetName)
-		if !ok {
-			err = fmt.Errorf("promptset does not exist")
-			return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+		case !hasToolset:
+			// a promptset without a toolset of the same name serves no tools
+			toolset = tools.Toolset{ToolsetConfig: tools.ToolsetConfig{Name: toolsetName}, McpManifest: []tools.McpManifest{}}
+		case !hasPromptset:
+			// a toolset without a promptset of the same name serves every prompt
+			promptset, hasPromptset = s.ResourceMgr.GetPromptset("")
+			if !hasPromptset {
+				err = fmt.Errorf("promptset does not exist")
+				return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+			}
 		}
 		res, err := mcp.ProcessMethod(ctx, protocolVersion, baseMessage.Id, baseMessage.Method, toolset, promptset, s.ResourceMgr, body, header)
 		return "", res, err
@@ -175,6 +175,8 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 	}
 	logger.DebugContext(ctx, "tool invocation authorized")
+	ctx = util.WithVerifiedAuthServices(ctx, verifiedAuthServices)
+	ctx = util.WithClaims(ctx, claimsFromAuth)
 
 	params, err := tool.ParseParams(data, claimsFromAuth)
 	if err != nil {
@@ -184,13 +186,16 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 	logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
 
 	// run tool invocation and generate response.
-	results, err := tool.Invoke(ctx, resourceMgr, params, accessToken)
+	results, err := tools.Invoke(ctx, tool, resourceMgr, params, accessToken)
 	if err != nil {
 		errStr := err.Error()
 		// Missing authService tokens.
 		if errors.Is(err, util.ErrUnauthorized) {
 			return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 		}
+		if errors.Is(err, tools.ErrEmbedding) {
+			return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+		}
 		// Upstream auth error
 		if strings.Contains(errStr, "Error 401") || strings.Contains(errStr, "Error 403") {
 			if clientAuth {
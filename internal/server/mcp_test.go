@@ -660,6 +660,75 @@ func TestMcpEndpoint(t *testing.T) {
 						},
 					},
 				},
+				{
+					name: "prompts/list on tool1_only",
+					url:  "/tool1_only",
+					body: jsonrpc.JSONRPCRequest{
+						Jsonrpc: jsonrpcVersion,
+						Id:      "prompts-list-tool1",
+						Request: jsonrpc.Request{
+							Method: "prompts/list",
+						},
+					},
+					wantStatusCode: http.StatusOK,
+					want: map[string]any{
+						"jsonrpc": "2.0",
+						"id":      "prompts-list-tool1",
+						"result": map[string]any{
+							"prompts": []any{
+								map[string]any{
+									"name": "prompt1",
+								},
+								map[string]any{
+									"name":      "prompt2",
+									"arguments": prompt2Args,
+								},
+							},
+						},
+					},
+				},
+				{
+					name: "prompts/list on prompt1_only",
+					url:  "/prompt1_only",
+					body: jsonrpc.JSONRPCRequest{
+						Jsonrpc: jsonrpcVersion,
+						Id:      "prompts-list-prompt1",
+						Request: jsonrpc.Request{
+							Method: "prompts/list",
+						},
+					},
+					wantStatusCode: http.StatusOK,
+					want: map[string]any{
+						"jsonrpc": "2.0",
+						"id":      "prompts-list-prompt1",
+						"result": map[string]any{
+							"prompts": []any{
+								map[string]any{
+									"name": "prompt1",
+								},
+							},
+						},
+					},
+				},
+				{
+					name: "tools/list on prompt1_only",
+					url:  "/prompt1_only",
+					body: jsonrpc.JSONRPCRequest{
+						Jsonrpc: jsonrpcVersion,
+						Id:      "tools-list-prompt1",
+						Request: jsonrpc.Request{
+							Method: "tools/list",
+						},
+					},
+					wantStatusCode: http.StatusOK,
+					want: map[string]any{
+						"jsonrpc": "2.0",
+						"id":      "tools-list-prompt1",
+						"result": map[string]any{
+							"tools": []any{},
+						},
+					},
+				},
 				{
 					name:  "tools/list on invalid tool set",
 					url:   "/foo",
@@ -1107,7 +1176,7 @@ func TestStdioSession(t *testing.T) {
 
 	sseManager := newSseManager(ctx)
 
-	resourceManager := resources.NewResourceManager(nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+	resourceManager := resources.NewResourceManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
 
 	server := &Server{
 		version:         fakeVersionString,
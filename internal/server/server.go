@@ -20,6 +20,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"reflect"
 	"slices"
 	"strconv"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	"github.com/go-chi/cors"
 	"github.com/go-chi/httplog/v2"
 	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/log"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server/resources"
@@ -56,6 +58,49 @@ type Server struct {
 func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	map[string]sources.Source,
 	map[string]auth.AuthService,
+	map[string]embeddingmodels.EmbeddingModel,
+	map[string]tools.Tool,
+	map[string]tools.Toolset,
+	map[string]prompts.Prompt,
+	map[string]prompts.Promptset,
+	error,
+) {
+	return ReinitializeConfigs(ctx, cfg, nil)
+}
+
+// ReinitializeConfigs initializes the configs like InitializeConfigs, but
+// reuses the sources in current whose config hasn't changed instead of
+// opening new connections for them. If initialization fails, the sources it
+// created are closed.
+func ReinitializeConfigs(ctx context.Context, cfg ServerConfig, current map[string]sources.Source) (
+	map[string]sources.Source,
+	map[string]auth.AuthService,
+	map[string]embeddingmodels.EmbeddingModel,
+	map[string]tools.Tool,
+	map[string]tools.Toolset,
+	map[string]prompts.Prompt,
+	map[string]prompts.Promptset,
+	error,
+) {
+	created := make(map[string]sources.Source)
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := initializeConfigs(ctx, cfg, current, created)
+	if err != nil {
+		l, lErr := util.LoggerFromContext(ctx)
+		if lErr != nil {
+			panic(lErr)
+		}
+		for name, s := range created {
+			closeSource(ctx, l, name, s)
+		}
+		return nil, nil, nil, nil, nil, nil, nil, err
+	}
+	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, nil
+}
+
+func initializeConfigs(ctx context.Context, cfg ServerConfig, current map[string]sources.Source, created map[string]sources.Source) (
+	map[string]sources.Source,
+	map[string]auth.AuthService,
+	map[string]embeddingmodels.EmbeddingModel,
 	map[string]tools.Tool,
 	map[string]tools.Toolset,
 	map[string]prompts.Prompt,
@@ -75,7 +120,13 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 
 	// initialize and validate the sources from configs
 	sourcesMap := make(map[string]sources.Source)
+	reusedNames := make([]string, 0)
 	for name, sc := range cfg.SourceConfigs {
+		if s, ok := current[name]; ok && reflect.DeepEqual(s.ToConfig(), sc) {
+			sourcesMap[name] = s
+			reusedNames = append(reusedNames, name)
+			continue
+		}
 		s, err := func() (sources.Source, error) {
 			childCtx, span := instrumentation.Tracer.Start(
 				ctx,
@@ -91,8 +142,9 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return s, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, err
 		}
+		created[name] = s
 		sourcesMap[name] = s
 	}
 	sourceNames := make([]string, 0, len(sourcesMap))
@@ -100,6 +152,9 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 		sourceNames = append(sourceNames, name)
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d sources: %s", len(sourcesMap), strings.Join(sourceNames, ", ")))
+	if len(reusedNames) > 0 {
+		l.DebugContext(ctx, fmt.Sprintf("Reused %d unchanged sources: %s", len(reusedNames), strings.Join(reusedNames, ", ")))
+	}
 
 	// initialize and validate the auth services from configs
 	authServicesMap := make(map[string]auth.AuthService)
@@ -119,7 +174,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return a, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, err
 		}
 		authServicesMap[name] = a
 	}
@@ -129,6 +184,34 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d authServices: %s", len(authServicesMap), strings.Join(authServiceNames, ", ")))
 
+	// initialize and validate the embedding models from configs
+	embeddingModelsMap := make(map[string]embeddingmodels.EmbeddingModel)
+	for name, mc := range cfg.EmbeddingModelConfigs {
+		m, err := func() (embeddingmodels.EmbeddingModel, error) {
+			childCtx, span := instrumentation.Tracer.Start(
+				ctx,
+				"toolbox/server/embeddingmodel/init",
+				trace.WithAttributes(attribute.String("embedding_model_kind", mc.EmbeddingModelConfigKind())),
+				trace.WithAttributes(attribute.String("embedding_model_name", name)),
+			)
+			defer span.End()
+			m, err := mc.Initialize(childCtx)
+			if err != nil {
+				return nil, fmt.Errorf("unable to initialize embedding model %q: %w", name, err)
+			}
+			return m, nil
+		}()
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, err
+		}
+		embeddingModelsMap[name] = m
+	}
+	embeddingModelNames := make([]string, 0, len(embeddingModelsMap))
+	for name := range embeddingModelsMap {
+		embeddingModelNames = append(embeddingModelNames, name)
+	}
+	l.InfoContext(ctx, fmt.Sprintf("Initialized %d embeddingModels: %s", len(embeddingModelsMap), strings.Join(embeddingModelNames, ", ")))
+
 	// initialize and validate the tools from configs
 	toolsMap := make(map[string]tools.Tool)
 	for name, tc := range cfg.ToolConfigs {
@@ -144,10 +227,13 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			if err != nil {
 				return nil, fmt.Errorf("unable to initialize tool %q: %w", name, err)
 			}
+			if ct, ok := t.(tools.CachedTool); ok && !ct.ReadOnly() {
+				l.WarnContext(ctx, fmt.Sprintf("tool %q caches its results but is not annotated with `readOnlyHint`; only cache tools that do not modify data", name))
+			}
 			return t, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, err
 		}
 		toolsMap[name] = t
 	}
@@ -184,7 +270,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return t, err
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, err
 		}
 		toolsetsMap[name] = t
 	}
@@ -216,7 +302,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return p, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, err
 		}
 		promptsMap[name] = p
 	}
@@ -253,7 +339,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return p, err
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, err
 		}
 		promptsetsMap[name] = p
 	}
@@ -267,7 +353,40 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d promptsets: %s", len(promptsetsMap), strings.Join(promptsetNames, ", ")))
 
-	return sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, nil
+	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, nil
+}
+
+// CloseReplacedSources closes the sources in old that weren't carried over
+// to replacement, e.g. because a reload removed them or changed their config.
+func CloseReplacedSources(ctx context.Context, old, replacement map[string]sources.Source) {
+	l, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		panic(err)
+	}
+	for name, s := range old {
+		if r, ok := replacement[name]; ok && sameSource(s, r) {
+			continue
+		}
+		closeSource(ctx, l, name, s)
+	}
+}
+
+// sameSource reports whether a and b are the same initialized source.
+func sameSource(a, b sources.Source) bool {
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b
+}
+
+// closeSource releases the connections held by a source, if it has any.
+func closeSource(ctx context.Context, l log.Logger, name string, s sources.Source) {
+	c, ok := s.(sources.Closer)
+	if !ok {
+		return
+	}
+	if err := c.Close(ctx); err != nil {
+		l.WarnContext(ctx, fmt.Sprintf("unable to close source %q: %s", name, err))
+		return
+	}
+	l.DebugContext(ctx, fmt.Sprintf("Closed source %q", name))
 }
 
 // NewServer returns a Server object based on provided Config.
@@ -320,7 +439,7 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	httpLogger := httplog.NewLogger("httplog", httpOpts)
 	r.Use(httplog.RequestLogger(httpLogger))
 
-	sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := InitializeConfigs(ctx, cfg)
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := InitializeConfigs(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize configs: %w", err)
 	}
@@ -330,7 +449,7 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 
 	sseManager := newSseManager(ctx)
 
-	resourceManager := resources.NewResourceManager(sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
+	resourceManager := resources.NewResourceManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
 
 	s := &Server{
 		version:         cfg.Version,
@@ -415,5 +534,12 @@ func (s *Server) ServeStdio(ctx context.Context, stdin io.Reader, stdout io.Writ
 // connections. It uses http.Server.Shutdown() and has the same functionality.
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.DebugContext(ctx, "shutting down the server.")
-	return s.srv.Shutdown(ctx)
+	if err := s.srv.Shutdown(ctx); err != nil {
+		return err
+	}
+	// in-flight requests are done, so the sources' connections can be released
+	for name, src := range s.ResourceMgr.GetSourcesMap() {
+		closeSource(ctx, s.logger, name, src)
+	}
+	return nil
 }
@@ -20,6 +20,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -162,7 +164,7 @@ func TestUpdateServer(t *testing.T) {
 			Prompts: []*prompts.Prompt{},
 		},
 	}
-	s.ResourceMgr.SetResources(newSources, newAuth, newTools, newToolsets, newPrompts, newPromptsets)
+	s.ResourceMgr.SetResources(newSources, newAuth, nil, newTools, newToolsets, newPrompts, newPromptsets)
 	if err != nil {
 		t.Errorf("error updating server: %s", err)
 	}
@@ -197,3 +199,64 @@ func TestUpdateServer(t *testing.T) {
 		t.Errorf("error updating server, promptset (-want +got):\n%s", diff)
 	}
 }
+
+func TestReinitializeConfigs(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("error setting up logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation("0.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	dir := t.TempDir()
+	sqliteConfig := func(name, file string) sqlite.Config {
+		return sqlite.Config{Name: name, Kind: "sqlite", Database: filepath.Join(dir, file)}
+	}
+	cfg := server.ServerConfig{
+		Version: "0.0.0",
+		SourceConfigs: server.SourceConfigs{
+			"unchanged": sqliteConfig("unchanged", "a.db"),
+			"changed":   sqliteConfig("changed", "b.db"),
+			"removed":   sqliteConfig("removed", "c.db"),
+		},
+	}
+	current, _, _, _, _, _, _, err := server.InitializeConfigs(ctx, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cfg.SourceConfigs = server.SourceConfigs{
+		"unchanged": sqliteConfig("unchanged", "a.db"),
+		"changed":   sqliteConfig("changed", "d.db"),
+	}
+	reloaded, _, _, _, _, _, _, err := server.ReinitializeConfigs(ctx, cfg, current)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if reloaded["unchanged"] != current["unchanged"] {
+		t.Errorf("expected unchanged source to be reused")
+	}
+	if reloaded["changed"] == current["changed"] {
+		t.Errorf("expected changed source to be reinitialized")
+	}
+
+	server.CloseReplacedSources(ctx, current, reloaded)
+	ping := func(s sources.Source) error {
+		return s.(*sqlite.Source).SQLiteDB().PingContext(ctx)
+	}
+	if err := ping(reloaded["unchanged"]); err != nil {
+		t.Errorf("expected reused source to stay open, got %s", err)
+	}
+	if err := ping(reloaded["changed"]); err != nil {
+		t.Errorf("expected new source to be open, got %s", err)
+	}
+	if err := ping(current["changed"]); err == nil {
+		t.Errorf("expected replaced source to be closed")
+	}
+	if err := ping(current["removed"]); err == nil {
+		t.Errorf("expected removed source to be closed")
+	}
+}
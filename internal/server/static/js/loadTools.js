@@ -146,6 +146,8 @@ async function fetchToolDetails(toolName, toolDisplayArea) {
                     const itemType = param.items && param.items.type ? param.items.type.toLowerCase() : 'string';
                     valueType = `array<${itemType}>`;
                     label += ' (Array)';
+                } else if (param.sensitive) {
+                    inputType = 'password';
                 }
 
                 return {
@@ -155,6 +157,7 @@ async function fetchToolDetails(toolName, toolDisplayArea) {
                     label: label,
                     authServices: param.authSources,
                     required: param.required || false,
+                    sensitive: param.sensitive || false,
                     // defaultValue: param.default, can't do this yet bc tool manifest doesn't have default
                 };
             })
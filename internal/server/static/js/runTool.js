@@ -60,7 +60,7 @@ export async function handleRunTool(toolId, form, responseArea, parameters, pret
                         } else {
                             const num = Number(RAW_VALUE);
                             if (isNaN(num)) {
-                                throw new Error(`Invalid number input for ${NAME}: ${RAW_VALUE}`);
+                                throw new Error(`Invalid number input for ${NAME}: ${param.sensitive ? '[REDACTED]' : RAW_VALUE}`);
                             }
                             typedParams[NAME] = num;
                         }
@@ -78,7 +78,13 @@ export async function handleRunTool(toolId, form, responseArea, parameters, pret
         }
     }
 
-    console.debug('Running tool:', toolId, 'with typed params:', typedParams);
+    const loggedParams = { ...typedParams };
+    for (const param of parameters) {
+        if (param.sensitive && param.name in loggedParams) {
+            loggedParams[param.name] = '[REDACTED]';
+        }
+    }
+    console.debug('Running tool:', toolId, 'with typed params:', loggedParams);
     try {
         const response = await fetch(`/api/tool/${toolId}/invoke`, {
             method: 'POST',
@@ -36,6 +36,7 @@ const SourceKind string = "alloydb-admin"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -62,6 +63,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	ua, err := util.UserAgentFromContext(ctx)
 	if err != nil {
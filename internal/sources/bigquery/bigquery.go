@@ -54,6 +54,7 @@ const (
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 type BigqueryClientCreator func(tokenString string, wantRestService bool) (*bigqueryapi.Client, *bigqueryrestapi.Service, error)
 
@@ -91,6 +92,10 @@ func (r Config) SourceConfigKind() string {
 	// Returns BigQuery source kind
 	return SourceKind
 }
+
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	if r.WriteMode == "" {
 		r.WriteMode = WriteModeAllowed
@@ -31,6 +31,7 @@ const SourceKind string = "bigtable"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -57,6 +58,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	client, err := initBigtableClient(ctx, tracer, r.Name, r.Project, r.Instance)
 	if err != nil {
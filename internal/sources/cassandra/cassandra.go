@@ -73,7 +73,12 @@ func (c Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (c Config) Placeholder() sources.Source {
+	return &Source{Config: c}
+}
+
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 type Source struct {
 	Config
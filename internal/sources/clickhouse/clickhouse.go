@@ -25,6 +25,7 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -32,6 +33,7 @@ const SourceKind string = "clickhouse"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -63,6 +65,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	pool, err := initClickHouseConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.Protocol, r.Secure)
 	if err != nil {
@@ -82,6 +88,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
@@ -96,6 +103,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// Close waits for in-flight queries to finish, then closes the database.
+func (s *Source) Close(ctx context.Context) error {
+	return s.Pool.Close()
+}
+
 func (s *Source) ClickHousePool() *sql.DB {
 	return s.Pool
 }
@@ -105,6 +117,10 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params parameters
 	if params != nil {
 		sliceParams = params.AsSlice()
 	}
+	sliceParams, err := sqltx.DBArgs(sliceParams)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
 	results, err := s.ClickHousePool().QueryContext(ctx, statement, sliceParams...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
@@ -34,6 +34,7 @@ const Endpoint string = "https://geminidataanalytics.googleapis.com"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -60,6 +61,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 // Initialize initializes a Gemini Data Analytics Source instance.
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	ua, err := util.UserAgentFromContext(ctx)
@@ -34,6 +34,7 @@ const SourceKind string = "cloud-healthcare"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 type HealthcareServiceCreator func(tokenString string) (*healthcare.Service, error)
 
@@ -67,6 +68,10 @@ func (c Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (c Config) Placeholder() sources.Source {
+	return &Source{Config: c}
+}
+
 func (c Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	var service *healthcare.Service
 	var serviceCreator HealthcareServiceCreator
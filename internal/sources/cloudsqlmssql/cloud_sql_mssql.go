@@ -26,6 +26,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -33,6 +34,7 @@ const SourceKind string = "cloud-sql-mssql"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -67,6 +69,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	// Initializes a Cloud SQL MSSQL source
 	db, err := initCloudSQLMssqlConnection(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
@@ -88,6 +94,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
@@ -103,18 +110,58 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// Close waits for in-flight queries to finish, then closes the database.
+func (s *Source) Close(ctx context.Context) error {
+	return s.Db.Close()
+}
+
 func (s *Source) MSSQLDB() *sql.DB {
 	// Returns a Cloud SQL MSSQL database connection pool
 	return s.Db
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	// report the rows affected and generated key of DML
+	if sqltx.IsDML(statement) {
+		return s.ExecSQL(ctx, statement, params, false)
+	}
+	params, err := sqltx.DBArgs(params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+
 	results, err := s.MSSQLDB().QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
 	defer results.Close()
 
+	return processRows(results)
+}
+
+// ExecSQL runs a DML statement and reports the rows it affected, along with
+// the rows it returns if returnsRows is set, e.g. for an OUTPUT clause.
+func (s *Source) ExecSQL(ctx context.Context, statement string, params []any, returnsRows bool) (any, error) {
+	params, err := sqltx.DBArgs(params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+	res, err := sqltx.ExecDB(ctx, s.MSSQLDB(), statement, params, returnsRows, processRows)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	return res, nil
+}
+
+func (s *Source) RunSQLTransaction(ctx context.Context, level sqltx.IsolationLevel, stmts []sqltx.Statement) (any, error) {
+	stmts, err := sqltx.DBStatements(stmts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+	return sqltx.RunDB(ctx, s.MSSQLDB(), level, stmts, processRows)
+}
+
+func processRows(results *sql.Rows) ([]any, error) {
 	cols, err := results.Columns()
 	// If Columns() errors, it might be a DDL/DML without an OUTPUT clause.
 	// We proceed, and results.Err() will catch actual query execution errors.
@@ -17,16 +17,19 @@ package cloudsqlmysql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"net/url"
-	"slices"
+	"net"
 
-	"cloud.google.com/go/cloudsqlconn/mysql/mysql"
+	"cloud.google.com/go/cloudsqlconn"
+	mysqlconn "cloud.google.com/go/cloudsqlconn/mysql/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -34,6 +37,7 @@ const SourceKind string = "cloud-sql-mysql"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -65,29 +69,38 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initCloudSQLMySQLConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
+	pool, dialer, err := initCloudSQLMySQLConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
 
 	err = pool.PingContext(ctx)
 	if err != nil {
+		_ = pool.Close()
+		_ = dialer.Close()
 		return nil, fmt.Errorf("unable to connect successfully: %w", err)
 	}
 
 	s := &Source{
 		Config: r,
 		Pool:   pool,
+		Dialer: dialer,
 	}
 	return s, nil
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
-	Pool *sql.DB
+	Pool   *sql.DB
+	Dialer *cloudsqlconn.Dialer
 }
 
 func (s *Source) SourceKind() string {
@@ -98,17 +111,58 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// Close waits for in-flight queries to finish, then closes the database and
+// the dialer that refreshes its certificates.
+func (s *Source) Close(ctx context.Context) error {
+	return errors.Join(s.Pool.Close(), s.Dialer.Close())
+}
+
 func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	// report the rows affected and generated key of DML
+	if sqltx.IsDML(statement) {
+		return s.ExecSQL(ctx, statement, params, false)
+	}
+	params, err := sqltx.DBArgs(params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+
 	results, err := s.MySQLPool().QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
 	defer results.Close()
 
+	return processRows(results)
+}
+
+// ExecSQL runs a DML statement and reports the rows it affected, along with
+// the rows it returns if returnsRows is set, e.g. for a RETURNING clause of MariaDB.
+func (s *Source) ExecSQL(ctx context.Context, statement string, params []any, returnsRows bool) (any, error) {
+	params, err := sqltx.DBArgs(params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+	res, err := sqltx.ExecDB(ctx, s.MySQLPool(), statement, params, returnsRows, processRows)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	return res, nil
+}
+
+func (s *Source) RunSQLTransaction(ctx context.Context, level sqltx.IsolationLevel, stmts []sqltx.Statement) (any, error) {
+	stmts, err := sqltx.DBStatements(stmts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+	return sqltx.RunDB(ctx, s.MySQLPool(), level, stmts, processRows)
+}
+
+func processRows(results *sql.Rows) ([]any, error) {
 	cols, err := results.Columns()
 	if err != nil {
 		return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
@@ -182,7 +236,7 @@ func getConnectionConfig(ctx context.Context, user, pass string) (string, string
 	return user, pass, useIAM, nil
 }
 
-func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string) (*sql.DB, error) {
+func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string) (*sql.DB, *cloudsqlconn.Dialer, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
@@ -190,59 +244,46 @@ func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, n
 	// Configure the driver to connect to the database
 	user, pass, useIAM, err := getConnectionConfig(ctx, user, pass)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get Cloud SQL connection config: %w", err)
+		return nil, nil, fmt.Errorf("unable to get Cloud SQL connection config: %w", err)
 	}
 
 	// Create a new dialer with options
 	userAgent, err := util.UserAgentFromContext(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	opts, err := sources.GetCloudSQLOpts(ipType, userAgent, useIAM)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	d, err := cloudsqlconn.NewDialer(ctx, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create dialer: %w", err)
 	}
 
-	// Use a unique driver name based on the source name.
-	driverName := fmt.Sprintf("cloudsql-mysql-%s", name)
-
-	if !slices.Contains(sql.Drivers(), driverName) {
-		if _, err := mysql.RegisterDriver(driverName, opts...); err != nil {
-			return nil, fmt.Errorf("unable to register driver: %w", err)
+	// Tell the driver to use the Cloud SQL Go Connector to create connections.
+	// The dialer is set on the connector rather than on a globally registered
+	// driver, so that a reloaded source uses its new options.
+	cfg := mysql.NewConfig()
+	cfg.User = user
+	if !useIAM {
+		cfg.Passwd = pass
+	}
+	cfg.Net = "cloudsql-mysql"
+	cfg.Addr = fmt.Sprintf("%s:%s:%s", project, region, instance)
+	cfg.DBName = dbname
+	cfg.ConnectionAttributes = "program_name:" + userAgent
+	cfg.DialFunc = func(ctx context.Context, _, addr string) (net.Conn, error) {
+		conn, err := d.Dial(ctx, addr)
+		if err != nil {
+			return nil, err
 		}
+		return &mysqlconn.LivenessCheckConn{Conn: conn}, nil
 	}
-
-	var dsn string
-	// Tell the driver to use the Cloud SQL Go Connector to create connections
-	if useIAM {
-		dsn = fmt.Sprintf("%s@%s(%s:%s:%s)/%s?connectionAttributes=program_name:%s",
-			user,
-			driverName,
-			project,
-			region,
-			instance,
-			dbname,
-			url.QueryEscape(userAgent),
-		)
-	} else {
-		dsn = fmt.Sprintf("%s:%s@%s(%s:%s:%s)/%s?connectionAttributes=program_name:%s",
-			user,
-			pass,
-			driverName,
-			project,
-			region,
-			instance,
-			dbname,
-			url.QueryEscape(userAgent),
-		)
-	}
-
-	db, err := sql.Open(
-		driverName,
-		dsn,
-	)
+	connector, err := mysql.NewConnector(cfg)
 	if err != nil {
-		return nil, err
+		_ = d.Close()
+		return nil, nil, err
 	}
-	return db, nil
+	return sql.OpenDB(connector), d, nil
 }
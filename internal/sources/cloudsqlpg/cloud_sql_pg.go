@@ -23,7 +23,7 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
-	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -32,6 +32,7 @@ const SourceKind string = "cloud-sql-postgres"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -63,29 +64,38 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initCloudSQLPgConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
+	pool, dialer, err := initCloudSQLPgConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
 
 	err = pool.Ping(ctx)
 	if err != nil {
+		pool.Close()
+		_ = dialer.Close()
 		return nil, fmt.Errorf("unable to connect successfully: %w", err)
 	}
 
 	s := &Source{
 		Config: r,
 		Pool:   pool,
+		Dialer: dialer,
 	}
 	return s, nil
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
-	Pool *pgxpool.Pool
+	Pool   *pgxpool.Pool
+	Dialer *cloudsqlconn.Dialer
 }
 
 func (s *Source) SourceKind() string {
@@ -96,35 +106,32 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// Close waits for in-flight queries to release their connections, then
+// closes the pool and the dialer that refreshes its certificates.
+func (s *Source) Close(ctx context.Context) error {
+	s.Pool.Close()
+	return s.Dialer.Close()
+}
+
 func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.PostgresPool().Query(ctx, statement, params...)
+	res, err := sqltx.QueryPgx(ctx, s.PostgresPool(), statement, params)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
-	defer results.Close()
-
-	fields := results.FieldDescriptions()
-	var out []any
-	for results.Next() {
-		values, err := results.Values()
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
-		}
-		row := orderedmap.Row{}
-		for i, f := range fields {
-			row.Add(f.Name, values[i])
-		}
-		out = append(out, row)
+	// DML that returns no rows reports its command tag and rows affected
+	// instead, while DML with a RETURNING clause returns its rows
+	if res.IsDML() && len(res.Columns) == 0 {
+		return res, nil
 	}
-	// this will catch actual query execution errors
-	if err := results.Err(); err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
-	}
-	return out, nil
+	return res.Rows, nil
+}
+
+func (s *Source) RunSQLTransaction(ctx context.Context, level sqltx.IsolationLevel, stmts []sqltx.Statement) (any, error) {
+	return sqltx.RunPgx(ctx, s.PostgresPool(), level, stmts)
 }
 
 func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string, bool, error) {
@@ -160,7 +167,7 @@ func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string
 	return dsn, useIAM, nil
 }
 
-func initCloudSQLPgConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string) (*pgxpool.Pool, error) {
+func initCloudSQLPgConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string) (*pgxpool.Pool, *cloudsqlconn.Dialer, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
@@ -168,26 +175,26 @@ func initCloudSQLPgConnectionPool(ctx context.Context, tracer trace.Tracer, name
 	// Configure the driver to connect to the database
 	dsn, useIAM, err := getConnectionConfig(ctx, user, pass, dbname)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get Cloud SQL connection config: %w", err)
+		return nil, nil, fmt.Errorf("unable to get Cloud SQL connection config: %w", err)
 	}
 
 	config, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+		return nil, nil, fmt.Errorf("unable to parse connection uri: %w", err)
 	}
 
 	// Create a new dialer with options
 	userAgent, err := util.UserAgentFromContext(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	opts, err := sources.GetCloudSQLOpts(ipType, userAgent, useIAM)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	d, err := cloudsqlconn.NewDialer(ctx, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+		return nil, nil, fmt.Errorf("unable to parse connection uri: %w", err)
 	}
 
 	// Tell the driver to use the Cloud SQL Go Connector to create connections
@@ -199,7 +206,8 @@ func initCloudSQLPgConnectionPool(ctx context.Context, tracer trace.Tracer, name
 	// Interact with the driver directly as you normally would
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
-		return nil, err
+		_ = d.Close()
+		return nil, nil, err
 	}
-	return pool, nil
+	return pool, d, nil
 }
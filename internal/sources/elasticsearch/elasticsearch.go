@@ -34,6 +34,7 @@ const SourceKind string = "elasticsearch"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -62,6 +63,10 @@ func (c Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (c Config) Placeholder() sources.Source {
+	return &Source{Config: c}
+}
+
 type EsClient interface {
 	esapi.Transport
 	elastictransport.Instrumented
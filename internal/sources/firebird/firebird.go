@@ -25,11 +25,13 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 )
 
 const SourceKind string = "firebird"
 
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -59,6 +61,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	pool, err := initFirebirdConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database)
 	if err != nil {
@@ -78,6 +84,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
@@ -92,11 +99,20 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// Close waits for in-flight queries to finish, then closes the database.
+func (s *Source) Close(ctx context.Context) error {
+	return s.Db.Close()
+}
+
 func (s *Source) FirebirdDB() *sql.DB {
 	return s.Db
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	params, err := sqltx.DBArgs(params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
 	rows, err := s.FirebirdDB().QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
@@ -24,6 +24,7 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -31,6 +32,7 @@ const SourceKind string = "mindsdb"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -61,6 +63,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	pool, err := initMindsDBConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryTimeout)
 	if err != nil {
@@ -80,6 +86,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
@@ -94,6 +101,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// Close waits for in-flight queries to finish, then closes the database.
+func (s *Source) Close(ctx context.Context) error {
+	return s.Pool.Close()
+}
+
 func (s *Source) MindsDBPool() *sql.DB {
 	return s.Pool
 }
@@ -103,6 +115,10 @@ func (s *Source) MySQLPool() *sql.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	params, err := sqltx.DBArgs(params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
 	// MindsDB now supports MySQL prepared statements natively
 	results, err := s.MindsDBPool().QueryContext(ctx, statement, params...)
 	if err != nil {
@@ -30,6 +30,7 @@ const SourceKind string = "mongodb"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -55,6 +56,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	client, err := initMongoDBClient(ctx, tracer, r.Name, r.Uri)
 	if err != nil {
@@ -75,6 +80,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
@@ -89,6 +95,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// Close waits for in-flight operations to finish, then disconnects the client.
+func (s *Source) Close(ctx context.Context) error {
+	return s.Client.Disconnect(ctx)
+}
+
 func (s *Source) MongoClient() *mongo.Client {
 	return s.Client
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -34,6 +35,7 @@ const SourceKind string = "mysql"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -65,6 +67,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	pool, err := initMySQLConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryTimeout, r.QueryParams)
 	if err != nil {
@@ -84,6 +90,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
@@ -98,17 +105,57 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// Close waits for in-flight queries to finish, then closes the database.
+func (s *Source) Close(ctx context.Context) error {
+	return s.Pool.Close()
+}
+
 func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	// report the rows affected and generated key of DML
+	if sqltx.IsDML(statement) {
+		return s.ExecSQL(ctx, statement, params, false)
+	}
+	params, err := sqltx.DBArgs(params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+
 	results, err := s.MySQLPool().QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
 	defer results.Close()
 
+	return processRows(results)
+}
+
+// ExecSQL runs a DML statement and reports the rows it affected, along with
+// the rows it returns if returnsRows is set, e.g. for a RETURNING clause of MariaDB.
+func (s *Source) ExecSQL(ctx context.Context, statement string, params []any, returnsRows bool) (any, error) {
+	params, err := sqltx.DBArgs(params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+	res, err := sqltx.ExecDB(ctx, s.MySQLPool(), statement, params, returnsRows, processRows)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	return res, nil
+}
+
+func (s *Source) RunSQLTransaction(ctx context.Context, level sqltx.IsolationLevel, stmts []sqltx.Statement) (any, error) {
+	stmts, err := sqltx.DBStatements(stmts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+	return sqltx.RunDB(ctx, s.MySQLPool(), level, stmts, processRows)
+}
+
+func processRows(results *sql.Rows) ([]any, error) {
 	cols, err := results.Columns()
 	if err != nil {
 		return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
@@ -28,6 +28,7 @@ const SourceKind string = "redis"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -58,9 +59,14 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 // RedisClient is an interface for `redis.Client` and `redis.ClusterClient
 type RedisClient interface {
 	Do(context.Context, ...any) *redis.Cmd
+	Close() error
 }
 
 var _ RedisClient = (*redis.Client)(nil)
@@ -135,6 +141,7 @@ func initRedisClient(ctx context.Context, r Config) (RedisClient, error) {
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
@@ -149,6 +156,10 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+func (s *Source) Close(ctx context.Context) error {
+	return s.Client.Close()
+}
+
 func (s *Source) RedisClient() RedisClient {
 	return s.Client
 }
@@ -16,8 +16,9 @@ package sources
 
 import (
 	"context"
-
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/goccy/go-yaml"
 	"go.opentelemetry.io/otel/attribute"
@@ -40,6 +41,11 @@ func Register(kind string, factory SourceConfigFactory) bool {
 	return true
 }
 
+// Kinds returns the registered source kinds, sorted.
+func Kinds() []string {
+	return slices.Sorted(maps.Keys(sourceRegistry))
+}
+
 // DecodeConfig decodes a source configuration using the registered factory for the given kind.
 func DecodeConfig(ctx context.Context, kind string, name string, decoder *yaml.Decoder) (SourceConfig, error) {
 	factory, found := sourceRegistry[kind]
@@ -65,6 +71,20 @@ type Source interface {
 	ToConfig() SourceConfig
 }
 
+// PlaceholderConfig is implemented by source configs that can build a Source
+// without connecting to anything. Placeholders are only used to check tools
+// against a source's kind offline, and must never be invoked.
+type PlaceholderConfig interface {
+	Placeholder() Source
+}
+
+// Closer is implemented by sources that hold connections or clients that
+// should be released once the source is no longer used, e.g. after it is
+// replaced by a reload or when the server shuts down.
+type Closer interface {
+	Close(ctx context.Context) error
+}
+
 // InitConnectionSpan adds a span for database pool connection initialization
 func InitConnectionSpan(ctx context.Context, tracer trace.Tracer, sourceKind, sourceName string) (context.Context, trace.Span) {
 	ctx, span := tracer.Start(
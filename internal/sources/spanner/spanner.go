@@ -20,10 +20,12 @@ import (
 	"fmt"
 
 	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/iterator"
 )
@@ -32,6 +34,7 @@ const SourceKind string = "spanner"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -60,6 +63,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	client, err := initSpannerClient(ctx, tracer, r.Name, r.Project, r.Instance, r.Database)
 	if err != nil {
@@ -139,6 +146,7 @@ func processRows(iter *spanner.RowIterator) ([]any, error) {
 
 func (s *Source) RunSQL(ctx context.Context, readOnly bool, statement string, params map[string]any) (any, error) {
 	var results []any
+	var dmlResult *sqltx.Result
 	var err error
 	var opErr error
 	stmt := spanner.Statement{
@@ -153,6 +161,16 @@ func (s *Source) RunSQL(ctx context.Context, readOnly bool, statement string, pa
 		results, opErr = processRows(iter)
 	} else {
 		_, opErr = s.SpannerClient().ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+			// report the rows affected of DML, along with any rows from a
+			// THEN RETURN clause
+			if sqltx.IsDML(statement) {
+				res, err := runStatement(ctx, txn, stmt)
+				if err != nil {
+					return err
+				}
+				dmlResult = &res
+				return nil
+			}
 			iter := txn.Query(ctx, stmt)
 			results, err = processRows(iter)
 			if err != nil {
@@ -165,10 +183,60 @@ func (s *Source) RunSQL(ctx context.Context, readOnly bool, statement string, pa
 	if opErr != nil {
 		return nil, fmt.Errorf("unable to execute client: %w", opErr)
 	}
+	if dmlResult != nil {
+		return *dmlResult, nil
+	}
 
 	return results, nil
 }
 
+func (s *Source) RunSQLTransaction(ctx context.Context, level sqltx.IsolationLevel, stmts []sqltx.Statement) (any, error) {
+	opts := spanner.TransactionOptions{}
+	switch level {
+	case "":
+	case sqltx.Serializable:
+		opts.IsolationLevel = sppb.TransactionOptions_SERIALIZABLE
+	case sqltx.RepeatableRead:
+		opts.IsolationLevel = sppb.TransactionOptions_REPEATABLE_READ
+	default:
+		return nil, fmt.Errorf("isolation level %q is not supported by spanner", level)
+	}
+
+	var out []any
+	_, err := s.SpannerClient().ReadWriteTransactionWithOptions(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		// the function may be retried, so results are reset on each attempt
+		out = make([]any, 0, len(stmts))
+		for i, stmt := range stmts {
+			res, err := runStatement(ctx, txn, spanner.Statement{SQL: stmt.SQL, Params: stmt.NamedArgs})
+			if err != nil {
+				return fmt.Errorf("statement %d failed: %w", i+1, err)
+			}
+			out = append(out, res)
+		}
+		return nil
+	}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("transaction rolled back: %w", err)
+	}
+	return out, nil
+}
+
+// runStatement runs a statement in a read-write transaction and reports its
+// rows, if any, along with the number of rows it affected. Statements are
+// always run as queries, which report the row count of DML once all rows are
+// read.
+func runStatement(ctx context.Context, txn *spanner.ReadWriteTransaction, stmt spanner.Statement) (sqltx.Result, error) {
+	iter := txn.Query(ctx, stmt)
+	rows, err := processRows(iter)
+	if err != nil {
+		return sqltx.Result{}, err
+	}
+	if sqltx.IsDML(stmt.SQL) {
+		return sqltx.Result{Rows: rows, RowsAffected: iter.RowCount}, nil
+	}
+	return sqltx.Result{Rows: rows, RowsAffected: int64(len(rows))}, nil
+}
+
 func initSpannerClient(ctx context.Context, tracer trace.Tracer, name, project, instance, dbname string) (*spanner.Client, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
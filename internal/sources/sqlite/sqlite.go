@@ -23,6 +23,7 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 	"go.opentelemetry.io/otel/trace"
 	_ "modernc.org/sqlite" // Pure Go SQLite driver
 )
@@ -31,6 +32,7 @@ const SourceKind string = "sqlite"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -56,6 +58,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	db, err := initSQLiteConnection(ctx, tracer, r.Name, r.Database)
 	if err != nil {
@@ -75,6 +81,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
@@ -89,11 +96,21 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// Close waits for in-flight queries to finish, then closes the database.
+func (s *Source) Close(ctx context.Context) error {
+	return s.Db.Close()
+}
+
 func (s *Source) SQLiteDB() *sql.DB {
 	return s.Db
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	// report the rows affected and generated key of DML
+	if sqltx.IsDML(statement) {
+		return s.ExecSQL(ctx, statement, params, false)
+	}
+
 	// Execute the SQL query with parameters
 	rows, err := s.SQLiteDB().QueryContext(ctx, statement, params...)
 	if err != nil {
@@ -101,6 +118,24 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 	}
 	defer rows.Close()
 
+	return processRows(rows)
+}
+
+// ExecSQL runs a DML statement and reports the rows it affected, along with
+// the rows it returns if returnsRows is set, e.g. for a RETURNING clause.
+func (s *Source) ExecSQL(ctx context.Context, statement string, params []any, returnsRows bool) (any, error) {
+	res, err := sqltx.ExecDB(ctx, s.SQLiteDB(), statement, params, returnsRows, processRows)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	return res, nil
+}
+
+func (s *Source) RunSQLTransaction(ctx context.Context, level sqltx.IsolationLevel, stmts []sqltx.Statement) (any, error) {
+	return sqltx.RunDB(ctx, s.SQLiteDB(), level, stmts, processRows)
+}
+
+func processRows(rows *sql.Rows) ([]any, error) {
 	// Get column names
 	cols, err := rows.Columns()
 	if err != nil {
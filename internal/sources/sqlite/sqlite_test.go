@@ -15,6 +15,8 @@
 package sqlite_test
 
 import (
+	"encoding/json"
+	"path/filepath"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
@@ -23,6 +25,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlSQLite(t *testing.T) {
@@ -64,3 +67,56 @@ func TestParseFromYamlSQLite(t *testing.T) {
 		})
 	}
 }
+
+func TestRunSQLDML(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := sqlite.Config{
+		Name:     "my-sqlite",
+		Kind:     "sqlite",
+		Database: filepath.Join(t.TempDir(), "test.db"),
+	}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	s := src.(*sqlite.Source)
+	if _, err := s.SQLiteDB().Exec("CREATE TABLE t (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+
+	tcs := []struct {
+		statement   string
+		params      []any
+		returnsRows bool
+		want        string
+	}{
+		{statement: "INSERT INTO t (name) VALUES (?), (?), (?)", params: []any{"a", "b", "c"}, want: `{"rowsAffected":3,"lastInsertId":3}`},
+		{statement: "-- rename\nUPDATE t SET name = ? WHERE id = 1", params: []any{"d"}, want: `{"rowsAffected":1}`},
+		{statement: "UPDATE t SET name = 'returning id' WHERE id = 3", want: `{"rowsAffected":1}`},
+		{statement: "DELETE FROM t WHERE id = 2 RETURNING name", returnsRows: true, want: `{"rows":[{"name":"b"}],"rowsAffected":1}`},
+		{statement: "SELECT id, name FROM t", want: `[{"id":1,"name":"d"},{"id":3,"name":"returning id"}]`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.statement, func(t *testing.T) {
+			var res any
+			var err error
+			if tc.returnsRows {
+				res, err = s.ExecSQL(ctx, tc.statement, tc.params, true)
+			} else {
+				res, err = s.RunSQL(ctx, tc.statement, tc.params)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, err := json.Marshal(res)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != tc.want {
+				t.Fatalf("unexpected result: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
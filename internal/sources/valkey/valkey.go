@@ -28,6 +28,7 @@ const SourceKind string = "valkey"
 
 // validate interface
 var _ sources.SourceConfig = Config{}
+var _ sources.PlaceholderConfig = Config{}
 
 func init() {
 	if !sources.Register(SourceKind, newConfig) {
@@ -58,6 +59,10 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+func (r Config) Placeholder() sources.Source {
+	return &Source{Config: r}
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 
 	client, err := initValkeyClient(ctx, r)
@@ -108,6 +113,7 @@ func initValkeyClient(ctx context.Context, r Config) (valkey.Client, error) {
 }
 
 var _ sources.Source = &Source{}
+var _ sources.Closer = &Source{}
 
 type Source struct {
 	Config
@@ -122,6 +128,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+func (s *Source) Close(ctx context.Context) error {
+	s.Client.Close()
+	return nil
+}
+
 func (s *Source) ValkeyClient() valkey.Client {
 	return s.Client
 }
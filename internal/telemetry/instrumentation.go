@@ -26,22 +26,26 @@ const (
 	TracerName = "github.com/googleapis/genai-toolbox/internal/opentel"
 	MetricName = "github.com/googleapis/genai-toolbox/internal/opentel"
 
-	toolsetGetCountName = "toolbox.server.toolset.get.count"
-	toolGetCountName    = "toolbox.server.tool.get.count"
-	toolInvokeCountName = "toolbox.server.tool.invoke.count"
-	mcpSseCountName     = "toolbox.server.mcp.sse.count"
-	mcpPostCountName    = "toolbox.server.mcp.post.count"
+	toolsetGetCountName   = "toolbox.server.toolset.get.count"
+	toolGetCountName      = "toolbox.server.tool.get.count"
+	toolInvokeCountName   = "toolbox.server.tool.invoke.count"
+	toolCacheCountName    = "toolbox.server.tool.cache.count"
+	promptsetGetCountName = "toolbox.server.promptset.get.count"
+	mcpSseCountName       = "toolbox.server.mcp.sse.count"
+	mcpPostCountName      = "toolbox.server.mcp.post.count"
 )
 
 // Instrumentation defines the telemetry instrumentation for toolbox
 type Instrumentation struct {
-	Tracer     trace.Tracer
-	meter      metric.Meter
-	ToolsetGet metric.Int64Counter
-	ToolGet    metric.Int64Counter
-	ToolInvoke metric.Int64Counter
-	McpSse     metric.Int64Counter
-	McpPost    metric.Int64Counter
+	Tracer       trace.Tracer
+	meter        metric.Meter
+	ToolsetGet   metric.Int64Counter
+	ToolGet      metric.Int64Counter
+	ToolInvoke   metric.Int64Counter
+	ToolCache    metric.Int64Counter
+	PromptsetGet metric.Int64Counter
+	McpSse       metric.Int64Counter
+	McpPost      metric.Int64Counter
 }
 
 func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, error) {
@@ -78,6 +82,24 @@ func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, err
 		return nil, fmt.Errorf("unable to create %s metric: %w", toolInvokeCountName, err)
 	}
 
+	toolCache, err := meter.Int64Counter(
+		toolCacheCountName,
+		metric.WithDescription("Number of tool result cache lookups."),
+		metric.WithUnit("{lookup}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolCacheCountName, err)
+	}
+
+	promptsetGet, err := meter.Int64Counter(
+		promptsetGetCountName,
+		metric.WithDescription("Number of promptset GET API calls."),
+		metric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", promptsetGetCountName, err)
+	}
+
 	mcpSse, err := meter.Int64Counter(
 		mcpSseCountName,
 		metric.WithDescription("Number of MCP SSE connection requests."),
@@ -97,13 +119,15 @@ func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, err
 	}
 
 	instrumentation := &Instrumentation{
-		Tracer:     tracer,
-		meter:      meter,
-		ToolsetGet: toolsetGet,
-		ToolGet:    toolGet,
-		ToolInvoke: toolInvoke,
-		McpSse:     mcpSse,
-		McpPost:    mcpPost,
+		Tracer:       tracer,
+		meter:        meter,
+		ToolsetGet:   toolsetGet,
+		ToolGet:      toolGet,
+		ToolInvoke:   toolInvoke,
+		ToolCache:    toolCache,
+		PromptsetGet: promptsetGet,
+		McpSse:       mcpSse,
+		McpPost:      mcpPost,
 	}
 	return instrumentation, nil
 }
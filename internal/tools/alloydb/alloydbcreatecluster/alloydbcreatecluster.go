@@ -67,7 +67,7 @@ func (cfg Config) ToolConfigKind() string {
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	rawS, ok := srcs[cfg.Source]
 	if !ok {
-		return nil, fmt.Errorf("source %q not found", cfg.Source)
+		return nil, tools.SourceNotFoundError{Name: cfg.Source}
 	}
 
 	s, ok := rawS.(compatibleSource)
@@ -87,7 +87,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		projectParam,
 		parameters.NewStringParameterWithDefault("location", "us-central1", "The location to create the cluster in. The default value is us-central1. If quota is exhausted then use other regions."),
 		parameters.NewStringParameter("cluster", "A unique ID for the AlloyDB cluster."),
-		parameters.NewStringParameter("password", "A secure password for the initial user."),
+		parameters.NewSensitiveStringParameter("password", "A secure password for the initial user.", true),
 		parameters.NewStringParameterWithDefault("network", "default", "The name of the VPC network to connect the cluster to (e.g., 'default')."),
 		parameters.NewStringParameterWithDefault("user", "postgres", "The name for the initial superuser. Defaults to 'postgres' if not provided."),
 	}
@@ -67,7 +67,7 @@ func (cfg Config) ToolConfigKind() string {
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	rawS, ok := srcs[cfg.Source]
 	if !ok {
-		return nil, fmt.Errorf("source %q not found", cfg.Source)
+		return nil, tools.SourceNotFoundError{Name: cfg.Source}
 	}
 
 	s, ok := rawS.(compatibleSource)
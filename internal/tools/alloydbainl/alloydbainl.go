@@ -64,6 +64,12 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters of the tool, including
+// the question asked.
+func (cfg Config) ParameterNames() []string {
+	return append([]string{"question"}, cfg.NLConfigParameters.Names()...)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	numParams := len(cfg.NLConfigParameters)
 	quotedNameParts := make([]string, 0, numParams)
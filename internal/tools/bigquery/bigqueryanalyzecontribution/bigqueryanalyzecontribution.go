@@ -75,7 +75,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
 	if !ok {
-		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+		return nil, tools.SourceNotFoundError{Name: cfg.Source}
 	}
 
 	// verify the source is compatible
@@ -68,6 +68,14 @@ func BQTypeStringFromToolType(toolType string) (string, error) {
 		return "FLOAT64", nil
 	case "boolean":
 		return "BOOL", nil
+	case "date":
+		return "DATE", nil
+	case "datetime":
+		return "TIMESTAMP", nil
+	case "uuid":
+		return "STRING", nil
+	case "decimal":
+		return "NUMERIC", nil
 	default:
 		return "", fmt.Errorf("unsupported tool parameter type for BigQuery: %s", toolType)
 	}
@@ -27,6 +27,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	bqutil "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycommon"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 	bigqueryrestapi "google.golang.org/api/bigquery/v2"
 )
 
@@ -71,6 +72,12 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (cfg Config) ParameterNames() []string {
+	return append(cfg.Parameters.Names(), cfg.TemplateParameters.Names()...)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
 	if err != nil {
@@ -113,17 +120,27 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	lowLevelParams := make([]*bigqueryrestapi.QueryParameter, 0, len(t.Parameters))
 
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, parameters.QuoteBackticks)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
 
+	// the dry run takes values as text, while the query binds native values
+	boundParams, err := sqltx.BigQueryArgs(t.AllParams, paramsMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+
 	for _, p := range t.Parameters {
 		name := p.GetName()
-		value := paramsMap[name]
+		value := boundParams[name]
 
 		// This block for converting []any to typed slices is still necessary and correct.
 		if arrayParam, ok := p.(*parameters.ArrayParameter); ok {
+			// BigQuery treats a NULL array as an empty one
+			if value == nil {
+				value = []any{}
+			}
 			arrayParamValue, ok := value.([]any)
 			if !ok {
 				return nil, fmt.Errorf("unable to convert parameter `%s` to []any", name)
@@ -180,7 +197,12 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 				return nil, err
 			}
 			lowLevelParam.ParameterType.Type = bqType
-			lowLevelParam.ParameterValue.Value = fmt.Sprintf("%v", value)
+			if sqltx.IsNull(paramsMap[name]) {
+				// send the value as an explicit NULL rather than as text
+				lowLevelParam.ParameterValue.NullFields = []string{"Value"}
+			} else {
+				lowLevelParam.ParameterValue.Value = fmt.Sprintf("%v", paramsMap[name])
+			}
 		}
 		lowLevelParams = append(lowLevelParams, lowLevelParam)
 	}
@@ -15,14 +15,23 @@
 package bigquerysql_test
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	bigqueryapi "cloud.google.com/go/bigquery"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerysql"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	bigqueryrestapi "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
 )
 
 func TestParseFromYamlBigQuery(t *testing.T) {
@@ -155,3 +164,75 @@ func TestParseFromYamlWithTemplateBigQuery(t *testing.T) {
 	}
 
 }
+
+// fakeSource records the statements it runs, after a dry run against a fake
+// BigQuery API.
+type fakeSource struct {
+	client    *bigqueryapi.Client
+	service   *bigqueryrestapi.Service
+	statement string
+}
+
+func (s *fakeSource) SourceKind() string                                  { return "bigquery" }
+func (s *fakeSource) ToConfig() sources.SourceConfig                      { return nil }
+func (s *fakeSource) BigQuerySession() bigqueryds.BigQuerySessionProvider { return nil }
+func (s *fakeSource) UseClientAuthorization() bool                        { return false }
+
+func (s *fakeSource) RetrieveClientAndService(tools.AccessToken) (*bigqueryapi.Client, *bigqueryrestapi.Service, error) {
+	return s.client, s.service, nil
+}
+
+func (s *fakeSource) RunSQL(_ context.Context, _ *bigqueryapi.Client, statement, _ string, _ []bigqueryapi.QueryParameter, _ []*bigqueryapi.ConnectionProperty) (any, error) {
+	s.statement = statement
+	return nil, nil
+}
+
+type provider map[string]sources.Source
+
+func (p provider) GetSource(name string) (sources.Source, bool) {
+	s, ok := p[name]
+	return s, ok
+}
+
+func TestInvokeHyphenatedIdentifier(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"statistics": {"query": {"statementType": "SELECT"}}}`))
+	}))
+	defer srv.Close()
+	client, err := bigqueryapi.NewClient(ctx, "my-proj", option.WithEndpoint(srv.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	service, err := bigqueryrestapi.NewService(ctx, option.WithEndpoint(srv.URL+"/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("unable to create service: %s", err)
+	}
+	src := &fakeSource{client: client, service: service}
+
+	tool, err := bigquerysql.Config{
+		Name:               "search",
+		Kind:               "bigquery-sql",
+		Source:             "my-bigquery",
+		Description:        "search a table",
+		Statement:          "SELECT * FROM {{.table}}",
+		TemplateParameters: parameters.Parameters{parameters.NewIdentifierParameter("table", "the table")},
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"table": "my-proj.dataset.table"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.Invoke(ctx, provider{"my-bigquery": src}, params, ""); err != nil {
+		t.Fatalf("unable to invoke tool: %s", err)
+	}
+	if want := "SELECT * FROM `my-proj`.`dataset`.`table`"; src.statement != want {
+		t.Fatalf("incorrect statement: got %q, want %q", src.statement, want)
+	}
+}
@@ -64,6 +64,12 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (cfg Config) ParameterNames() []string {
+	return append(cfg.Parameters.Names(), cfg.TemplateParameters.Names()...)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
 	if err != nil {
@@ -103,7 +109,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, parameters.QuoteBackticks)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/cache"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// CachedToolConfig wraps a ToolConfig that has a `cache` block configured.
+// The wrapped config is initialized as usual and the resulting Tool has its
+// invocation results cached.
+type CachedToolConfig struct {
+	ToolConfig
+	Cache cache.Config
+}
+
+// validate interface
+var _ ToolConfig = CachedToolConfig{}
+
+func (cfg CachedToolConfig) Initialize(srcs map[string]sources.Source) (Tool, error) {
+	t, err := cfg.ToolConfig.Initialize(srcs)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Cache.IsEnabled() {
+		return t, nil
+	}
+	ttl, err := cfg.Cache.GetTTL()
+	if err != nil {
+		return nil, err
+	}
+	backend, err := cfg.Cache.NewBackend()
+	if err != nil {
+		return nil, err
+	}
+	return NewCachedTool(t, backend, ttl), nil
+}
+
+// NewCachedTool returns a Tool that serves repeated invocations of t from
+// backend for the duration of ttl.
+func NewCachedTool(t Tool, backend cache.Backend, ttl time.Duration) Tool {
+	return CachedTool{Tool: t, name: t.McpManifest().Name, backend: backend, ttl: ttl}
+}
+
+// validate interface
+var _ Tool = CachedTool{}
+
+// CachedTool is a Tool whose successful results are cached. Entries are keyed
+// by the tool name, the parsed parameter values (which include any
+// authenticated parameters, and therefore the caller's identity), the client
+// access token, and the auth services the caller was verified for and the
+// claims of their tokens, since tools such as pipelines use those when
+// invoked.
+type CachedTool struct {
+	Tool
+	name    string
+	backend cache.Backend
+	ttl     time.Duration
+}
+
+// ReadOnly returns whether the wrapped tool is annotated as read-only, i.e.
+// whether its results are known to be safe to cache.
+func (t CachedTool) ReadOnly() bool {
+	a := t.Tool.McpManifest().Annotations
+	return a != nil && a.ReadOnlyHint != nil && *a.ReadOnlyHint
+}
+
+func (t CachedTool) Invoke(ctx context.Context, resourceMgr SourceProvider, params parameters.ParamValues, accessToken AccessToken) (any, error) {
+	verified, _ := util.VerifiedAuthServicesFromContext(ctx)
+	verified = slices.Clone(verified)
+	slices.Sort(verified)
+	claims, _ := util.ClaimsFromContext(ctx)
+	key, err := cache.Key(t.name, params, accessToken, verified, claims)
+	if err != nil {
+		// values that can't be hashed are never cached
+		return invoke(ctx, t.Tool, resourceMgr, params, accessToken)
+	}
+	if v, ok := t.backend.Get(ctx, key); ok {
+		if e, ok := v.(cacheEntry); ok {
+			t.recordLookup(ctx, "hit")
+			return e.decode()
+		}
+	}
+	t.recordLookup(ctx, "miss")
+
+	// params are embedded after the lookup, since entries are keyed by their
+	// texts rather than by the vectors
+	res, err := invoke(ctx, t.Tool, resourceMgr, params, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	// results that can't be marshaled are never cached
+	if e, err := newCacheEntry(res); err == nil {
+		t.backend.Set(ctx, key, e, t.ttl)
+	}
+	return res, nil
+}
+
+// cacheEntry is a cached result, kept as JSON so that every hit decodes its
+// own copy and callers can't change the entry.
+type cacheEntry struct {
+	value []byte
+	// list is set for results that are lists, whose items MCP returns as
+	// separate contents.
+	list bool
+}
+
+func newCacheEntry(res any) (cacheEntry, error) {
+	b, err := json.Marshal(res)
+	if err != nil {
+		return cacheEntry{}, err
+	}
+	_, list := res.([]any)
+	return cacheEntry{value: b, list: list && b[0] == '['}, nil
+}
+
+// decode returns a copy of the cached result. Items are kept as raw JSON,
+// which is returned as it was marshaled, e.g. with the columns of rows in
+// order.
+func (e cacheEntry) decode() (any, error) {
+	if !e.list {
+		return json.RawMessage(slices.Clone(e.value)), nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(e.value, &items); err != nil {
+		return nil, fmt.Errorf("unable to decode cached result: %w", err)
+	}
+	out := make([]any, len(items))
+	for i, item := range items {
+		out[i] = item
+	}
+	return out, nil
+}
+
+// recordLookup records a cache hit or miss if instrumentation is available.
+func (t CachedTool) recordLookup(ctx context.Context, result string) {
+	if logger, err := util.LoggerFromContext(ctx); err == nil {
+		logger.DebugContext(ctx, fmt.Sprintf("cache %s for tool %q", result, t.name))
+	}
+	instrumentation, err := util.InstrumentationFromContext(ctx)
+	if err != nil {
+		return
+	}
+	instrumentation.ToolCache.Add(
+		ctx,
+		1,
+		metric.WithAttributes(attribute.String("toolbox.name", t.name)),
+		metric.WithAttributes(attribute.String("toolbox.cache.result", result)),
+	)
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/cache"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// countingTool is a minimal Tool that counts how often it was invoked.
+type countingTool struct {
+	tools.Tool
+	calls *int
+	fail  bool
+}
+
+func (t countingTool) Invoke(_ context.Context, _ tools.SourceProvider, params parameters.ParamValues, _ tools.AccessToken) (any, error) {
+	*t.calls++
+	if t.fail {
+		return nil, fmt.Errorf("invocation failed")
+	}
+	return fmt.Sprintf("%v", params.AsMap()), nil
+}
+
+func (t countingTool) McpManifest() tools.McpManifest {
+	return tools.McpManifest{Name: "counting"}
+}
+
+func TestCachedTool(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	ct := tools.NewCachedTool(countingTool{calls: &calls}, cache.NewMemory(10), time.Minute)
+
+	p1 := parameters.ParamValues{{Name: "id", Value: 1}}
+	p2 := parameters.ParamValues{{Name: "id", Value: 2}}
+
+	for i := 0; i < 3; i++ {
+		if _, err := ct.Invoke(ctx, nil, p1, ""); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected repeated invocations to be cached, got %d calls", calls)
+	}
+
+	if _, err := ct.Invoke(ctx, nil, p2, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := ct.Invoke(ctx, nil, p1, "Bearer other-user"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected different params and access tokens to miss the cache, got %d calls", calls)
+	}
+}
+
+func TestCachedToolDoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	ct := tools.NewCachedTool(countingTool{calls: &calls, fail: true}, cache.NewMemory(10), time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := ct.Invoke(ctx, nil, nil, ""); err == nil {
+			t.Fatalf("expected error")
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected failed invocations not to be cached, got %d calls", calls)
+	}
+}
+
+// readOnlyTool is a countingTool annotated as read-only.
+type readOnlyTool struct {
+	countingTool
+}
+
+func (t readOnlyTool) McpManifest() tools.McpManifest {
+	readOnly := true
+	return tools.McpManifest{Name: "read-only", Annotations: &tools.ToolAnnotations{ReadOnlyHint: &readOnly}}
+}
+
+func TestCachedToolReadOnly(t *testing.T) {
+	calls := 0
+	if ct := tools.NewCachedTool(countingTool{calls: &calls}, cache.NewMemory(10), time.Minute).(tools.CachedTool); ct.ReadOnly() {
+		t.Fatalf("expected a tool without annotations not to be read-only")
+	}
+	if ct := tools.NewCachedTool(readOnlyTool{countingTool{calls: &calls}}, cache.NewMemory(10), time.Minute).(tools.CachedTool); !ct.ReadOnly() {
+		t.Fatalf("expected a tool annotated with readOnlyHint to be read-only")
+	}
+}
+
+// embeddingProvider provides an embedding model that counts its calls.
+type embeddingProvider struct {
+	calls *int
+}
+
+func (p embeddingProvider) GetSource(string) (sources.Source, bool) {
+	return nil, false
+}
+
+func (p embeddingProvider) GetEmbeddingModelMap() map[string]embeddingmodels.EmbeddingModel {
+	return map[string]embeddingmodels.EmbeddingModel{"model": countingModel(p)}
+}
+
+type countingModel struct {
+	calls *int
+}
+
+func (m countingModel) EmbeddingModelKind() string {
+	return "counting"
+}
+
+func (m countingModel) ToConfig() embeddingmodels.EmbeddingModelConfig {
+	return nil
+}
+
+func (m countingModel) Embed(_ context.Context, texts []string) ([][]float64, error) {
+	*m.calls++
+	vectors := make([][]float64, len(texts))
+	for i := range texts {
+		vectors[i] = []float64{1, 2}
+	}
+	return vectors, nil
+}
+
+func TestCachedToolEmbedsOnMiss(t *testing.T) {
+	ctx := context.Background()
+	calls, embeds := 0, 0
+	ct := tools.NewCachedTool(countingTool{calls: &calls}, cache.NewMemory(10), time.Minute)
+	provider := embeddingProvider{calls: &embeds}
+
+	params := parameters.ParamValues{{Name: "query", Value: parameters.EmbeddingText{Model: "model", Text: "hello"}}}
+	for i := 0; i < 3; i++ {
+		res, err := tools.Invoke(ctx, ct, provider, params, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got, want := marshal(t, res), `"map[query:[1 2]]"`; got != want {
+			t.Fatalf("unexpected result: got %s, want %s", got, want)
+		}
+	}
+	if calls != 1 || embeds != 1 {
+		t.Fatalf("expected cache hits not to embed, got %d calls and %d embeddings", calls, embeds)
+	}
+}
+
+// rowsTool is a minimal Tool that returns the same rows on every invocation.
+type rowsTool struct {
+	tools.Tool
+	rows []any
+}
+
+func (t rowsTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, error) {
+	return t.rows, nil
+}
+
+func (t rowsTool) McpManifest() tools.McpManifest {
+	return tools.McpManifest{Name: "rows"}
+}
+
+func marshal(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unable to marshal %v: %s", v, err)
+	}
+	return string(b)
+}
+
+func TestCachedToolReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	ct := tools.NewCachedTool(rowsTool{rows: []any{map[string]any{"id": 1}, map[string]any{"id": 2}}}, cache.NewMemory(10), time.Minute)
+
+	// callers changing results, on a miss or a hit, don't change the entry
+	for i := 0; i < 3; i++ {
+		res, err := ct.Invoke(ctx, nil, nil, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		rows, ok := res.([]any)
+		if !ok {
+			t.Fatalf("expected rows to be returned as a list, got %T", res)
+		}
+		if got, want := marshal(t, rows), `[{"id":1},{"id":2}]`; got != want {
+			t.Fatalf("unexpected result: got %s, want %s", got, want)
+		}
+		if i == 0 {
+			rows[0].(map[string]any)["id"] = 3
+		}
+		rows[1] = "changed"
+	}
+}
@@ -64,6 +64,12 @@ func (c Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (c Config) ParameterNames() []string {
+	return append(c.Parameters.Names(), c.TemplateParameters.Names()...)
+}
+
 // Initialize implements tools.ToolConfig.
 func (c Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	allParameters, paramManifest, err := parameters.ProcessParameters(c.TemplateParameters, c.Parameters)
@@ -113,7 +119,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, parameters.QuoteDoubleQuotesLower)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -59,6 +59,11 @@ func (cfg Config) ToolConfigKind() string {
 	return listDatabasesKind
 }
 
+// ParameterNames returns the names of the parameters of the tool.
+func (cfg Config) ParameterNames() []string {
+	return cfg.Parameters.Names()
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	allParameters, paramManifest, _ := parameters.ProcessParameters(nil, cfg.Parameters)
 	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, allParameters, nil)
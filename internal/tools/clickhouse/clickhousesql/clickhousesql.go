@@ -22,6 +22,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 )
 
 const sqlKind string = "clickhouse-sql"
@@ -53,6 +54,7 @@ type Config struct {
 	AuthRequired       []string              `yaml:"authRequired"`
 	Parameters         parameters.Parameters `yaml:"parameters"`
 	TemplateParameters parameters.Parameters `yaml:"templateParameters"`
+	DryRun             bool                  `yaml:"dryRun"`
 }
 
 var _ tools.ToolConfig = Config{}
@@ -61,8 +63,17 @@ func (cfg Config) ToolConfigKind() string {
 	return sqlKind
 }
 
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (cfg Config) ParameterNames() []string {
+	return append(cfg.Parameters.Names(), cfg.TemplateParameters.Names()...)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
-	allParameters, paramManifest, _ := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, sqltx.WithDryRun(cfg.Parameters, cfg.DryRun))
+	if err != nil {
+		return nil, err
+	}
 	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, allParameters, nil)
 
 	t := Tool{
@@ -94,7 +105,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, parameters.QuoteBackticks)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params: %w", err)
 	}
@@ -104,9 +115,31 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, fmt.Errorf("unable to extract standard params: %w", err)
 	}
 
+	if sqltx.IsDryRun(params) {
+		return explain(ctx, source, newStatement, newParams)
+	}
 	return source.RunSQL(ctx, newStatement, newParams)
 }
 
+// explain returns the plan of a statement without running it. ClickHouse
+// doesn't estimate the cost of a plan.
+func explain(ctx context.Context, source compatibleSource, statement string, params parameters.ParamValues) (any, error) {
+	res, err := source.RunSQL(ctx, "EXPLAIN indexes = 1 "+statement, params)
+	if err != nil {
+		return nil, err
+	}
+	rows, _ := res.([]any)
+	lines := make([]string, 0, len(rows))
+	for _, r := range rows {
+		if row, ok := r.(map[string]any); ok {
+			if line, ok := row["explain"].(string); ok {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return sqltx.Plan{Plan: lines}, nil
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
 	return parameters.ParseParams(t.AllParams, data, claims)
 }
@@ -67,7 +67,7 @@ func (cfg Config) ToolConfigKind() string {
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	rawS, ok := srcs[cfg.Source]
 	if !ok {
-		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+		return nil, tools.SourceNotFoundError{Name: cfg.Source}
 	}
 	s, ok := rawS.(compatibleSource)
 	if !ok {
@@ -86,7 +86,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		projectParam,
 		parameters.NewStringParameter("instance", "The ID of the instance where the user will be created."),
 		parameters.NewStringParameter("name", "The name for the new user. Must be unique within the instance."),
-		parameters.NewStringParameterWithRequired("password", "A secure password for the new user. Not required for IAM users.", false),
+		parameters.NewSensitiveStringParameter("password", "A secure password for the new user. Not required for IAM users.", false),
 		parameters.NewBooleanParameter("iamUser", "Set to true to create a Cloud IAM user."),
 	}
 	paramManifest := allParameters.Manifest()
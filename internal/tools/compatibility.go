@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"errors"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+var errSourceProbed = errors.New("source compatibility probed")
+
+// sourceProbe is a SourceProvider that makes GetCompatibleSource record
+// whether the source is compatible and stop the invocation right there.
+type sourceProbe struct {
+	name       string
+	source     sources.Source
+	checked    bool
+	compatible bool
+}
+
+func (p *sourceProbe) GetSource(name string) (sources.Source, bool) {
+	if name != p.name {
+		return nil, false
+	}
+	return p.source, true
+}
+
+// CheckSourceCompatibility reports whether source implements the interface
+// the tool looks its source up as, without running the tool against it, so
+// that placeholder sources can be used. Tools look their source up with
+// GetCompatibleSource before doing anything else when invoked, which stops
+// the invocation right there. checked is false when the tool didn't, in which
+// case compatible is meaningless.
+func CheckSourceCompatibility(ctx context.Context, t Tool, sourceName string, source sources.Source) (compatible, checked bool) {
+	// caches and rules are skipped, since they run before the tool
+	for {
+		switch w := t.(type) {
+		case CachedTool:
+			t = w.Tool
+			continue
+		case RuledTool:
+			t = w.Tool
+			continue
+		}
+		break
+	}
+	// nothing the tool might start before looking its source up gets far
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	p := &sourceProbe{name: sourceName, source: source}
+	func() {
+		defer func() { _ = recover() }()
+		_, _ = t.Invoke(ctx, p, parameters.ParamValues{}, "")
+	}()
+	return p.compatible, p.checked
+}
@@ -65,6 +65,11 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters of the tool.
+func (cfg Config) ParameterNames() []string {
+	return cfg.Parameters.Names()
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, cfg.Parameters, nil)
 
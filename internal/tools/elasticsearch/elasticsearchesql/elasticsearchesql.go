@@ -58,6 +58,11 @@ func (c Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters of the tool.
+func (c Config) ParameterNames() []string {
+	return c.Parameters.Names()
+}
+
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
 	actual := Config{Name: name}
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
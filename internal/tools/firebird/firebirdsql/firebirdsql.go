@@ -65,7 +65,17 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (cfg Config) ParameterNames() []string {
+	return append(cfg.Parameters.Names(), cfg.TemplateParameters.Names()...)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	if err := parameters.CheckLikeEscape(cfg.Statement, cfg.Parameters); err != nil {
+		return nil, fmt.Errorf("invalid statement for tool %q: %w", cfg.Name, err)
+	}
+
 	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
 	if err != nil {
 		return nil, err
@@ -104,7 +114,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	paramsMap := params.AsMap()
-	statement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	statement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, parameters.QuoteDoubleQuotesUpper)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params: %w", err)
 	}
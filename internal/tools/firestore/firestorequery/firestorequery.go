@@ -103,6 +103,11 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters of the tool.
+func (cfg Config) ParameterNames() []string {
+	return cfg.Parameters.Names()
+}
+
 // Initialize creates a new Tool instance from the configuration
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// Set default limit if not specified
@@ -79,11 +79,16 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters of the tool.
+func (cfg Config) ParameterNames() []string {
+	return slices.Concat(cfg.PathParams, cfg.BodyParams, cfg.HeaderParams, cfg.QueryParams).Names()
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
 	if !ok {
-		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+		return nil, tools.SourceNotFoundError{Name: cfg.Source}
 	}
 
 	// verify the source is compatible
@@ -194,7 +199,7 @@ func getURL(baseURL, path string, pathParams, queryParams parameters.Parameters,
 	query := parsedURL.Query()
 	for _, p := range queryParams {
 		v, ok := paramsMap[p.GetName()]
-		if !ok || v == nil {
+		if !ok || parameters.IsNull(v) {
 			if !p.GetRequired() {
 				// If the param is not required AND
 				// Not provodid OR provided with a nil value
@@ -216,7 +221,7 @@ func getHeaders(headerParams parameters.Parameters, defaultHeaders map[string]st
 	maps.Copy(allHeaders, defaultHeaders)
 	for _, p := range headerParams {
 		headerValue, ok := paramsMap[p.GetName()]
-		if ok {
+		if ok && !parameters.IsNull(headerValue) {
 			if strValue, ok := headerValue.(string); ok {
 				allHeaders[p.GetName()] = strValue
 			} else {
@@ -229,3 +229,19 @@ func TestFailParseFromYamlHTTP(t *testing.T) {
 	}
 
 }
+
+func TestParameterNamesHTTP(t *testing.T) {
+	owner := parameters.NewStringParameter("owner", "owner of the pet")
+	owner.Computed = `{{ claim "my-google-auth-service" "email" }}`
+	cfg := http.Config{
+		PathParams:   parameters.Parameters{parameters.NewStringParameter("id", "id of the pet")},
+		QueryParams:  parameters.Parameters{parameters.NewStringParameter("country", "country of the pet")},
+		BodyParams:   parameters.Parameters{parameters.NewIntParameter("age", "age of the pet")},
+		HeaderParams: parameters.Parameters{owner},
+	}
+	// computed parameters can be referred to by rules too
+	want := []string{"id", "age", "owner", "country"}
+	if diff := cmp.Diff(want, cfg.ParameterNames()); diff != "" {
+		t.Fatalf("incorrect parameter names (-want +got):\n%s", diff)
+	}
+}
@@ -49,6 +49,7 @@ type compatibleSource interface {
 	LookerClient() *v4.LookerSDK
 	LookerApiSettings() *rtl.ApiSettings
 }
+
 type Config struct {
 	Name         string                 `yaml:"name" validate:"required"`
 	Kind         string                 `yaml:"kind" validate:"required"`
@@ -68,6 +68,11 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters of the tool.
+func (cfg Config) ParameterNames() []string {
+	return cfg.FilterParams.Names()
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// Create a slice for all parameters
 	allParameters := slices.Concat(cfg.FilterParams)
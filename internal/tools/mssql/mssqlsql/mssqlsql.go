@@ -17,13 +17,17 @@ package mssqlsql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 )
 
 const kind string = "mssql-sql"
@@ -45,6 +49,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 type compatibleSource interface {
 	MSSQLDB() *sql.DB
 	RunSQL(context.Context, string, []any) (any, error)
+	ExecSQL(context.Context, string, []any, bool) (any, error)
 }
 
 type Config struct {
@@ -56,6 +61,8 @@ type Config struct {
 	AuthRequired       []string              `yaml:"authRequired"`
 	Parameters         parameters.Parameters `yaml:"parameters"`
 	TemplateParameters parameters.Parameters `yaml:"templateParameters"`
+	DryRun             bool                  `yaml:"dryRun"`
+	ReturnsRows        bool                  `yaml:"returnsRows"`
 }
 
 // validate interface
@@ -65,8 +72,18 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (cfg Config) ParameterNames() []string {
+	return append(cfg.Parameters.Names(), cfg.TemplateParameters.Names()...)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
-	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	if err := parameters.CheckLikeEscape(cfg.Statement, cfg.Parameters); err != nil {
+		return nil, fmt.Errorf("invalid statement for tool %q: %w", cfg.Name, err)
+	}
+
+	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, sqltx.WithDryRun(cfg.Parameters, cfg.DryRun))
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +117,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, parameters.QuoteSquareBrackets)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -122,11 +139,56 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 			namedArgs = append(namedArgs, value)
 		}
 	}
+	if sqltx.IsDryRun(params) {
+		return explain(ctx, source.MSSQLDB(), newStatement, namedArgs)
+	}
+	if t.ReturnsRows && sqltx.IsDML(newStatement) {
+		return source.ExecSQL(ctx, newStatement, namedArgs, true)
+	}
 	return source.RunSQL(ctx, newStatement, namedArgs)
 }
 
+var subtreeCost = regexp.MustCompile(`StatementSubtreeCost="([^"]+)"`)
+
+// explain returns the estimated plan and cost of a statement without running
+// it.
+func explain(ctx context.Context, db *sql.DB, statement string, args []any) (any, error) {
+	// SHOWPLAN applies to the whole session, so it needs a dedicated
+	// connection that is reset before going back to the pool
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get connection: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "SET SHOWPLAN_XML ON"); err != nil {
+		return nil, fmt.Errorf("unable to enable showplan: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), "SET SHOWPLAN_XML OFF"); err != nil {
+			// discard the connection rather than reuse it with showplan on
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+
+	var plan string
+	if err := conn.QueryRowContext(ctx, statement, args...).Scan(&plan); err != nil {
+		return nil, fmt.Errorf("unable to get query plan: %w", err)
+	}
+	out := sqltx.Plan{Plan: plan}
+	if m := subtreeCost.FindStringSubmatch(plan); m != nil {
+		if cost, err := strconv.ParseFloat(m[1], 64); err == nil {
+			out.EstimatedCost = &cost
+		}
+	}
+	return out, nil
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
-	return parameters.ParseParams(t.AllParams, data, claims)
+	params, err := parameters.ParseParams(t.AllParams, data, claims)
+	if err != nil {
+		return nil, err
+	}
+	return parameters.EscapeLikeBrackets(t.AllParams, params), nil
 }
 
 func (t Tool) Manifest() tools.Manifest {
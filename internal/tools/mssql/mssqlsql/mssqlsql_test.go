@@ -15,6 +15,7 @@
 package mssqlsql_test
 
 import (
+	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
@@ -173,3 +174,45 @@ func TestParseFromYamlWithTemplateMssql(t *testing.T) {
 		})
 	}
 }
+
+func TestLikeParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var params parameters.Parameters
+	in := `
+- name: name
+  type: string
+  description: name
+  transforms:
+    - like: prefix`
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	cfg := mssqlsql.Config{
+		Name:        "example_tool",
+		Kind:        "mssql-sql",
+		Source:      "my-instance",
+		Description: "some description",
+		Statement:   "SELECT * FROM t WHERE name LIKE @name",
+		Parameters:  params,
+	}
+	if _, err := cfg.Initialize(nil); err == nil || !strings.Contains(err.Error(), "ESCAPE") {
+		t.Fatalf("expected an error about the missing ESCAPE clause, got %v", err)
+	}
+
+	cfg.Statement = `SELECT * FROM t WHERE name LIKE @name ESCAPE '\'`
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := tool.ParseParams(map[string]any{"name": "[draft]_"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := parameters.ParamValues{{Name: "name", Value: `\[draft]\_%`}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect values (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mssqltransaction_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/mssql/mssqltransaction"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
+)
+
+func TestParseFromYamlMSSQLTransaction(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: mssql-transaction
+					source: my-mssql-instance
+					description: some description
+					isolationLevel: Serializable
+					statements:
+						- statement: INSERT INTO orders (customer) VALUES (@customer)
+						  parameters: [customer]
+						- statement: SELECT COUNT(*) AS n FROM orders
+					parameters:
+						- name: customer
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mssqltransaction.Config{
+					Name:           "example_tool",
+					Kind:           "mssql-transaction",
+					Source:         "my-mssql-instance",
+					Description:    "some description",
+					IsolationLevel: sqltx.Serializable,
+					Statements: []sqltx.StatementConfig{
+						{Statement: "INSERT INTO orders (customer) VALUES (@customer)", Parameters: []string{"customer"}},
+						{Statement: "SELECT COUNT(*) AS n FROM orders"},
+					},
+					AuthRequired: []string{},
+					Parameters: []parameters.Parameter{
+						parameters.NewStringParameter("customer", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
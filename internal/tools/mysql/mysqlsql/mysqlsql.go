@@ -17,12 +17,17 @@ package mysqlsql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strconv"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 )
 
 const kind string = "mysql-sql"
@@ -46,6 +51,14 @@ type compatibleSource interface {
 	RunSQL(context.Context, string, []any) (any, error)
 }
 
+// dmlSource is implemented by the sources that can return the rows of DML.
+type dmlSource interface {
+	ExecSQL(context.Context, string, []any, bool) (any, error)
+}
+
+var _ dmlSource = &cloudsqlmysql.Source{}
+var _ dmlSource = &mysql.Source{}
+
 type Config struct {
 	Name               string                `yaml:"name" validate:"required"`
 	Kind               string                `yaml:"kind" validate:"required"`
@@ -55,6 +68,8 @@ type Config struct {
 	AuthRequired       []string              `yaml:"authRequired"`
 	Parameters         parameters.Parameters `yaml:"parameters"`
 	TemplateParameters parameters.Parameters `yaml:"templateParameters"`
+	DryRun             bool                  `yaml:"dryRun"`
+	ReturnsRows        bool                  `yaml:"returnsRows"`
 }
 
 // validate interface
@@ -64,8 +79,14 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (cfg Config) ParameterNames() []string {
+	return append(cfg.Parameters.Names(), cfg.TemplateParameters.Names()...)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
-	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, sqltx.WithDryRun(cfg.Parameters, cfg.DryRun))
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +120,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, parameters.QuoteBackticks)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -110,9 +131,53 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	sliceParams := newParams.AsSlice()
+	if sqltx.IsDryRun(params) {
+		return explain(ctx, source, newStatement, sliceParams)
+	}
+	if t.ReturnsRows && sqltx.IsDML(newStatement) {
+		dml, ok := source.(dmlSource)
+		if !ok {
+			return nil, fmt.Errorf("source %q does not support `returnsRows`", t.Source)
+		}
+		return dml.ExecSQL(ctx, newStatement, sliceParams, true)
+	}
 	return source.RunSQL(ctx, newStatement, sliceParams)
 }
 
+// explain returns the plan and estimated cost of a statement without running
+// it.
+func explain(ctx context.Context, source compatibleSource, statement string, params []any) (any, error) {
+	res, err := source.RunSQL(ctx, "EXPLAIN FORMAT=JSON "+statement, params)
+	if err != nil {
+		return nil, err
+	}
+	v, err := sqltx.FirstValue(res)
+	if err != nil {
+		return nil, err
+	}
+	plan, ok := v.(map[string]any)
+	if !ok {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("unable to convert plan object to string")
+		}
+		if err := json.Unmarshal([]byte(s), &plan); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal query plan: %w", err)
+		}
+	}
+	out := sqltx.Plan{Plan: plan}
+	if block, ok := plan["query_block"].(map[string]any); ok {
+		if info, ok := block["cost_info"].(map[string]any); ok {
+			if s, ok := info["query_cost"].(string); ok {
+				if cost, err := strconv.ParseFloat(s, 64); err == nil {
+					out.EstimatedCost = &cost
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
 	return parameters.ParseParams(t.AllParams, data, claims)
 }
@@ -16,12 +16,14 @@ package postgressql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -55,6 +57,7 @@ type Config struct {
 	AuthRequired       []string              `yaml:"authRequired"`
 	Parameters         parameters.Parameters `yaml:"parameters"`
 	TemplateParameters parameters.Parameters `yaml:"templateParameters"`
+	DryRun             bool                  `yaml:"dryRun"`
 }
 
 // validate interface
@@ -64,8 +67,14 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (cfg Config) ParameterNames() []string {
+	return append(cfg.Parameters.Names(), cfg.TemplateParameters.Names()...)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
-	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, sqltx.WithDryRun(cfg.Parameters, cfg.DryRun))
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +108,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, parameters.QuoteDoubleQuotesLower)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -109,9 +118,42 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, fmt.Errorf("unable to extract standard params %w", err)
 	}
 	sliceParams := newParams.AsSlice()
+	if sqltx.IsDryRun(params) {
+		return explain(ctx, source, newStatement, sliceParams)
+	}
 	return source.RunSQL(ctx, newStatement, sliceParams)
 }
 
+// explain returns the plan and estimated cost of a statement without running
+// it.
+func explain(ctx context.Context, source compatibleSource, statement string, params []any) (any, error) {
+	res, err := source.RunSQL(ctx, "EXPLAIN (FORMAT JSON) "+statement, params)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := sqltx.FirstValue(res)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := plan.(string); ok {
+		if err := json.Unmarshal([]byte(s), &plan); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal query plan: %w", err)
+		}
+	}
+	out := sqltx.Plan{Plan: plan}
+	// the plan is a list holding one object, whose top node has the total cost
+	if nodes, ok := plan.([]any); ok && len(nodes) > 0 {
+		if node, ok := nodes[0].(map[string]any); ok {
+			if top, ok := node["Plan"].(map[string]any); ok {
+				if cost, ok := top["Total Cost"].(float64); ok {
+					out.EstimatedCost = &cost
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
 	return parameters.ParseParams(t.AllParams, data, claims)
 }
@@ -15,6 +15,7 @@
 package postgressql_test
 
 import (
+	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
@@ -165,3 +166,31 @@ func TestParseFromYamlWithTemplateParamsPostgres(t *testing.T) {
 	}
 
 }
+
+func TestDryRunParameter(t *testing.T) {
+	cfg := postgressql.Config{
+		Name:        "example_tool",
+		Kind:        "postgres-sql",
+		Source:      "my-pg-instance",
+		Description: "some description",
+		Statement:   "SELECT * FROM t WHERE id = $1",
+		Parameters:  parameters.Parameters{parameters.NewIntParameter("id", "some description")},
+		DryRun:      true,
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got []string
+	for _, p := range tool.Manifest().Parameters {
+		got = append(got, p.Name)
+	}
+	if diff := cmp.Diff([]string{"id", "dry_run"}, got); diff != "" {
+		t.Fatalf("incorrect parameters: diff %v", diff)
+	}
+
+	cfg.Parameters = append(cfg.Parameters, parameters.NewBooleanParameter("dry_run", "conflicting parameter"))
+	if _, err := cfg.Initialize(nil); err == nil || !strings.Contains(err.Error(), "dry_run") {
+		t.Fatalf("expected duplicate parameter error, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// RuledToolConfig wraps a ToolConfig that has `rules` configured. The
+// wrapped config is initialized as usual and the resulting Tool checks the
+// rules when it parses its parameters.
+type RuledToolConfig struct {
+	ToolConfig
+	Rules []parameters.Rule
+}
+
+// ParameterNamer is implemented by tool configs that declare the parameters
+// of their tools, so that rules can refer to them.
+type ParameterNamer interface {
+	ParameterNames() []string
+}
+
+// validate interface
+var _ ToolConfig = RuledToolConfig{}
+
+func (cfg RuledToolConfig) Initialize(srcs map[string]sources.Source) (Tool, error) {
+	t, err := cfg.ToolConfig.Initialize(srcs)
+	if err != nil {
+		return nil, err
+	}
+	if err := parameters.CheckRules(cfg.Rules, parameterNames(cfg.ToolConfig, t)); err != nil {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+	return RuledTool{Tool: t, rules: cfg.Rules}, nil
+}
+
+// parameterNames returns the names of the parameters a tool takes, as
+// declared by its config, which unlike the manifest includes computed
+// parameters. Tools with fixed parameters fall back to the names listed in
+// their manifest.
+func parameterNames(tc ToolConfig, t Tool) []string {
+	if n, ok := BaseConfig(tc).(ParameterNamer); ok {
+		return n.ParameterNames()
+	}
+	names := make([]string, 0)
+	for _, p := range t.Manifest().Parameters {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// validate interface
+var _ Tool = RuledTool{}
+
+// RuledTool is a Tool whose parameter values must also satisfy rules across
+// parameters, e.g. that exactly one of two parameters is given. Broken rules
+// are reported as invalid parameters, before the tool is invoked.
+type RuledTool struct {
+	Tool
+	rules []parameters.Rule
+}
+
+func (t RuledTool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
+	params, err := t.Tool.ParseParams(data, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := parameters.ValidateRules(t.rules, params, claims); err != nil {
+		return nil, params.RedactError(err)
+	}
+	return params, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// manifestTool is a minimal Tool with fixed parameters, listed in its
+// manifest.
+type manifestTool struct {
+	tools.Tool
+	params parameters.Parameters
+}
+
+func (t manifestTool) Manifest() tools.Manifest {
+	return tools.Manifest{Parameters: t.params.Manifest()}
+}
+
+// fixedConfig is a minimal ToolConfig whose tools have fixed parameters.
+type fixedConfig struct {
+	params parameters.Parameters
+}
+
+func (cfg fixedConfig) ToolConfigKind() string {
+	return "fixed"
+}
+
+func (cfg fixedConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return manifestTool{params: cfg.params}, nil
+}
+
+// declaredConfig is a minimal ToolConfig that declares the parameters of its
+// tools.
+type declaredConfig struct {
+	fixedConfig
+}
+
+func (cfg declaredConfig) ParameterNames() []string {
+	return cfg.params.Names()
+}
+
+func (cfg declaredConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	// the manifest doesn't list the parameters, so that they can only be
+	// known from the config
+	return manifestTool{}, nil
+}
+
+func TestRuledToolConfigParameters(t *testing.T) {
+	params := parameters.Parameters{
+		parameters.NewStringParameter("id", "id"),
+		parameters.NewStringParameter("email", "email"),
+	}
+	computed := parameters.NewStringParameter("owner", "owner")
+	computed.Computed = `{{ claim "my-auth" "email" }}`
+	tcs := []struct {
+		name string
+		cfg  tools.ToolConfig
+		rule parameters.Rule
+		want string
+	}{
+		{
+			name: "declared parameters",
+			cfg:  declaredConfig{fixedConfig{params}},
+			rule: parameters.Rule{ExactlyOneOf: []string{"id", "email"}},
+		},
+		{
+			name: "unknown declared parameter",
+			cfg:  declaredConfig{fixedConfig{params}},
+			rule: parameters.Rule{ExactlyOneOf: []string{"id", "phone"}},
+			want: `invalid rules: rule #0 (exactlyOneOf) lists unknown parameter "phone"`,
+		},
+		{
+			name: "fixed parameters",
+			cfg:  fixedConfig{params},
+			rule: parameters.Rule{AtMostOneOf: []string{"id", "email"}},
+		},
+		{
+			name: "unknown fixed parameter",
+			cfg:  fixedConfig{params},
+			rule: parameters.Rule{AtMostOneOf: []string{"id", "phone"}},
+			want: `invalid rules: rule #0 (atMostOneOf) lists unknown parameter "phone"`,
+		},
+		{
+			name: "computed parameter",
+			cfg:  declaredConfig{fixedConfig{append(params, computed)}},
+			rule: parameters.Rule{AtMostOneOf: []string{"id", "owner"}},
+		},
+		{
+			name: "no parameters",
+			cfg:  fixedConfig{},
+			rule: parameters.Rule{AtMostOneOf: []string{"id", "email"}},
+			want: `lists unknown parameter "id"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tools.RuledToolConfig{ToolConfig: tc.cfg, Rules: []parameters.Rule{tc.rule}}
+			_, err := cfg.Initialize(nil)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("unexpected error: got %v, want it to contain %q", err, tc.want)
+			}
+		})
+	}
+}
@@ -16,14 +16,18 @@ package spannersql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 const kind string = "spanner-sql"
@@ -58,6 +62,7 @@ type Config struct {
 	AuthRequired       []string              `yaml:"authRequired"`
 	Parameters         parameters.Parameters `yaml:"parameters"`
 	TemplateParameters parameters.Parameters `yaml:"templateParameters"`
+	DryRun             bool                  `yaml:"dryRun"`
 }
 
 // validate interface
@@ -67,8 +72,14 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (cfg Config) ParameterNames() []string {
+	return append(cfg.Parameters.Names(), cfg.TemplateParameters.Names()...)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
-	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, sqltx.WithDryRun(cfg.Parameters, cfg.DryRun))
 	if err != nil {
 		return nil, err
 	}
@@ -112,8 +123,13 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, err
 	}
 
+	// identifiers are quoted as in the database's dialect
+	quote := parameters.QuoteBackticks
+	if strings.EqualFold(source.DatabaseDialect(), "postgresql") {
+		quote = parameters.QuoteDoubleQuotesLower
+	}
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, quote)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -150,9 +166,45 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if err != nil {
 		return nil, fmt.Errorf("fail to get map params: %w", err)
 	}
+	mapParams, err = sqltx.SpannerArgs(mapParams, source.DatabaseDialect())
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind parameters: %w", err)
+	}
+	if sqltx.IsDryRun(params) {
+		return explain(ctx, source.SpannerClient(), t.ReadOnly, spanner.Statement{SQL: newStatement, Params: mapParams})
+	}
 	return source.RunSQL(ctx, t.ReadOnly, newStatement, mapParams)
 }
 
+// explain returns the plan of a statement without running it. Spanner doesn't
+// estimate the cost of a plan.
+func explain(ctx context.Context, client *spanner.Client, readOnly bool, stmt spanner.Statement) (any, error) {
+	var plan *sppb.QueryPlan
+	var err error
+	if readOnly {
+		plan, err = client.Single().AnalyzeQuery(ctx, stmt)
+	} else {
+		// DML can only be planned in a read-write transaction
+		_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+			var err error
+			plan, err = txn.AnalyzeQuery(ctx, stmt)
+			return err
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get query plan: %w", err)
+	}
+	b, err := protojson.Marshal(plan)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal query plan: %w", err)
+	}
+	var out any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal query plan: %w", err)
+	}
+	return sqltx.Plan{Plan: out}, nil
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
 	return parameters.ParseParams(t.AllParams, data, claims)
 }
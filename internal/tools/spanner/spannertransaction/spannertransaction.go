@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannertransaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
+)
+
+const kind string = "spanner-transaction"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	DatabaseDialect() string
+	RunSQLTransaction(context.Context, sqltx.IsolationLevel, []sqltx.Statement) (any, error)
+}
+
+type Config struct {
+	Name               string                  `yaml:"name" validate:"required"`
+	Kind               string                  `yaml:"kind" validate:"required"`
+	Source             string                  `yaml:"source" validate:"required"`
+	Description        string                  `yaml:"description" validate:"required"`
+	Statements         []sqltx.StatementConfig `yaml:"statements" validate:"required,min=1,dive"`
+	IsolationLevel     sqltx.IsolationLevel    `yaml:"isolationLevel"`
+	AuthRequired       []string                `yaml:"authRequired"`
+	Parameters         parameters.Parameters   `yaml:"parameters"`
+	TemplateParameters parameters.Parameters   `yaml:"templateParameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (cfg Config) ParameterNames() []string {
+	return append(cfg.Parameters.Names(), cfg.TemplateParameters.Names()...)
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	switch cfg.IsolationLevel {
+	case "", sqltx.Serializable, sqltx.RepeatableRead:
+	default:
+		return nil, fmt.Errorf("isolation level %q of tool %q is not supported by spanner; use %q or %q", cfg.IsolationLevel, cfg.Name, sqltx.Serializable, sqltx.RepeatableRead)
+	}
+	if err := sqltx.CheckParameters(cfg.Statements, cfg.Parameters); err != nil {
+		return nil, fmt.Errorf("invalid statements for tool %q: %w", cfg.Name, err)
+	}
+
+	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, allParameters, nil)
+
+	// finish tool setup
+	t := Tool{
+		Config:      cfg,
+		AllParams:   allParameters,
+		manifest:    tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest: mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Config
+	AllParams   parameters.Parameters `yaml:"allParams"`
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// namedArgs converts the values bound to a statement into Spanner's named
+// parameters.
+func (t Tool) namedArgs(values parameters.ParamValues, dialect string) (map[string]any, error) {
+	for i, v := range values {
+		// Spanner only accepts typed slices as input
+		for _, p := range t.Parameters {
+			arrayParam, ok := p.(*parameters.ArrayParameter)
+			if !ok || p.GetName() != v.Name {
+				continue
+			}
+			arrayParamValue, ok := v.Value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("unable to convert parameter `%s` to []any", v.Name)
+			}
+			value, err := parameters.ConvertAnySliceToTyped(arrayParamValue, arrayParam.GetItems().GetType())
+			if err != nil {
+				return nil, fmt.Errorf("unable to convert parameter `%s` from []any to typed slice: %w", v.Name, err)
+			}
+			values[i] = parameters.ParamValue{Name: v.Name, Value: value}
+		}
+	}
+
+	var args map[string]any
+	switch strings.ToLower(dialect) {
+	case "googlesql":
+		args = values.AsMap()
+	case "postgresql":
+		args = values.AsMapByOrderedKeys()
+	default:
+		return nil, fmt.Errorf("invalid dialect %s", dialect)
+	}
+	return sqltx.SpannerArgs(args, dialect)
+}
+
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, error) {
+	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Source, t.Name, t.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	// identifiers are quoted as in the database's dialect
+	quote := parameters.QuoteBackticks
+	if strings.EqualFold(source.DatabaseDialect(), "postgresql") {
+		quote = parameters.QuoteDoubleQuotesLower
+	}
+	paramsMap := params.AsMap()
+	stmts := make([]sqltx.Statement, 0, len(t.Statements))
+	for _, s := range t.Statements {
+		newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, s.Statement, paramsMap, quote)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract template params %w", err)
+		}
+		args, err := t.namedArgs(sqltx.SelectParams(s.Parameters, params), source.DatabaseDialect())
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, sqltx.Statement{SQL: newStatement, NamedArgs: args})
+	}
+	return source.RunSQLTransaction(ctx, t.IsolationLevel, stmts)
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
+	return parameters.ParseParams(t.AllParams, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
+
+func (t Tool) RequiresClientAuthorization(resourceMgr tools.SourceProvider) (bool, error) {
+	return false, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Config
+}
+
+func (t Tool) GetAuthTokenHeaderName(resourceMgr tools.SourceProvider) (string, error) {
+	return "Authorization", nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
 )
 
 const kind string = "sqlite-sql"
@@ -44,6 +45,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 type compatibleSource interface {
 	SQLiteDB() *sql.DB
 	RunSQL(context.Context, string, []any) (any, error)
+	ExecSQL(context.Context, string, []any, bool) (any, error)
 }
 
 type Config struct {
@@ -55,6 +57,8 @@ type Config struct {
 	AuthRequired       []string              `yaml:"authRequired"`
 	Parameters         parameters.Parameters `yaml:"parameters"`
 	TemplateParameters parameters.Parameters `yaml:"templateParameters"`
+	DryRun             bool                  `yaml:"dryRun"`
+	ReturnsRows        bool                  `yaml:"returnsRows"`
 }
 
 // validate interface
@@ -64,8 +68,18 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// ParameterNames returns the names of the parameters and template parameters
+// of the tool.
+func (cfg Config) ParameterNames() []string {
+	return append(cfg.Parameters.Names(), cfg.TemplateParameters.Names()...)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
-	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	if err := parameters.CheckLikeEscape(cfg.Statement, cfg.Parameters); err != nil {
+		return nil, fmt.Errorf("invalid statement for tool %q: %w", cfg.Name, err)
+	}
+
+	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, sqltx.WithDryRun(cfg.Parameters, cfg.DryRun))
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +113,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, parameters.QuoteDoubleQuotes)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -108,6 +122,17 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract standard params %w", err)
 	}
+	if sqltx.IsDryRun(params) {
+		// SQLite doesn't estimate the cost of a plan
+		res, err := source.RunSQL(ctx, "EXPLAIN QUERY PLAN "+newStatement, newParams.AsSlice())
+		if err != nil {
+			return nil, err
+		}
+		return sqltx.Plan{Plan: res}, nil
+	}
+	if t.ReturnsRows && sqltx.IsDML(newStatement) {
+		return source.ExecSQL(ctx, newStatement, newParams.AsSlice(), true)
+	}
 	return source.RunSQL(ctx, newStatement, newParams.AsSlice())
 }
 
@@ -15,14 +15,20 @@
 package sqlitesql_test
 
 import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools/sqlite/sqlitesql"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/trace/noop"
 	_ "modernc.org/sqlite"
 )
 
@@ -175,3 +181,179 @@ func TestParseFromYamlWithTemplateSqlite(t *testing.T) {
 		})
 	}
 }
+
+type provider map[string]sources.Source
+
+func (p provider) GetSource(name string) (sources.Source, bool) {
+	s, ok := p[name]
+	return s, ok
+}
+
+func TestInvokeDryRun(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := sqlite.Config{
+		Name:     "my-sqlite",
+		Kind:     "sqlite",
+		Database: filepath.Join(t.TempDir(), "test.db"),
+	}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	db := src.(*sqlite.Source).SQLiteDB()
+	if _, err := db.Exec("CREATE TABLE t (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+
+	tool, err := sqlitesql.Config{
+		Name:        "delete_row",
+		Kind:        "sqlite-sql",
+		Source:      "my-sqlite",
+		Description: "some description",
+		Statement:   "DELETE FROM t WHERE id = ?",
+		Parameters:  parameters.Parameters{parameters.NewIntParameter("id", "some description")},
+		DryRun:      true,
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := db.Exec("INSERT INTO t (id, name) VALUES (1, 'a')"); err != nil {
+		t.Fatalf("unable to insert row: %s", err)
+	}
+
+	params, err := tool.ParseParams(map[string]any{"id": 1, "dry_run": true}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res, err := tool.Invoke(ctx, provider{"my-sqlite": src}, params, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(string(got), `{"plan":[{"id":`) || !strings.Contains(string(got), "SEARCH t USING INTEGER PRIMARY KEY") {
+		t.Fatalf("unexpected plan: %s", got)
+	}
+
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM t").Scan(&n); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n != 1 {
+		t.Fatalf("dry run deleted the row")
+	}
+}
+
+func TestInvokeReturnsRows(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := sqlite.Config{
+		Name:     "my-sqlite",
+		Kind:     "sqlite",
+		Database: filepath.Join(t.TempDir(), "test.db"),
+	}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	if _, err := src.(*sqlite.Source).SQLiteDB().Exec("CREATE TABLE t (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+
+	tool, err := sqlitesql.Config{
+		Name:        "add_row",
+		Kind:        "sqlite-sql",
+		Source:      "my-sqlite",
+		Description: "some description",
+		Statement:   "INSERT INTO t (name) VALUES (?) RETURNING id, name",
+		Parameters:  parameters.Parameters{parameters.NewStringParameter("name", "some description")},
+		ReturnsRows: true,
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"name": "a"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res, err := tool.Invoke(ctx, provider{"my-sqlite": src}, params, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := `{"rows":[{"id":1,"name":"a"}],"rowsAffected":1}`; string(got) != want {
+		t.Fatalf("unexpected result: got %s, want %s", got, want)
+	}
+}
+
+func TestLikeParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := sqlite.Config{
+		Name:     "my-sqlite",
+		Kind:     "sqlite",
+		Database: filepath.Join(t.TempDir(), "test.db"),
+	}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	db := src.(*sqlite.Source).SQLiteDB()
+	if _, err := db.Exec("CREATE TABLE t (name TEXT); INSERT INTO t VALUES ('100%'), ('1000')"); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+
+	var params parameters.Parameters
+	in := `
+- name: name
+  type: string
+  description: name
+  transforms:
+    - like: prefix`
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	cfg := sqlitesql.Config{
+		Name:        "example_tool",
+		Kind:        "sqlite-sql",
+		Source:      "my-sqlite",
+		Description: "some description",
+		Statement:   "SELECT name FROM t WHERE name LIKE ?",
+		Parameters:  params,
+	}
+	// SQLite has no default escape character, so the escaped wildcards would
+	// match as wildcards
+	if _, err := cfg.Initialize(nil); err == nil || !strings.Contains(err.Error(), "ESCAPE") {
+		t.Fatalf("expected an error about the missing ESCAPE clause, got %v", err)
+	}
+
+	cfg.Statement = `SELECT name FROM t WHERE name LIKE ? ESCAPE '\'`
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	values, err := tool.ParseParams(map[string]any{"name": "100%"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res, err := tool.Invoke(ctx, provider{"my-sqlite": src}, values, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := `[{"name":"100%"}]`; string(got) != want {
+		t.Fatalf("unexpected result: got %s, want %s", got, want)
+	}
+}
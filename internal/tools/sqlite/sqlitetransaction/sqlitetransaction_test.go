@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlitetransaction_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlite/sqlitetransaction"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlSQLiteTransaction(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: sqlite-transaction
+					source: my-sqlite-instance
+					description: some description
+					isolationLevel: Serializable
+					statements:
+						- statement: INSERT INTO orders (customer) VALUES (?)
+						  parameters: [customer]
+						- statement: DELETE FROM orders WHERE customer = ? RETURNING id
+						  parameters: [customer]
+						  returnsRows: true
+						- statement: SELECT COUNT(*) AS n FROM orders
+					parameters:
+						- name: customer
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": sqlitetransaction.Config{
+					Name:           "example_tool",
+					Kind:           "sqlite-transaction",
+					Source:         "my-sqlite-instance",
+					Description:    "some description",
+					IsolationLevel: sqltx.Serializable,
+					Statements: []sqltx.StatementConfig{
+						{Statement: "INSERT INTO orders (customer) VALUES (?)", Parameters: []string{"customer"}},
+						{Statement: "DELETE FROM orders WHERE customer = ? RETURNING id", Parameters: []string{"customer"}, ReturnsRows: true},
+						{Statement: "SELECT COUNT(*) AS n FROM orders"},
+					},
+					AuthRequired: []string{},
+					Parameters: []parameters.Parameter{
+						parameters.NewStringParameter("customer", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseFromYamlInvalidIsolationLevel(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		example_tool:
+			kind: sqlite-transaction
+			source: my-sqlite-instance
+			description: some description
+			isolationLevel: snapshot
+			statements:
+				- statement: SELECT 1
+	`
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	err = yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got)
+	if err == nil || !strings.Contains(err.Error(), "isolationLevel invalid") {
+		t.Fatalf("expected isolationLevel error, got %v", err)
+	}
+}
+
+type provider map[string]sources.Source
+
+func (p provider) GetSource(name string) (sources.Source, bool) {
+	s, ok := p[name]
+	return s, ok
+}
+
+func TestInvoke(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := sqlite.Config{
+		Name:     "my-sqlite",
+		Kind:     "sqlite",
+		Database: filepath.Join(t.TempDir(), "test.db"),
+	}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	db := src.(*sqlite.Source).SQLiteDB()
+	if _, err := db.Exec("CREATE TABLE orders (id INTEGER PRIMARY KEY, customer TEXT NOT NULL)"); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+
+	newTool := func(second sqltx.StatementConfig) sqlitetransaction.Tool {
+		tool, err := sqlitetransaction.Config{
+			Name:        "tx",
+			Kind:        "sqlite-transaction",
+			Source:      "my-sqlite",
+			Description: "d",
+			Statements: []sqltx.StatementConfig{
+				{Statement: "INSERT INTO orders (customer) VALUES (?)", Parameters: []string{"customer"}},
+				second,
+			},
+			Parameters: parameters.Parameters{parameters.NewStringParameter("customer", "")},
+		}.Initialize(nil)
+		if err != nil {
+			t.Fatalf("unable to initialize tool: %s", err)
+		}
+		return tool.(sqlitetransaction.Tool)
+	}
+	params := parameters.ParamValues{{Name: "customer", Value: "alice"}}
+
+	res, err := newTool(sqltx.StatementConfig{Statement: "SELECT customer FROM orders WHERE customer = ?", Parameters: []string{"customer"}}).Invoke(ctx, provider{"my-sqlite": src}, params, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `[{"rowsAffected":1,"lastInsertId":1},{"rows":[{"customer":"alice"}],"rowsAffected":1}]`
+	if string(got) != want {
+		t.Fatalf("unexpected result: got %s, want %s", got, want)
+	}
+
+	// a failing statement rolls back the whole transaction
+	_, err = newTool(sqltx.StatementConfig{Statement: "INSERT INTO missing (customer) VALUES (?)", Parameters: []string{"customer"}}).Invoke(ctx, provider{"my-sqlite": src}, params, "")
+	if err == nil || !strings.Contains(err.Error(), "statement 2 failed") {
+		t.Fatalf("expected statement 2 to fail, got %v", err)
+	}
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM orders").Scan(&n); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected rollback to leave 1 row, got %d", n)
+	}
+
+	// DML returns its rows when declared to
+	res, err = newTool(sqltx.StatementConfig{Statement: "DELETE FROM orders WHERE customer = ? RETURNING id", Parameters: []string{"customer"}, ReturnsRows: true}).Invoke(ctx, provider{"my-sqlite": src}, params, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err = json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want = `[{"rowsAffected":1,"lastInsertId":2},{"rows":[{"id":1},{"id":2}],"rowsAffected":2}]`
+	if string(got) != want {
+		t.Fatalf("unexpected result: got %s, want %s", got, want)
+	}
+}
+
+func TestInitializeUndeclaredParameter(t *testing.T) {
+	_, err := sqlitetransaction.Config{
+		Name:        "tx",
+		Kind:        "sqlite-transaction",
+		Source:      "my-sqlite",
+		Description: "d",
+		Statements:  []sqltx.StatementConfig{{Statement: "SELECT ?", Parameters: []string{"missing"}}},
+	}.Initialize(nil)
+	if err == nil || !strings.Contains(err.Error(), `parameter "missing" is not declared`) {
+		t.Fatalf("expected undeclared parameter error, got %v", err)
+	}
+}
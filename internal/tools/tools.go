@@ -16,11 +16,14 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 
 	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
@@ -47,6 +50,11 @@ func Register(kind string, factory ToolConfigFactory) bool {
 	return true
 }
 
+// Kinds returns the registered tool kinds, sorted.
+func Kinds() []string {
+	return slices.Sorted(maps.Keys(toolRegistry))
+}
+
 // DecodeConfig looks up the registered factory for the given kind and uses it
 // to decode the tool configuration.
 func DecodeConfig(ctx context.Context, kind string, name string, decoder *yaml.Decoder) (ToolConfig, error) {
@@ -66,6 +74,31 @@ type ToolConfig interface {
 	Initialize(map[string]sources.Source) (Tool, error)
 }
 
+// SourceNotFoundError is returned by ToolConfig.Initialize when a tool that
+// needs its source to initialize is given no source of that name.
+type SourceNotFoundError struct {
+	Name string
+}
+
+func (e SourceNotFoundError) Error() string {
+	return fmt.Sprintf("no source named %q configured", e.Name)
+}
+
+// BaseConfig returns the config of the tool kind that tc wraps, e.g. for a
+// tool with a cache or rules.
+func BaseConfig(tc ToolConfig) ToolConfig {
+	for {
+		switch c := tc.(type) {
+		case CachedToolConfig:
+			tc = c.ToolConfig
+		case RuledToolConfig:
+			tc = c.ToolConfig
+		default:
+			return tc
+		}
+	}
+}
+
 // https://modelcontextprotocol.io/specification/2025-06-18/schema#toolannotations
 type ToolAnnotations struct {
 	DestructiveHint *bool `json:"destructiveHint,omitempty" yaml:"destructiveHint,omitempty"`
@@ -95,6 +128,43 @@ type Tool interface {
 	GetAuthTokenHeaderName(SourceProvider) (string, error)
 }
 
+// Invoke invokes a tool with its parsed parameters, redacting the text of
+// sensitive values from the error since sources may echo them back. The texts
+// of parameters with `embeddedBy` set are embedded with the models of
+// resourceMgr first, unless the result is served from a cache.
+func Invoke(ctx context.Context, tool Tool, resourceMgr SourceProvider, params parameters.ParamValues, accessToken AccessToken) (any, error) {
+	res, err := invoke(ctx, tool, resourceMgr, params, accessToken)
+	return res, params.RedactError(err)
+}
+
+// ErrEmbedding is wrapped by the errors of Invoke that are caused by
+// embedding parameters, rather than by the tool.
+var ErrEmbedding = errors.New("unable to embed parameters")
+
+// EmbeddingModelProvider is the view of the server.ResourceManager needed to
+// embed parameters.
+type EmbeddingModelProvider interface {
+	GetEmbeddingModelMap() map[string]embeddingmodels.EmbeddingModel
+}
+
+// invoke embeds the params of a tool and invokes it. Cached tools look up
+// their results first and embed on a miss, so that hits don't call the
+// embedding model.
+func invoke(ctx context.Context, tool Tool, resourceMgr SourceProvider, params parameters.ParamValues, accessToken AccessToken) (any, error) {
+	if c, ok := tool.(CachedTool); ok {
+		return c.Invoke(ctx, resourceMgr, params, accessToken)
+	}
+	var models map[string]embeddingmodels.EmbeddingModel
+	if p, ok := resourceMgr.(EmbeddingModelProvider); ok {
+		models = p.GetEmbeddingModelMap()
+	}
+	params, err := parameters.EmbedParams(ctx, params, models)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEmbedding, err)
+	}
+	return tool.Invoke(ctx, resourceMgr, params, accessToken)
+}
+
 // SourceProvider defines the minimal view of the server.ResourceManager
 // that the Tool package needs.
 // This is implemented to prevent import cycles.
@@ -165,6 +235,10 @@ func GetCompatibleSource[T any](resourceMgr SourceProvider, sourceName, toolName
 		return zero, fmt.Errorf("unable to retrieve source %q for tool %q", sourceName, toolName)
 	}
 	source, ok := s.(T)
+	if p, isProbe := resourceMgr.(*sourceProbe); isProbe {
+		p.checked, p.compatible = true, ok
+		return zero, errSourceProbed
+	}
 	if !ok {
 		return zero, fmt.Errorf("invalid source for %q tool: source %q is not a compatible type", toolKind, sourceName)
 	}
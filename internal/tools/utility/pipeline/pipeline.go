@@ -0,0 +1,419 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+const kind string = "pipeline"
+
+const (
+	outputLast = "last"
+	outputAll  = "all"
+)
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// toolProvider is the view of the server.ResourceManager needed to look up
+// the tools referenced by each step.
+type toolProvider interface {
+	GetTool(toolName string) (tools.Tool, bool)
+}
+
+// Step is a single invocation of an existing tool.
+type Step struct {
+	// Name identifies the step's result for later steps. Defaults to the tool name.
+	Name string `yaml:"name"`
+	// Tool is the name of the tool to invoke.
+	Tool string `yaml:"tool" validate:"required"`
+	// Params maps the step tool's parameter names to expressions.
+	Params map[string]any `yaml:"params"`
+}
+
+type Config struct {
+	Name         string                `yaml:"name" validate:"required"`
+	Kind         string                `yaml:"kind" validate:"required"`
+	Description  string                `yaml:"description" validate:"required"`
+	AuthRequired []string              `yaml:"authRequired"`
+	Parameters   parameters.Parameters `yaml:"parameters"`
+	Steps        []Step                `yaml:"steps" validate:"required,min=1,dive"`
+	Output       string                `yaml:"output" validate:"omitempty,oneof=last all"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+// ParameterNames returns the names of the parameters of the tool.
+func (cfg Config) ParameterNames() []string {
+	return cfg.Parameters.Names()
+}
+
+func (cfg Config) Initialize(_ map[string]sources.Source) (tools.Tool, error) {
+	seen := make(map[string]bool)
+	steps := make([]Step, len(cfg.Steps))
+	for i, s := range cfg.Steps {
+		if s.Tool == cfg.Name {
+			return nil, fmt.Errorf("step #%d of pipeline %q references the pipeline itself", i+1, cfg.Name)
+		}
+		if s.Name == "" {
+			s.Name = s.Tool
+		}
+		if seen[s.Name] {
+			return nil, fmt.Errorf("duplicate step name %q in pipeline %q; set a unique `name` for each step", s.Name, cfg.Name)
+		}
+		if err := checkReferences(s.Params, seen, cfg.Parameters); err != nil {
+			return nil, fmt.Errorf("invalid params for step %q: %w", s.Name, err)
+		}
+		seen[s.Name] = true
+		steps[i] = s
+	}
+	if cfg.Output == "" {
+		cfg.Output = outputLast
+	}
+
+	params := cfg.Parameters
+	if params == nil {
+		params = make(parameters.Parameters, 0)
+	}
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, nil)
+
+	t := Tool{
+		Config:      cfg,
+		steps:       steps,
+		manifest:    tools.Manifest{Description: cfg.Description, Parameters: params.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Config
+	steps       []Step
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+type pipelineKey struct{}
+
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, error) {
+	provider, ok := resourceMgr.(toolProvider)
+	if !ok {
+		return nil, fmt.Errorf("tool %q requires a resource manager that provides tools", t.Name)
+	}
+
+	// guard against pipelines that (indirectly) invoke themselves
+	running, _ := ctx.Value(pipelineKey{}).([]string)
+	if slices.Contains(running, t.Name) {
+		return nil, fmt.Errorf("pipeline %q invokes itself: %s", t.Name, strings.Join(append(running, t.Name), " -> "))
+	}
+	ctx = context.WithValue(ctx, pipelineKey{}, append(slices.Clone(running), t.Name))
+
+	// params are normalized like step results, so that typed values such as
+	// dates and typed nulls are passed on as the plain values steps parse
+	paramsScope, err := normalize(params.AsMap())
+	if err != nil {
+		return nil, fmt.Errorf("unable to normalize params: %w", err)
+	}
+	scope := map[string]any{
+		"params": paramsScope,
+		"steps":  map[string]any{},
+	}
+	// steps are authorized like direct calls, against the auth services the
+	// caller's tokens were verified for, and take authenticated params from
+	// the claims of those tokens
+	verified, _ := util.VerifiedAuthServicesFromContext(ctx)
+	claims, _ := util.ClaimsFromContext(ctx)
+	results := orderedmap.Row{}
+	var last any
+	for _, s := range t.steps {
+		tool, ok := provider.GetTool(s.Tool)
+		if !ok {
+			return nil, fmt.Errorf("step %q: tool %q does not exist", s.Name, s.Tool)
+		}
+		if !tool.Authorized(verified) {
+			return nil, fmt.Errorf("step %q: tool %q requires authorization the caller does not have: %w", s.Name, s.Tool, util.ErrUnauthorized)
+		}
+
+		data := make(map[string]any, len(s.Params))
+		for k, expr := range s.Params {
+			v, err := evaluate(expr, scope)
+			if err != nil {
+				return nil, fmt.Errorf("step %q: unable to resolve param %q: %w", s.Name, k, err)
+			}
+			data[k] = v
+		}
+		stepParams, err := tool.ParseParams(data, claims)
+		if err != nil {
+			return nil, fmt.Errorf("step %q: invalid params for tool %q: %w", s.Name, s.Tool, err)
+		}
+		res, err := tools.Invoke(ctx, tool, resourceMgr, stepParams, accessToken)
+		if err != nil {
+			return nil, fmt.Errorf("step %q: error invoking tool %q: %w", s.Name, s.Tool, err)
+		}
+		normalized, err := normalize(res)
+		if err != nil {
+			return nil, fmt.Errorf("step %q: %w", s.Name, err)
+		}
+		scope["steps"].(map[string]any)[s.Name] = normalized
+		results.Add(s.Name, normalized)
+		last = normalized
+	}
+
+	if t.Output == outputAll {
+		return results, nil
+	}
+	return last, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
+	return parameters.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
+
+// checkingProvider carries the pipelines already checked by
+// RequiresClientAuthorization into nested pipelines, whether or not they are
+// wrapped (e.g. cached), so that steps referencing each other don't recurse
+// forever.
+type checkingProvider struct {
+	tools.SourceProvider
+	toolProvider
+	visited map[string]bool
+}
+
+func (t Tool) RequiresClientAuthorization(resourceMgr tools.SourceProvider) (bool, error) {
+	checker, ok := resourceMgr.(checkingProvider)
+	if !ok {
+		provider, ok := resourceMgr.(toolProvider)
+		if !ok {
+			return false, nil
+		}
+		checker = checkingProvider{SourceProvider: resourceMgr, toolProvider: provider, visited: make(map[string]bool)}
+	}
+	if checker.visited[t.Name] {
+		return false, nil
+	}
+	checker.visited[t.Name] = true
+	for _, s := range t.steps {
+		tool, ok := checker.GetTool(s.Tool)
+		if !ok {
+			return false, fmt.Errorf("step %q: tool %q does not exist", s.Name, s.Tool)
+		}
+		clientAuth, err := tool.RequiresClientAuthorization(checker)
+		if err != nil || clientAuth {
+			return clientAuth, err
+		}
+	}
+	return false, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Config
+}
+
+func (t Tool) GetAuthTokenHeaderName(resourceMgr tools.SourceProvider) (string, error) {
+	return "Authorization", nil
+}
+
+// normalize converts a tool result into plain JSON values (maps, slices,
+// strings, json.Number, bools) so that later steps can address into it.
+func normalize(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal result: %w", err)
+	}
+	var out any
+	if err := util.DecodeJSON(bytes.NewReader(b), &out); err != nil {
+		return nil, fmt.Errorf("unable to decode result: %w", err)
+	}
+	return out, nil
+}
+
+// evaluate resolves an expression against the pipeline scope. Strings that
+// start with "$" are references such as `$params.email` or
+// `$steps.lookup[0].id`, and "$$" escapes a literal leading dollar sign. Maps
+// and lists are evaluated recursively and any other value is a literal.
+func evaluate(expr any, scope map[string]any) (any, error) {
+	switch e := expr.(type) {
+	case string:
+		if strings.HasPrefix(e, "$$") {
+			return e[1:], nil
+		}
+		if strings.HasPrefix(e, "$") {
+			return resolve(e[1:], scope)
+		}
+		return e, nil
+	case map[string]any:
+		out := make(map[string]any, len(e))
+		for k, v := range e {
+			r, err := evaluate(v, scope)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = r
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(e))
+		for i, v := range e {
+			r, err := evaluate(v, scope)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = r
+		}
+		return out, nil
+	case uint64:
+		// integers in YAML are decoded as uint64, which parameters don't accept
+		return json.Number(strconv.FormatUint(e, 10)), nil
+	default:
+		return e, nil
+	}
+}
+
+// resolve walks a dotted path with optional [index] segments through v.
+func resolve(path string, v any) (any, error) {
+	segments, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 || (segments[0] != "params" && segments[0] != "steps") {
+		return nil, fmt.Errorf("reference %q must start with $params or $steps", "$"+path)
+	}
+	cur := v
+	for i, seg := range segments {
+		switch c := cur.(type) {
+		case map[string]any:
+			next, ok := c[seg]
+			if !ok {
+				return nil, fmt.Errorf("%q not found in $%s", seg, strings.Join(segments[:i], "."))
+			}
+			cur = next
+		case []any:
+			idx, err := strconv.Atoi(seg)
+			if err != nil {
+				return nil, fmt.Errorf("expected index into list at $%s, got %q", strings.Join(segments[:i], "."), seg)
+			}
+			if idx < 0 {
+				idx += len(c)
+			}
+			if idx < 0 || idx >= len(c) {
+				return nil, fmt.Errorf("index %s out of range at $%s (length %d)", seg, strings.Join(segments[:i], "."), len(c))
+			}
+			cur = c[idx]
+		default:
+			return nil, fmt.Errorf("cannot resolve %q on a value of type %T at $%s", seg, cur, strings.Join(segments[:i], "."))
+		}
+	}
+	return cur, nil
+}
+
+// splitPath splits `steps.lookup[0].id` into ["steps", "lookup", "0", "id"].
+func splitPath(path string) ([]string, error) {
+	normalized := strings.NewReplacer("[", ".", "]", "").Replace(path)
+	segments := strings.Split(normalized, ".")
+	for _, seg := range segments {
+		if seg == "" {
+			return nil, fmt.Errorf("malformed reference %q", "$"+path)
+		}
+	}
+	return segments, nil
+}
+
+// checkReferences validates that references in a step's params point at
+// declared pipeline parameters or earlier steps.
+func checkReferences(expr any, steps map[string]bool, params parameters.Parameters) error {
+	switch e := expr.(type) {
+	case string:
+		if !strings.HasPrefix(e, "$") || strings.HasPrefix(e, "$$") {
+			return nil
+		}
+		segments, err := splitPath(e[1:])
+		if err != nil {
+			return err
+		}
+		if len(segments) < 2 {
+			return fmt.Errorf("reference %q must name a parameter or step", e)
+		}
+		switch segments[0] {
+		case "params":
+			if !slices.ContainsFunc(params, func(p parameters.Parameter) bool { return p.GetName() == segments[1] }) {
+				return fmt.Errorf("reference %q: pipeline has no parameter %q", e, segments[1])
+			}
+		case "steps":
+			if !steps[segments[1]] {
+				return fmt.Errorf("reference %q: no earlier step named %q", e, segments[1])
+			}
+		default:
+			return fmt.Errorf("reference %q must start with $params or $steps", e)
+		}
+	case map[string]any:
+		for _, v := range e {
+			if err := checkReferences(v, steps, params); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, v := range e {
+			if err := checkReferences(v, steps, params); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
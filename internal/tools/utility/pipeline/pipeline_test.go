@@ -0,0 +1,446 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/utility/pipeline"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/cache"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYamlPipeline(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				customer_orders:
+					kind: pipeline
+					description: some description
+					parameters:
+						- name: email
+							type: string
+							description: customer email
+					steps:
+						- name: lookup
+							tool: find_customer
+							params:
+								email: $params.email
+						- tool: get_orders
+							params:
+								customer_id: $steps.lookup[0].id
+					output: all
+			`,
+			want: server.ToolConfigs{
+				"customer_orders": pipeline.Config{
+					Name:         "customer_orders",
+					Kind:         "pipeline",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Parameters: parameters.Parameters{
+						parameters.NewStringParameter("email", "customer email"),
+					},
+					Steps: []pipeline.Step{
+						{Name: "lookup", Tool: "find_customer", Params: map[string]any{"email": "$params.email"}},
+						{Tool: "get_orders", Params: map[string]any{"customer_id": "$steps.lookup[0].id"}},
+					},
+					Output: "all",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidReferences(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		steps   []pipeline.Step
+		wantErr string
+	}{
+		{
+			desc:    "unknown parameter",
+			steps:   []pipeline.Step{{Tool: "a", Params: map[string]any{"x": "$params.missing"}}},
+			wantErr: `pipeline has no parameter "missing"`,
+		},
+		{
+			desc: "later step",
+			steps: []pipeline.Step{
+				{Tool: "a", Params: map[string]any{"x": "$steps.b.id"}},
+				{Tool: "b"},
+			},
+			wantErr: `no earlier step named "b"`,
+		},
+		{
+			desc:    "duplicate step",
+			steps:   []pipeline.Step{{Tool: "a"}, {Tool: "a"}},
+			wantErr: `duplicate step name "a"`,
+		},
+		{
+			desc:    "self reference",
+			steps:   []pipeline.Step{{Tool: "p"}},
+			wantErr: "references the pipeline itself",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := pipeline.Config{Name: "p", Kind: "pipeline", Description: "d", Steps: tc.steps}
+			_, err := cfg.Initialize(nil)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+// fakeTool returns a fixed result, or echoes its parameters if result is nil.
+type fakeTool struct {
+	tools.Tool
+	params       parameters.Parameters
+	result       any
+	authRequired []string
+	clientAuth   bool
+}
+
+func (f fakeTool) Invoke(_ context.Context, _ tools.SourceProvider, params parameters.ParamValues, _ tools.AccessToken) (any, error) {
+	if f.result != nil {
+		return f.result, nil
+	}
+	return params.AsMap(), nil
+}
+
+func (f fakeTool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
+	return parameters.ParseParams(f.params, data, claims)
+}
+
+func (f fakeTool) Authorized(verified []string) bool {
+	return tools.IsAuthorized(f.authRequired, verified)
+}
+
+func (f fakeTool) RequiresClientAuthorization(tools.SourceProvider) (bool, error) {
+	return f.clientAuth, nil
+}
+
+type fakeProvider struct {
+	tools map[string]tools.Tool
+}
+
+func (p fakeProvider) GetSource(string) (sources.Source, bool) {
+	return nil, false
+}
+
+func (p fakeProvider) GetTool(name string) (tools.Tool, bool) {
+	t, ok := p.tools[name]
+	return t, ok
+}
+
+func TestInvoke(t *testing.T) {
+	provider := fakeProvider{tools: map[string]tools.Tool{
+		"find_customer": fakeTool{
+			params: parameters.Parameters{parameters.NewStringParameter("email", "")},
+			result: []any{map[string]any{"id": 42, "email": "a@b.c"}},
+		},
+		"get_orders": fakeTool{
+			params: parameters.Parameters{
+				parameters.NewIntParameter("customer_id", ""),
+				parameters.NewIntParameter("limit", ""),
+				parameters.NewStringParameter("note", ""),
+			},
+		},
+	}}
+
+	for _, output := range []string{"", "all"} {
+		t.Run("output "+output, func(t *testing.T) {
+			cfg := pipeline.Config{
+				Name:        "customer_orders",
+				Kind:        "pipeline",
+				Description: "d",
+				Parameters:  parameters.Parameters{parameters.NewStringParameter("email", "")},
+				Steps: []pipeline.Step{
+					{Name: "lookup", Tool: "find_customer", Params: map[string]any{"email": "$params.email"}},
+					{Tool: "get_orders", Params: map[string]any{
+						"customer_id": "$steps.lookup[0].id",
+						"limit":       uint64(10),
+						"note":        "$$literal",
+					}},
+				},
+				Output: output,
+			}
+			tool, err := cfg.Initialize(nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			params, err := tool.ParseParams(map[string]any{"email": "a@b.c"}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			res, err := tool.Invoke(context.Background(), provider, params, "")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, err := json.Marshal(res)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			want := `{"customer_id":42,"limit":10,"note":"$literal"}`
+			if output == "all" {
+				want = `{"lookup":[{"email":"a@b.c","id":42}],"get_orders":` + want + `}`
+			}
+			if string(got) != want {
+				t.Fatalf("unexpected result: got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestInvokeForwardTypedParams(t *testing.T) {
+	provider := fakeProvider{tools: map[string]tools.Tool{
+		"get": fakeTool{params: parameters.Parameters{
+			parameters.NewDateParameter("day", ""),
+			parameters.NewUUIDParameter("id", ""),
+		}},
+	}}
+	cfg := pipeline.Config{
+		Name:        "p",
+		Kind:        "pipeline",
+		Description: "d",
+		Parameters: parameters.Parameters{
+			parameters.NewDateParameter("d", ""),
+			parameters.NewUUIDParameter("u", ""),
+		},
+		Steps: []pipeline.Step{
+			{Tool: "get", Params: map[string]any{"day": "$params.d", "id": "$params.u"}},
+		},
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	id := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	params, err := tool.ParseParams(map[string]any{"d": "2024-01-02", "u": id}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res, err := tool.Invoke(context.Background(), provider, params, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `{"day":"2024-01-02","id":"` + id + `"}`
+	if string(got) != want {
+		t.Fatalf("unexpected result: got %s, want %s", got, want)
+	}
+}
+
+func TestInvokeResolveError(t *testing.T) {
+	provider := fakeProvider{tools: map[string]tools.Tool{
+		"list": fakeTool{result: []any{}},
+		"get":  fakeTool{params: parameters.Parameters{parameters.NewIntParameter("id", "")}},
+	}}
+	cfg := pipeline.Config{
+		Name:        "p",
+		Kind:        "pipeline",
+		Description: "d",
+		Steps: []pipeline.Step{
+			{Tool: "list"},
+			{Tool: "get", Params: map[string]any{"id": "$steps.list[0].id"}},
+		},
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = tool.Invoke(context.Background(), provider, nil, "")
+	if err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Fatalf("expected out of range error, got %v", err)
+	}
+}
+
+func TestInvokeStepAuthorization(t *testing.T) {
+	provider := fakeProvider{tools: map[string]tools.Tool{
+		"public": fakeTool{result: []any{}},
+		"admin":  fakeTool{result: []any{}, authRequired: []string{"admin-auth"}},
+	}}
+	cfg := pipeline.Config{
+		Name:         "p",
+		Kind:         "pipeline",
+		Description:  "d",
+		AuthRequired: []string{"user-auth", "admin-auth"},
+		Steps:        []pipeline.Step{{Tool: "public"}, {Tool: "admin"}},
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the pipeline is authorized by user-auth, but the admin step isn't
+	ctx := util.WithVerifiedAuthServices(context.Background(), []string{"user-auth"})
+	if !tool.Authorized([]string{"user-auth"}) {
+		t.Fatalf("expected the pipeline to be authorized")
+	}
+	_, err = tool.Invoke(ctx, provider, nil, "")
+	if !errors.Is(err, util.ErrUnauthorized) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+
+	ctx = util.WithVerifiedAuthServices(context.Background(), []string{"admin-auth"})
+	if _, err := tool.Invoke(ctx, provider, nil, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestInvokeStepAuthenticatedParams(t *testing.T) {
+	provider := fakeProvider{tools: map[string]tools.Tool{
+		"whoami": fakeTool{params: parameters.Parameters{
+			parameters.NewStringParameterWithAuth("email", "", []parameters.ParamAuthService{{Name: "user-auth", Field: "email"}}),
+		}},
+	}}
+	cfg := pipeline.Config{
+		Name:         "p",
+		Kind:         "pipeline",
+		Description:  "d",
+		AuthRequired: []string{"user-auth"},
+		Steps:        []pipeline.Step{{Tool: "whoami"}},
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the step takes the email from the claims of the caller's token
+	ctx := util.WithVerifiedAuthServices(context.Background(), []string{"user-auth"})
+	ctx = util.WithClaims(ctx, map[string]map[string]any{"user-auth": {"email": "a@b.c"}})
+	res, err := tool.Invoke(ctx, provider, nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(map[string]any{"email": "a@b.c"}, res); diff != "" {
+		t.Fatalf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	// without a token, the authenticated param can't be parsed
+	if _, err := tool.Invoke(context.Background(), provider, nil, ""); err == nil {
+		t.Fatalf("expected an error for a missing token")
+	}
+}
+
+func TestInvokeCachedStepAuthorization(t *testing.T) {
+	provider := fakeProvider{tools: map[string]tools.Tool{
+		"admin": fakeTool{result: []any{}, authRequired: []string{"admin-auth"}},
+	}}
+	cfg := pipeline.Config{
+		Name:         "p",
+		Kind:         "pipeline",
+		Description:  "d",
+		AuthRequired: []string{"user-auth", "admin-auth"},
+		Steps:        []pipeline.Step{{Tool: "admin"}},
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cached := tools.NewCachedTool(tool, cache.NewMemory(10), time.Minute)
+
+	ctx := util.WithVerifiedAuthServices(context.Background(), []string{"admin-auth"})
+	if _, err := cached.Invoke(ctx, provider, nil, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// the result cached for the admin isn't served to a caller without admin-auth
+	ctx = util.WithVerifiedAuthServices(context.Background(), []string{"user-auth"})
+	if _, err := cached.Invoke(ctx, provider, nil, ""); !errors.Is(err, util.ErrUnauthorized) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestRequiresClientAuthorization(t *testing.T) {
+	newPipeline := func(name string, steps ...string) tools.Tool {
+		cfg := pipeline.Config{Name: name, Kind: "pipeline", Description: "d"}
+		for _, s := range steps {
+			cfg.Steps = append(cfg.Steps, pipeline.Step{Tool: s})
+		}
+		tool, err := cfg.Initialize(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		// wrapped pipelines must be followed too
+		return tools.NewCachedTool(tool, cache.NewMemory(10), time.Minute)
+	}
+	provider := fakeProvider{tools: map[string]tools.Tool{
+		"public": fakeTool{},
+		"client": fakeTool{clientAuth: true},
+		"a":      newPipeline("a", "b", "public"),
+		"b":      newPipeline("b", "a", "public"),
+		"outer":  newPipeline("outer", "public", "inner"),
+		"inner":  newPipeline("inner", "outer", "client"),
+	}}
+
+	tcs := []struct {
+		tool string
+		want bool
+	}{
+		{tool: "a", want: false},
+		{tool: "outer", want: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.tool, func(t *testing.T) {
+			got, err := provider.tools[tc.tool].RequiresClientAuthorization(provider)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect client authorization: got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
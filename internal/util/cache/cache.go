@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// MemoryBackend is the name of the in-process LRU backend.
+	MemoryBackend = "memory"
+
+	defaultTTL     = 5 * time.Minute
+	defaultMaxSize = 1000
+)
+
+// Backend stores cached values. Implementations must be safe for concurrent use.
+type Backend interface {
+	Get(ctx context.Context, key string) (any, bool)
+	Set(ctx context.Context, key string, value any, ttl time.Duration)
+}
+
+// BackendFactory creates a Backend that holds at most maxSize entries.
+type BackendFactory func(maxSize int) (Backend, error)
+
+var (
+	backendRegistryMu sync.RWMutex
+	backendRegistry   = map[string]BackendFactory{
+		MemoryBackend: func(maxSize int) (Backend, error) { return NewMemory(maxSize), nil },
+	}
+)
+
+// RegisterBackend allows additional cache backends to be made available by
+// name. It returns false if a backend with the same name is already registered.
+func RegisterBackend(name string, factory BackendFactory) bool {
+	backendRegistryMu.Lock()
+	defer backendRegistryMu.Unlock()
+	if _, exists := backendRegistry[name]; exists {
+		return false
+	}
+	backendRegistry[name] = factory
+	return true
+}
+
+// NewBackend creates a new Backend using the factory registered for name.
+func NewBackend(name string, maxSize int) (Backend, error) {
+	backendRegistryMu.RLock()
+	factory, ok := backendRegistry[name]
+	backendRegistryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown cache backend: %q", name)
+	}
+	return factory(maxSize)
+}
+
+// Config is the caching configuration that can be attached to a tool.
+type Config struct {
+	// Enabled toggles the cache. Defaults to true when a cache block is present.
+	Enabled *bool `yaml:"enabled"`
+	// TTL is how long an entry is kept, as a Go duration string (e.g. "30s").
+	TTL string `yaml:"ttl"`
+	// MaxSize is the maximum number of entries kept for the tool.
+	MaxSize int `yaml:"maxSize" validate:"gte=0"`
+	// Backend is the name of the registered backend. Defaults to "memory".
+	Backend string `yaml:"backend"`
+}
+
+// IsEnabled reports whether caching is turned on for this config.
+func (c Config) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// GetTTL returns the parsed TTL, or the default TTL if unset.
+func (c Config) GetTTL() (time.Duration, error) {
+	if c.TTL == "" {
+		return defaultTTL, nil
+	}
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache ttl %q: %w", c.TTL, err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("cache ttl must be positive, got %q", c.TTL)
+	}
+	return ttl, nil
+}
+
+// NewBackend creates the Backend described by the config.
+func (c Config) NewBackend() (Backend, error) {
+	name := c.Backend
+	if name == "" {
+		name = MemoryBackend
+	}
+	maxSize := c.MaxSize
+	if maxSize == 0 {
+		maxSize = defaultMaxSize
+	}
+	return NewBackend(name, maxSize)
+}
+
+// Key returns a stable hash of the given parts, suitable for use as a cache key.
+func Key(parts ...any) (string, error) {
+	b, err := json.Marshal(parts)
+	if err != nil {
+		return "", fmt.Errorf("unable to compute cache key: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+type memoryEntry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+// Memory is an in-process LRU Backend with per-entry expiry.
+type Memory struct {
+	mu      sync.Mutex
+	maxSize int
+	ll      *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+var _ Backend = &Memory{}
+
+// NewMemory returns an in-memory Backend holding at most maxSize entries.
+func NewMemory(maxSize int) *Memory {
+	return &Memory{
+		maxSize: maxSize,
+		ll:      list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+func (m *Memory) Get(_ context.Context, key string) (any, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*memoryEntry)
+	if !m.now().Before(entry.expiresAt) {
+		m.ll.Remove(e)
+		delete(m.entries, key)
+		return nil, false
+	}
+	m.ll.MoveToFront(e)
+	return entry.value, true
+}
+
+func (m *Memory) Set(_ context.Context, key string, value any, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expiresAt := m.now().Add(ttl)
+	if e, ok := m.entries[key]; ok {
+		entry := e.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.ll.MoveToFront(e)
+		return
+	}
+	m.entries[key] = m.ll.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for m.maxSize > 0 && m.ll.Len() > m.maxSize {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// Len returns the number of entries currently held, including expired ones
+// that have not been evicted yet.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(0, 0)
+	m := NewMemory(10)
+	m.now = func() time.Time { return now }
+
+	m.Set(ctx, "a", "value", time.Minute)
+	if v, ok := m.Get(ctx, "a"); !ok || v != "value" {
+		t.Fatalf("expected hit with %q, got %v (ok=%t)", "value", v, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := m.Get(ctx, "a"); ok {
+		t.Fatalf("expected entry to be expired")
+	}
+	if m.Len() != 0 {
+		t.Fatalf("expected expired entry to be evicted, got %d entries", m.Len())
+	}
+}
+
+func TestMemoryEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(2)
+
+	m.Set(ctx, "a", 1, time.Minute)
+	m.Set(ctx, "b", 2, time.Minute)
+	// touch "a" so "b" becomes the least recently used entry
+	if _, ok := m.Get(ctx, "a"); !ok {
+		t.Fatalf("expected hit for %q", "a")
+	}
+	m.Set(ctx, "c", 3, time.Minute)
+
+	if _, ok := m.Get(ctx, "b"); ok {
+		t.Fatalf("expected %q to be evicted", "b")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok := m.Get(ctx, k); !ok {
+			t.Fatalf("expected hit for %q", k)
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	k1, err := Key("tool", map[string]any{"a": 1, "b": "x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	k2, err := Key("tool", map[string]any{"b": "x", "a": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if k1 != k2 {
+		t.Fatalf("expected equal keys for equal inputs")
+	}
+	k3, err := Key("tool", map[string]any{"a": 2, "b": "x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if k1 == k3 {
+		t.Fatalf("expected different keys for different inputs")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	disabled := false
+	tcs := []struct {
+		desc        string
+		cfg         Config
+		wantEnabled bool
+		wantTTL     time.Duration
+		wantErr     bool
+	}{
+		{desc: "defaults", cfg: Config{}, wantEnabled: true, wantTTL: defaultTTL},
+		{desc: "custom ttl", cfg: Config{TTL: "30s"}, wantEnabled: true, wantTTL: 30 * time.Second},
+		{desc: "disabled", cfg: Config{Enabled: &disabled}, wantEnabled: false, wantTTL: defaultTTL},
+		{desc: "invalid ttl", cfg: Config{TTL: "soon"}, wantEnabled: true, wantErr: true},
+		{desc: "negative ttl", cfg: Config{TTL: "-1s"}, wantEnabled: true, wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.cfg.IsEnabled(); got != tc.wantEnabled {
+				t.Fatalf("IsEnabled: got %t, want %t", got, tc.wantEnabled)
+			}
+			ttl, err := tc.cfg.GetTTL()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ttl != tc.wantTTL {
+				t.Fatalf("GetTTL: got %s, want %s", ttl, tc.wantTTL)
+			}
+		})
+	}
+}
+
+func TestNewBackendUnknown(t *testing.T) {
+	if _, err := (Config{Backend: "does-not-exist"}).NewBackend(); err == nil {
+		t.Fatalf("expected error for unknown backend")
+	}
+}
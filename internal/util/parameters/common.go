@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"text/template"
 )
 
@@ -109,6 +110,16 @@ func PopulateTemplateWithFunc(templateName, templateString string, data map[stri
 	return result.String(), nil
 }
 
+// IsNull reports whether a parameter value is null, which is nil or the typed
+// nil of a nullable parameter.
+func IsNull(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
 // CheckDuplicateParameters verify there are no duplicate parameter names
 func CheckDuplicateParameters(ps Parameters) error {
 	seenNames := make(map[string]bool)
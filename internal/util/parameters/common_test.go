@@ -295,3 +295,24 @@ func TestPopulateTemplateWithJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestIsNull(t *testing.T) {
+	tcs := []struct {
+		name string
+		in   any
+		want bool
+	}{
+		{name: "nil", in: nil, want: true},
+		{name: "typed nil", in: (*string)(nil), want: true},
+		{name: "empty string", in: "", want: false},
+		{name: "zero", in: 0, want: false},
+		{name: "empty map", in: map[string]any{}, want: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parameters.IsNull(tc.in); got != tc.want {
+				t.Fatalf("IsNull(%v) = %v, want %v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// computedFuncs returns the functions available to the templates of computed
+// parameters during a single invocation.
+func computedFuncs(claimsMap map[string]map[string]any) template.FuncMap {
+	now := time.Now().UTC()
+	var requestID string
+	return template.FuncMap{
+		"now": func() string {
+			return now.Format(time.RFC3339Nano)
+		},
+		"today": func() string {
+			return now.Format(time.DateOnly)
+		},
+		"requestId": func() string {
+			if requestID == "" {
+				requestID = uuid.NewString()
+			}
+			return requestID
+		},
+		"claim": func(service, field string) (any, error) {
+			return parseFromAuthService([]ParamAuthService{{Name: service, Field: field}}, claimsMap)
+		},
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"trim":  strings.TrimSpace,
+		"domain": func(email string) string {
+			return email[strings.LastIndex(email, "@")+1:]
+		},
+		"add": arithmetic(func(a, b int) (int, error) { return a + b, nil }, func(a, b float64) float64 { return a + b }),
+		"sub": arithmetic(func(a, b int) (int, error) { return a - b, nil }, func(a, b float64) float64 { return a - b }),
+		"mul": arithmetic(func(a, b int) (int, error) { return a * b, nil }, func(a, b float64) float64 { return a * b }),
+		"div": arithmetic(func(a, b int) (int, error) {
+			if b == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return a / b, nil
+		}, func(a, b float64) float64 { return a / b }),
+	}
+}
+
+// arithmetic returns a template function that applies an operator to two
+// numbers. The result is an integer if both numbers are integers.
+func arithmetic(intOp func(a, b int) (int, error), floatOp func(a, b float64) float64) func(a, b any) (any, error) {
+	return func(a, b any) (any, error) {
+		ai, aIsInt := a.(int)
+		bi, bIsInt := b.(int)
+		if aIsInt && bIsInt {
+			return intOp(ai, bi)
+		}
+		af, err := toFloat(a)
+		if err != nil {
+			return nil, err
+		}
+		bf, err := toFloat(b)
+		if err != nil {
+			return nil, err
+		}
+		return floatOp(af, bf), nil
+	}
+}
+
+func toFloat(v any) (float64, error) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	}
+	return 0, fmt.Errorf("%v is not a number", v)
+}
+
+// checkComputed verifies that the template of a computed parameter parses.
+func checkComputed(p Parameter) error {
+	if len(p.GetAuthServices()) != 0 {
+		return fmt.Errorf("computed parameter %q should not have auth services", p.GetName())
+	}
+	if _, err := template.New(p.GetName()).Funcs(computedFuncs(nil)).Parse(p.GetComputed()); err != nil {
+		return fmt.Errorf("unable to parse computed value of %q: %w", p.GetName(), err)
+	}
+	return nil
+}
+
+// computeParams sets the values of the computed parameters in ps, in order.
+// Their templates are given the values of the other parameters, including
+// computed parameters listed before them.
+func computeParams(ps Parameters, values ParamValues, claimsMap map[string]map[string]any) error {
+	data := make(map[string]any, len(values))
+	for i, p := range ps {
+		if p.GetComputed() == "" {
+			data[p.GetName()] = values[i].Value
+		}
+	}
+	funcs := computedFuncs(claimsMap)
+	for i, p := range ps {
+		if p.GetComputed() == "" {
+			continue
+		}
+		name := p.GetName()
+		t, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(p.GetComputed())
+		if err != nil {
+			return fmt.Errorf("unable to parse computed value of %q: %w", name, err)
+		}
+		var out bytes.Buffer
+		if err := t.Execute(&out, data); err != nil {
+			return fmt.Errorf("unable to compute value of %q: %w", name, err)
+		}
+		v, err := computedValue(p, out.String())
+		if err != nil {
+			return fmt.Errorf("unable to parse computed value of %q: %w", name, err)
+		}
+		values[i].Value = v
+		data[name] = v
+	}
+	return nil
+}
+
+// computedValue parses the output of a computed parameter's template as a
+// value of the parameter's type. Values of types other than strings are
+// written as JSON.
+func computedValue(p Parameter, s string) (any, error) {
+	var v any = s
+	switch p.GetType() {
+	case TypeString, TypeDate, TypeDateTime, TypeUUID, TypeDecimal, TypeIdentifier, TypeKeyword:
+	default:
+		if err := util.DecodeJSON(strings.NewReader(s), &v); err != nil {
+			return nil, fmt.Errorf("%q is not a valid %s", s, p.GetType())
+		}
+	}
+	return p.Parse(v)
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+func computed(p parameters.Parameter, tmpl string) parameters.Parameter {
+	switch p := p.(type) {
+	case *parameters.StringParameter:
+		p.Computed = tmpl
+	case *parameters.IntParameter:
+		p.Computed = tmpl
+	case *parameters.FloatParameter:
+		p.Computed = tmpl
+	case *parameters.BooleanParameter:
+		p.Computed = tmpl
+	case *parameters.UUIDParameter:
+		p.Computed = tmpl
+	}
+	return p
+}
+
+func TestComputedParams(t *testing.T) {
+	claims := map[string]map[string]any{
+		"my-google-auth": {"email": "Jane.Doe@Example.com"},
+	}
+	tcs := []struct {
+		name   string
+		params parameters.Parameters
+		in     map[string]any
+		want   parameters.ParamValues
+	}{
+		{
+			name: "expression over other parameters",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithDefault("page", 1, "page"),
+				parameters.NewIntParameter("pageSize", "page size"),
+				computed(parameters.NewIntParameter("offset", "offset"), "{{ mul (sub .page 1) .pageSize }}"),
+			},
+			in: map[string]any{"page": 3, "pageSize": 20},
+			want: parameters.ParamValues{
+				{Name: "page", Value: 3},
+				{Name: "pageSize", Value: 20},
+				{Name: "offset", Value: 40},
+			},
+		},
+		{
+			name: "computed before the parameters it uses",
+			params: parameters.Parameters{
+				computed(parameters.NewFloatParameter("total", "total"), "{{ mul .price 1.5 }}"),
+				parameters.NewFloatParameter("price", "price"),
+			},
+			in: map[string]any{"price": 2.0},
+			want: parameters.ParamValues{
+				{Name: "total", Value: 3.0},
+				{Name: "price", Value: 2.0},
+			},
+		},
+		{
+			name: "transformed claim",
+			params: parameters.Parameters{
+				computed(parameters.NewStringParameter("email", "email"), `{{ claim "my-google-auth" "email" | lower }}`),
+				computed(parameters.NewStringParameter("domain", "domain"), "{{ domain .email }}"),
+			},
+			in: map[string]any{},
+			want: parameters.ParamValues{
+				{Name: "email", Value: "jane.doe@example.com"},
+				{Name: "domain", Value: "example.com"},
+			},
+		},
+		{
+			name: "client value is ignored",
+			params: parameters.Parameters{
+				computed(parameters.NewBooleanParameter("internal", "internal"), "true"),
+			},
+			in:   map[string]any{"internal": false},
+			want: parameters.ParamValues{{Name: "internal", Value: true}},
+		},
+		{
+			name: "unknown parameter",
+			params: parameters.Parameters{
+				computed(parameters.NewStringParameter("s", "s"), "{{ .missing }}"),
+			},
+			in: map[string]any{},
+		},
+		{
+			name: "value of the wrong type",
+			params: parameters.Parameters{
+				computed(parameters.NewIntParameter("n", "n"), "{{ lower \"ABC\" }}"),
+			},
+			in: map[string]any{},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parameters.ParseParams(tc.params, tc.in, claims)
+			if tc.want == nil {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect values (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestComputedRequestID(t *testing.T) {
+	params := parameters.Parameters{
+		computed(parameters.NewUUIDParameter("id", "id"), "{{ requestId }}"),
+		computed(parameters.NewStringParameter("same_id", "same id"), "{{ requestId }}"),
+		computed(parameters.NewStringParameter("today", "today"), "{{ today }}"),
+	}
+	first, err := parameters.ParseParams(params, map[string]any{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := uuid.Parse(string(first[0].Value.(parameters.UUID))); err != nil {
+		t.Fatalf("request ID is not a UUID: %s", err)
+	}
+	if string(first[0].Value.(parameters.UUID)) != first[1].Value {
+		t.Fatalf("request ID changed within an invocation: %v", first)
+	}
+	second, err := parameters.ParseParams(params, map[string]any{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if first[0].Value == second[0].Value {
+		t.Fatalf("request ID is the same for two invocations: %v", first[0].Value)
+	}
+}
+
+func TestComputedMissingClaim(t *testing.T) {
+	params := parameters.Parameters{
+		computed(parameters.NewStringParameter("email", "email"), `{{ claim "my-google-auth" "email" }}`),
+	}
+	_, err := parameters.ParseParams(params, map[string]any{}, map[string]map[string]any{})
+	if !errors.Is(err, util.ErrUnauthorized) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestComputedManifest(t *testing.T) {
+	params := parameters.Parameters{
+		parameters.NewIntParameter("page", "page"),
+		computed(parameters.NewIntParameter("offset", "offset"), "{{ mul .page 10 }}"),
+	}
+	manifest := params.Manifest()
+	if len(manifest) != 1 || manifest[0].Name != "page" {
+		t.Fatalf("computed parameter is listed in the manifest: %v", manifest)
+	}
+	schema, _ := params.McpManifest()
+	if _, ok := schema.Properties["offset"]; ok {
+		t.Fatalf("computed parameter is listed in the MCP manifest: %v", schema)
+	}
+	if diff := cmp.Diff([]string{"page"}, schema.Required); diff != "" {
+		t.Fatalf("incorrect required parameters (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+)
+
+// EmbeddingText is the value of a string parameter with `embeddedBy` set
+// until EmbedParams replaces it with the vector of Text.
+type EmbeddingText struct {
+	Model string
+	Text  string
+}
+
+// String returns the text, e.g. for the templates of computed parameters.
+func (e EmbeddingText) String() string {
+	return e.Text
+}
+
+// EmbeddingModelNames returns the names of the embedding models used by the
+// parameters, including by the items and properties of their values.
+func EmbeddingModelNames(ps Parameters) []string {
+	var names []string
+	var visit func(p Parameter)
+	visit = func(p Parameter) {
+		switch p := p.(type) {
+		case *StringParameter:
+			if p.EmbeddedBy != "" && !slices.Contains(names, p.EmbeddedBy) {
+				names = append(names, p.EmbeddedBy)
+			}
+		case *ArrayParameter:
+			visit(p.Items)
+		case *ObjectParameter:
+			for _, prop := range p.Properties {
+				visit(prop)
+			}
+		}
+	}
+	for _, p := range ps {
+		visit(p)
+	}
+	return names
+}
+
+// EmbedParams replaces the texts of parameters with `embeddedBy` set by their
+// vectors, which are bound as arrays of floats. The distinct texts of each
+// model are embedded with a single request.
+func EmbedParams(ctx context.Context, params ParamValues, models map[string]embeddingmodels.EmbeddingModel) (ParamValues, error) {
+	texts := make(map[string][]string)
+	var collect func(v any)
+	collect = func(v any) {
+		switch v := v.(type) {
+		case EmbeddingText:
+			if !slices.Contains(texts[v.Model], v.Text) {
+				texts[v.Model] = append(texts[v.Model], v.Text)
+			}
+		case []any:
+			for _, item := range v {
+				collect(item)
+			}
+		case map[string]any:
+			for _, prop := range v {
+				collect(prop)
+			}
+		}
+	}
+	for _, p := range params {
+		collect(p.Value)
+	}
+	if len(texts) == 0 {
+		return params, nil
+	}
+
+	vectors := make(map[string]map[string][]float64, len(texts))
+	for name, modelTexts := range texts {
+		m, ok := models[name]
+		if !ok {
+			return nil, fmt.Errorf("unable to retrieve embedding model %q", name)
+		}
+		vs, err := m.Embed(ctx, modelTexts)
+		if err != nil {
+			return nil, err
+		}
+		vectors[name] = make(map[string][]float64, len(modelTexts))
+		for i, text := range modelTexts {
+			vectors[name][text] = vs[i]
+		}
+	}
+
+	var replace func(v any) any
+	replace = func(v any) any {
+		switch v := v.(type) {
+		case EmbeddingText:
+			return vectors[v.Model][v.Text]
+		case []any:
+			out := make([]any, len(v))
+			for i, item := range v {
+				out[i] = replace(item)
+			}
+			return out
+		case map[string]any:
+			out := make(map[string]any, len(v))
+			for k, prop := range v {
+				out[k] = replace(prop)
+			}
+			return out
+		}
+		return v
+	}
+	embedded := make(ParamValues, len(params))
+	for i, p := range params {
+		embedded[i] = ParamValue{Name: p.Name, Value: replace(p.Value), Sensitive: p.Sensitive}
+	}
+	return embedded, nil
+}
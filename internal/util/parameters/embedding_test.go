@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// fakeEmbeddingModel embeds a text as its length, and records the texts of
+// each call.
+type fakeEmbeddingModel struct {
+	calls *[][]string
+}
+
+func (m fakeEmbeddingModel) EmbeddingModelKind() string {
+	return "fake"
+}
+
+func (m fakeEmbeddingModel) ToConfig() embeddingmodels.EmbeddingModelConfig {
+	return nil
+}
+
+func (m fakeEmbeddingModel) Embed(_ context.Context, texts []string) ([][]float64, error) {
+	*m.calls = append(*m.calls, texts)
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1}
+	}
+	return vectors, nil
+}
+
+func TestEmbedParams(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: query
+  type: string
+  description: text to search for
+  embeddedBy: my-embeddings
+- name: limit
+  type: integer
+  description: number of results
+- name: query_vector
+  type: string
+  description: vector of the query
+  computed: "{{ .query }}"
+  embeddedBy: my-embeddings
+- name: documents
+  type: array
+  description: documents to insert
+  items:
+    name: document
+    type: string
+    description: a document
+    embeddedBy: my-embeddings
+`
+	var params parameters.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff([]string{"my-embeddings"}, parameters.EmbeddingModelNames(params)); diff != "" {
+		t.Fatalf("incorrect embedding models (-want +got):\n%s", diff)
+	}
+
+	values, err := parameters.ParseParams(params, map[string]any{"query": "dog", "limit": 5, "documents": []any{"a cat", "dog"}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(parameters.EmbeddingText{Model: "my-embeddings", Text: "dog"}, values[0].Value); diff != "" {
+		t.Fatalf("incorrect parsed value (-want +got):\n%s", diff)
+	}
+
+	var calls [][]string
+	models := map[string]embeddingmodels.EmbeddingModel{"my-embeddings": fakeEmbeddingModel{calls: &calls}}
+	got, err := parameters.EmbedParams(ctx, values, models)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := parameters.ParamValues{
+		{Name: "query", Value: []float64{3, 1}},
+		{Name: "limit", Value: 5},
+		{Name: "query_vector", Value: []float64{3, 1}},
+		{Name: "documents", Value: []any{[]float64{5, 1}, []float64{3, 1}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect values (-want +got):\n%s", diff)
+	}
+	// the distinct texts are embedded with a single request
+	if diff := cmp.Diff([][]string{{"dog", "a cat"}}, calls); diff != "" {
+		t.Fatalf("incorrect embedding requests (-want +got):\n%s", diff)
+	}
+
+	_, err = parameters.EmbedParams(ctx, values, nil)
+	if err == nil || !strings.Contains(err.Error(), `unable to retrieve embedding model "my-embeddings"`) {
+		t.Fatalf("expected an error for a missing model, got %v", err)
+	}
+}
+
+func TestFailEmbeddedByUnmarshal(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: query
+  type: string
+  description: text to search for
+  embeddedBy: my-embeddings
+  escape: double-quotes
+`
+	var params parameters.Parameters
+	err = yaml.UnmarshalContext(ctx, []byte(in), &params)
+	if err == nil || !strings.Contains(err.Error(), "`embeddedBy` and `escape` are mutually exclusive") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
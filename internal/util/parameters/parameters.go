@@ -19,12 +19,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
@@ -35,6 +41,17 @@ const (
 	TypeBool   = "boolean"
 	TypeArray  = "array"
 	TypeMap    = "map"
+	TypeObject = "object"
+
+	TypeDate     = "date"
+	TypeDateTime = "datetime"
+	TypeUUID     = "uuid"
+	TypeDecimal  = "decimal"
+
+	// types of template parameters that are safe to substitute into statements
+	TypeIdentifier     = "identifier"
+	TypeIdentifierList = "identifierList"
+	TypeKeyword        = "keyword"
 )
 
 // delimiters for string parameter escaping
@@ -45,6 +62,24 @@ const (
 	escapeSquareBrackets = "square-brackets"
 )
 
+// Identifier quotes of SQL dialects, used to quote the values of identifier
+// template parameters. QuoteNone leaves them unquoted, for templates that
+// aren't SQL.
+//
+// Quoting an identifier makes it case-sensitive in dialects that fold unquoted
+// identifiers to one case. QuoteDoubleQuotesLower and QuoteDoubleQuotesUpper
+// fold identifiers the same way before quoting them, so that e.g. "users"
+// names the same table as users does when written unquoted, in PostgreSQL and
+// Oracle alike.
+const (
+	QuoteNone              = ""
+	QuoteDoubleQuotes      = escapeDoubleQuotes
+	QuoteDoubleQuotesLower = "double-quotes-lower"
+	QuoteDoubleQuotesUpper = "double-quotes-upper"
+	QuoteBackticks         = escapeBackticks
+	QuoteSquareBrackets    = escapeSquareBrackets
+)
+
 // ParamValues is an ordered list of ParamValue
 type ParamValues []ParamValue
 
@@ -52,6 +87,17 @@ type ParamValues []ParamValue
 type ParamValue struct {
 	Name  string
 	Value any
+	// Sensitive values are redacted when formatted, e.g. in logs.
+	Sensitive bool
+}
+
+// String formats the parameter like fmt's default for structs, with
+// sensitive values redacted.
+func (p ParamValue) String() string {
+	if p.Sensitive {
+		return fmt.Sprintf("{%s %s}", p.Name, Redacted)
+	}
+	return fmt.Sprintf("{%s %v}", p.Name, p.Value)
 }
 
 // AsSlice returns a slice of the Param's values (in order).
@@ -128,15 +174,27 @@ func CheckParamRequired(required bool, defaultV any) bool {
 // ParseParams is a helper function for parsing Parameters from an arbitraryJSON object.
 func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[string]any) (ParamValues, error) {
 	params := make([]ParamValue, 0, len(ps))
+	hasComputed := false
 	for _, p := range ps {
 		var v, newV any
 		var err error
 		paramAuthServices := p.GetAuthServices()
 		name := p.GetName()
+		if p.GetComputed() != "" {
+			// computed once the values of the other parameters are known
+			hasComputed = true
+			params = append(params, ParamValue{Name: name, Sensitive: p.GetSensitive()})
+			continue
+		}
 		if len(paramAuthServices) == 0 {
 			// parse non auth-required parameter
 			var ok bool
 			v, ok = data[name]
+			// null is only a value of nullable parameters, and is otherwise
+			// the same as leaving the parameter out
+			if ok && v == nil && !p.GetNullable() {
+				ok = false
+			}
 			if !ok {
 				v = p.GetDefault()
 				// if the parameter is required and no value given, throw an error
@@ -154,14 +212,43 @@ func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[st
 		if v != nil {
 			newV, err = p.Parse(v)
 			if err != nil {
+				if p.GetSensitive() {
+					err = redactError(err, v)
+				}
 				return nil, fmt.Errorf("unable to parse value for %q: %w", name, err)
 			}
+		} else if p.GetNullable() {
+			newV = nullOf(p)
+		}
+		params = append(params, ParamValue{Name: name, Value: newV, Sensitive: p.GetSensitive()})
+	}
+	if hasComputed {
+		if err := computeParams(ps, params, claimsMap); err != nil {
+			return nil, ParamValues(params).RedactError(err)
 		}
-		params = append(params, ParamValue{Name: name, Value: newV})
 	}
 	return params, nil
 }
 
+// nullOf returns a nil pointer to the type of a parameter's values, so that
+// drivers bind a NULL of that type. Arrays, maps and objects have no single
+// type that every driver binds as a NULL, so they're left as an untyped nil.
+func nullOf(p Parameter) any {
+	switch p.GetType() {
+	case TypeString, TypeUUID, TypeDecimal:
+		return (*string)(nil)
+	case TypeInt:
+		return (*int)(nil)
+	case TypeFloat:
+		return (*float64)(nil)
+	case TypeBool:
+		return (*bool)(nil)
+	case TypeDate, TypeDateTime:
+		return (*time.Time)(nil)
+	}
+	return nil
+}
+
 // helper function to convert a string array parameter to a comma separated string
 func ConvertArrayParamToString(param any) (string, error) {
 	switch v := param.(type) {
@@ -194,12 +281,21 @@ func GetParams(params Parameters, paramValuesMap map[string]any) (ParamValues, e
 	return resultParamValues, nil
 }
 
-func ResolveTemplateParams(templateParams Parameters, originalStatement string, paramsMap map[string]any) (string, error) {
+// ResolveTemplateParams substitutes the values of template parameters into a
+// statement. Identifiers are quoted with quote, one of the Quote constants.
+func ResolveTemplateParams(templateParams Parameters, originalStatement string, paramsMap map[string]any, quote string) (string, error) {
 	templateParamsValues, err := GetParams(templateParams, paramsMap)
-	templateParamsMap := templateParamsValues.AsMap()
 	if err != nil {
 		return "", fmt.Errorf("error getting template params %s", err)
 	}
+	templateParamsMap := make(map[string]any, len(templateParamsValues))
+	for _, v := range templateParamsValues {
+		quoted, err := quoteIdentifiers(quote, v.Value)
+		if err != nil {
+			return "", fmt.Errorf("unable to substitute template parameter %q: %w", v.Name, err)
+		}
+		templateParamsMap[v.Name] = quoted
+	}
 
 	funcMap := template.FuncMap{
 		"array": ConvertArrayParamToString,
@@ -218,9 +314,71 @@ func ResolveTemplateParams(templateParams Parameters, originalStatement string,
 	return modifiedStatement, nil
 }
 
+// TemplateParamNames returns the names of the template parameters referenced
+// by a statement, e.g. "table" for `SELECT * FROM {{.table}}`, in order of
+// first use.
+func TemplateParamNames(statement string) ([]string, error) {
+	funcMap := template.FuncMap{
+		"array": ConvertArrayParamToString,
+	}
+	t, err := template.New("statement").Funcs(funcMap).Parse(statement)
+	if err != nil {
+		return nil, fmt.Errorf("error creating go template %s", err)
+	}
+	var names []string
+	var walk func(parse.Node)
+	walk = func(n parse.Node) {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Nodes {
+				walk(c)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Cmds {
+				walk(c)
+			}
+		case *parse.CommandNode:
+			for _, a := range n.Args {
+				walk(a)
+			}
+		case *parse.FieldNode:
+			if !slices.Contains(names, n.Ident[0]) {
+				names = append(names, n.Ident[0])
+			}
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+	walk(t.Root)
+	return names, nil
+}
+
 // ProcessParameters concatenate templateParameters and parameters from a tool.
 // It returns a list of concatenated parameters, concatenated Toolbox manifest, and concatenated MCP Manifest.
 func ProcessParameters(templateParams Parameters, params Parameters) (Parameters, []ParameterManifest, error) {
+	for _, p := range templateParams {
+		if err := checkTemplateParam(p); err != nil {
+			return nil, nil, err
+		}
+	}
 	allParameters := slices.Concat(params, templateParams)
 
 	// verify no duplicate parameter names
@@ -245,6 +403,11 @@ type Parameter interface {
 	GetType() string
 	GetDefault() any
 	GetRequired() bool
+	GetNullable() bool
+	GetComputed() string
+	GetUnsafe() bool
+	GetSensitive() bool
+	GetTransforms() []Transform
 	GetAuthServices() []ParamAuthService
 	Parse(any) (any, error)
 	Manifest() ParameterManifest
@@ -272,6 +435,11 @@ func (c *Parameters) UnmarshalYAML(ctx context.Context, unmarshal func(interface
 		if err != nil {
 			return err
 		}
+		if p.GetComputed() != "" {
+			if err := checkComputed(p); err != nil {
+				return err
+			}
+		}
 		(*c) = append((*c), p)
 	}
 	return nil
@@ -291,7 +459,36 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 		return nil, fmt.Errorf("parameter is missing 'type' field")
 	}
 
-	return ParseParameter(ctx, p, t.(string))
+	param, err := ParseParameter(ctx, p, t.(string))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTransforms(param); err != nil {
+		return nil, fmt.Errorf("unable to parse as %q: %w", param.GetType(), err)
+	}
+	return param, nil
+}
+
+// Types returns an empty parameter of each type, by type.
+func Types() map[string]Parameter {
+	return map[string]Parameter{
+		TypeString: &StringParameter{},
+		TypeInt:    &IntParameter{},
+		TypeFloat:  &FloatParameter{},
+		TypeBool:   &BooleanParameter{},
+		TypeArray:  &ArrayParameter{},
+		TypeMap:    &MapParameter{},
+		TypeObject: &ObjectParameter{},
+
+		TypeDate:     &DateParameter{},
+		TypeDateTime: &DateTimeParameter{},
+		TypeUUID:     &UUIDParameter{},
+		TypeDecimal:  &DecimalParameter{},
+
+		TypeIdentifier:     &IdentifierParameter{},
+		TypeIdentifierList: &IdentifierListParameter{},
+		TypeKeyword:        &KeywordParameter{},
+	}
 }
 
 // ParseParameter parses a raw map into a Parameter object based on its "type" field.
@@ -310,6 +507,14 @@ func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Pa
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
 		}
+		if a.Pattern != nil {
+			if _, err := compilePattern(*a.Pattern); err != nil {
+				return nil, fmt.Errorf("unable to parse as %q: invalid pattern: %w", paramType, err)
+			}
+		}
+		if a.EmbeddedBy != "" && a.Escape != nil {
+			return nil, fmt.Errorf("unable to parse as %q: `embeddedBy` and `escape` are mutually exclusive", paramType)
+		}
 		if a.AuthSources != nil {
 			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
 			a.AuthServices = append(a.AuthServices, a.AuthSources...)
@@ -371,14 +576,148 @@ func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Pa
 			a.AuthSources = nil
 		}
 		return a, nil
+	case TypeDate:
+		a := &DateParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case TypeDateTime:
+		a := &DateTimeParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case TypeUUID:
+		a := &UUIDParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case TypeDecimal:
+		a := &DecimalParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case TypeIdentifier:
+		a := &IdentifierParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case TypeIdentifierList:
+		a := &IdentifierListParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case TypeKeyword:
+		a := &KeywordParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if len(a.AllowedValues) == 0 {
+			return nil, fmt.Errorf("unable to parse as %q: 'allowedValues' must not be empty", paramType)
+		}
+		for _, v := range a.AllowedValues {
+			if s, ok := v.(string); !ok || !keywordRegexp.MatchString(s) {
+				return nil, fmt.Errorf("unable to parse as %q: %v is not a keyword", paramType, v)
+			}
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case TypeObject:
+		a := &ObjectParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if len(a.Properties) == 0 {
+			return nil, fmt.Errorf("unable to parse as %q: 'properties' must not be empty", paramType)
+		}
+		if err := CheckDuplicateParameters(a.Properties); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		for _, prop := range a.Properties {
+			if len(prop.GetAuthServices()) != 0 {
+				return nil, fmt.Errorf("unable to parse as %q: nested properties should not have auth services", paramType)
+			}
+			if prop.GetComputed() != "" {
+				return nil, fmt.Errorf("unable to parse as %q: nested properties should not be computed", paramType)
+			}
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
 	}
 	return nil, fmt.Errorf("%q is not valid type for a parameter", paramType)
 }
 
+// Names returns the names of the parameters, in order.
+func (ps Parameters) Names() []string {
+	names := make([]string, 0, len(ps))
+	for _, p := range ps {
+		names = append(names, p.GetName())
+	}
+	return names
+}
+
 func (ps Parameters) Manifest() []ParameterManifest {
 	rtn := make([]ParameterManifest, 0, len(ps))
 	for _, p := range ps {
-		rtn = append(rtn, p.Manifest())
+		// computed parameters aren't given by clients
+		if p.GetComputed() != "" {
+			continue
+		}
+		m := p.Manifest()
+		m.Nullable = p.GetNullable()
+		m.Sensitive = p.GetSensitive()
+		if m.Sensitive {
+			// the default and allowed values of sensitive parameters are
+			// secrets too
+			m.Default, m.Enum = nil, nil
+			if m.Items != nil {
+				m.Items.Default, m.Items.Enum = nil, nil
+			}
+		}
+		rtn = append(rtn, m)
 	}
 	return rtn
 }
@@ -389,8 +728,24 @@ func (ps Parameters) McpManifest() (McpToolsSchema, map[string][]string) {
 	authParam := make(map[string][]string)
 
 	for _, p := range ps {
+		if p.GetComputed() != "" {
+			continue
+		}
 		name := p.GetName()
 		paramManifest, authParamList := p.McpManifest()
+		if p.GetSensitive() {
+			paramManifest.Default, paramManifest.Enum = nil, nil
+			if paramManifest.Items != nil {
+				paramManifest.Items.Default, paramManifest.Items.Enum = nil, nil
+			}
+		}
+		if p.GetNullable() {
+			// JSON Schema lists null as one of the types of the value
+			paramManifest.Type = []any{paramManifest.Type, "null"}
+			if paramManifest.Enum != nil {
+				paramManifest.Enum = append(slices.Clip(paramManifest.Enum), nil)
+			}
+		}
 		properties[name] = paramManifest
 		// parameters that doesn't have a default value are added to the required field
 		if CheckParamRequired(p.GetRequired(), p.GetDefault()) {
@@ -408,22 +763,50 @@ func (ps Parameters) McpManifest() (McpToolsSchema, map[string][]string) {
 }
 
 // ParameterManifest represents parameters when served as part of a ToolManifest.
+// Constraints use the names of JSON Schema keywords.
 type ParameterManifest struct {
-	Name                 string             `json:"name"`
-	Type                 string             `json:"type"`
-	Required             bool               `json:"required"`
-	Description          string             `json:"description"`
-	AuthServices         []string           `json:"authSources"`
-	Items                *ParameterManifest `json:"items,omitempty"`
-	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Name                 string              `json:"name"`
+	Type                 string              `json:"type"`
+	Required             bool                `json:"required"`
+	Nullable             bool                `json:"nullable,omitempty"`
+	Sensitive            bool                `json:"sensitive,omitempty"`
+	Description          string              `json:"description"`
+	AuthServices         []string            `json:"authSources"`
+	Items                *ParameterManifest  `json:"items,omitempty"`
+	Properties           []ParameterManifest `json:"properties,omitempty"`
+	AdditionalProperties any                 `json:"additionalProperties,omitempty"`
+	Default              any                 `json:"default,omitempty"`
+	Enum                 []any               `json:"enum,omitempty"`
+	Minimum              any                 `json:"minimum,omitempty"`
+	Maximum              any                 `json:"maximum,omitempty"`
+	Pattern              string              `json:"pattern,omitempty"`
+	Format               string              `json:"format,omitempty"`
+	MinLength            *int                `json:"minLength,omitempty"`
+	MaxLength            *int                `json:"maxLength,omitempty"`
+	MinItems             *int                `json:"minItems,omitempty"`
+	MaxItems             *int                `json:"maxItems,omitempty"`
 }
 
 // ParameterMcpManifest represents properties when served as part of a ToolMcpManifest.
+// Type is the name of a JSON Schema type, or a list of names for nullable
+// parameters.
 type ParameterMcpManifest struct {
-	Type                 string                `json:"type"`
-	Description          string                `json:"description"`
-	Items                *ParameterMcpManifest `json:"items,omitempty"`
-	AdditionalProperties any                   `json:"additionalProperties,omitempty"`
+	Type                 any                             `json:"type"`
+	Description          string                          `json:"description"`
+	Items                *ParameterMcpManifest           `json:"items,omitempty"`
+	Properties           map[string]ParameterMcpManifest `json:"properties,omitempty"`
+	Required             []string                        `json:"required,omitempty"`
+	AdditionalProperties any                             `json:"additionalProperties,omitempty"`
+	Default              any                             `json:"default,omitempty"`
+	Enum                 []any                           `json:"enum,omitempty"`
+	Minimum              any                             `json:"minimum,omitempty"`
+	Maximum              any                             `json:"maximum,omitempty"`
+	Pattern              string                          `json:"pattern,omitempty"`
+	Format               string                          `json:"format,omitempty"`
+	MinLength            *int                            `json:"minLength,omitempty"`
+	MaxLength            *int                            `json:"maxLength,omitempty"`
+	MinItems             *int                            `json:"minItems,omitempty"`
+	MaxItems             *int                            `json:"maxItems,omitempty"`
 }
 
 // CommonParameter are default fields that are emebdding in most Parameter implementations. Embedding this stuct will give the object Name() and Type() functions.
@@ -432,6 +815,11 @@ type CommonParameter struct {
 	Type           string             `yaml:"type" validate:"required"`
 	Desc           string             `yaml:"description" validate:"required"`
 	Required       *bool              `yaml:"required"`
+	Nullable       bool               `yaml:"nullable"`
+	Computed       string             `yaml:"computed"`
+	Unsafe         bool               `yaml:"unsafe"`
+	Sensitive      bool               `yaml:"sensitive"`
+	Transforms     []Transform        `yaml:"transforms"`
 	AllowedValues  []any              `yaml:"allowedValues"`
 	ExcludedValues []any              `yaml:"excludedValues"`
 	AuthServices   []ParamAuthService `yaml:"authServices"`
@@ -457,6 +845,34 @@ func (p *CommonParameter) GetRequired() bool {
 	return *p.Required
 }
 
+// GetNullable returns whether the Parameter accepts null as a value.
+func (p *CommonParameter) GetNullable() bool {
+	return p.Nullable
+}
+
+// GetComputed returns the template of a computed Parameter, or "" if the
+// Parameter is given by clients.
+func (p *CommonParameter) GetComputed() string {
+	return p.Computed
+}
+
+// GetUnsafe returns whether the Parameter may substitute free-form text into
+// a statement as a template parameter.
+func (p *CommonParameter) GetUnsafe() bool {
+	return p.Unsafe
+}
+
+// GetSensitive returns whether values of the Parameter must not appear in
+// logs, error messages or the web UI.
+func (p *CommonParameter) GetSensitive() bool {
+	return p.Sensitive
+}
+
+// GetTransforms returns the steps that normalize values of the Parameter.
+func (p *CommonParameter) GetTransforms() []Transform {
+	return p.Transforms
+}
+
 // GetAllowedValues returns the allowed values for the Parameter.
 func (p *CommonParameter) GetAllowedValues() []any {
 	return p.AllowedValues
@@ -493,6 +909,25 @@ func (p *CommonParameter) IsExcludedValues(v any) bool {
 	return false
 }
 
+// enum returns the allowed values as a JSON Schema enum. Allowed values that
+// are regular expressions can't be listed, so there's no enum if any is.
+func (p *CommonParameter) enum() []any {
+	for _, v := range p.AllowedValues {
+		if s, ok := v.(string); ok && regexp.QuoteMeta(s) != s {
+			return nil
+		}
+	}
+	return p.AllowedValues
+}
+
+// valueOf returns the value of an optional field, or nil if it isn't set.
+func valueOf[T any](v *T) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
 // MatchStringOrRegex checks if the input matches the target
 func MatchStringOrRegex(input, target any) bool {
 	targetS, ok := target.(string)
@@ -593,6 +1028,20 @@ func NewStringParameterWithRequired(name string, desc string, required bool) *St
 	}
 }
 
+// NewSensitiveStringParameter is a convenience function for initializing a StringParameter whose values are redacted, e.g. a password.
+func NewSensitiveStringParameter(name string, desc string, required bool) *StringParameter {
+	return &StringParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeString,
+			Desc:         desc,
+			Required:     &required,
+			Sensitive:    true,
+			AuthServices: nil,
+		},
+	}
+}
+
 // NewStringParameterWithAuth is a convenience function for initializing a StringParameter with a list of ParamAuthService.
 func NewStringParameterWithAuth(name string, desc string, authServices []ParamAuthService) *StringParameter {
 	return &StringParameter{
@@ -638,10 +1087,20 @@ type StringParameter struct {
 	CommonParameter `yaml:",inline"`
 	Default         *string `yaml:"default"`
 	Escape          *string `yaml:"escape"`
+	Pattern         *string `yaml:"pattern"`
+	MinLength       *int    `yaml:"minLength"`
+	MaxLength       *int    `yaml:"maxLength"`
+	// EmbeddedBy is the name of the embedding model that turns the value
+	// into a vector before the tool is invoked.
+	EmbeddedBy string `yaml:"embeddedBy"`
 }
 
 // Parse casts the value "v" as a "string".
 func (p *StringParameter) Parse(v any) (any, error) {
+	v, err := p.transform(v)
+	if err != nil {
+		return nil, err
+	}
 	newV, ok := v.(string)
 	if !ok {
 		return nil, &ParseTypeError{p.Name, p.Type, v}
@@ -652,9 +1111,28 @@ func (p *StringParameter) Parse(v any) (any, error) {
 	if p.IsExcludedValues(newV) {
 		return nil, fmt.Errorf("%s is an excluded value", newV)
 	}
+	// lengths are counted in characters, as in JSON Schema
+	if p.MinLength != nil && utf8.RuneCountInString(newV) < *p.MinLength {
+		return nil, fmt.Errorf("%s is shorter than the minimum length of %d", newV, *p.MinLength)
+	}
+	if p.MaxLength != nil && utf8.RuneCountInString(newV) > *p.MaxLength {
+		return nil, fmt.Errorf("%s is longer than the maximum length of %d", newV, *p.MaxLength)
+	}
+	if p.Pattern != nil {
+		re, err := compilePattern(*p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", *p.Pattern, err)
+		}
+		if !re.MatchString(newV) {
+			return nil, fmt.Errorf("%s does not match the pattern %q", newV, *p.Pattern)
+		}
+	}
 	if p.Escape != nil {
 		return applyEscape(*p.Escape, newV)
 	}
+	if p.EmbeddedBy != "" {
+		return EmbeddingText{Model: p.EmbeddedBy, Text: newV}, nil
+	}
 	return newV, nil
 }
 
@@ -695,7 +1173,33 @@ func (p *StringParameter) Manifest() ParameterManifest {
 		Required:     r,
 		Description:  p.Desc,
 		AuthServices: authServiceNames,
+		Default:      p.GetDefault(),
+		Enum:         p.enum(),
+		Pattern:      p.pattern(),
+		MinLength:    p.MinLength,
+		MaxLength:    p.MaxLength,
+	}
+}
+
+// McpManifest returns the MCP manifest for the StringParameter.
+func (p *StringParameter) McpManifest() (ParameterMcpManifest, []string) {
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	return ParameterMcpManifest{
+		Type:        p.Type,
+		Description: p.Desc,
+		Default:     p.GetDefault(),
+		Enum:        p.enum(),
+		Pattern:     p.pattern(),
+		MinLength:   p.MinLength,
+		MaxLength:   p.MaxLength,
+	}, authServiceNames
+}
+
+func (p *StringParameter) pattern() string {
+	if p.Pattern == nil {
+		return ""
 	}
+	return *p.Pattern
 }
 
 // NewIntParameter is a convenience function for initializing a IntParameter.
@@ -799,6 +1303,10 @@ type IntParameter struct {
 }
 
 func (p *IntParameter) Parse(v any) (any, error) {
+	v, err := p.transform(v)
+	if err != nil {
+		return nil, err
+	}
 	var out int
 	switch newV := v.(type) {
 	default:
@@ -853,9 +1361,26 @@ func (p *IntParameter) Manifest() ParameterManifest {
 		Required:     r,
 		Description:  p.Desc,
 		AuthServices: authServiceNames,
+		Default:      p.GetDefault(),
+		Enum:         p.enum(),
+		Minimum:      valueOf(p.MinValue),
+		Maximum:      valueOf(p.MaxValue),
 	}
 }
 
+// McpManifest returns the MCP manifest for the IntParameter.
+func (p *IntParameter) McpManifest() (ParameterMcpManifest, []string) {
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	return ParameterMcpManifest{
+		Type:        p.Type,
+		Description: p.Desc,
+		Default:     p.GetDefault(),
+		Enum:        p.enum(),
+		Minimum:     valueOf(p.MinValue),
+		Maximum:     valueOf(p.MaxValue),
+	}, authServiceNames
+}
+
 // NewFloatParameter is a convenience function for initializing a FloatParameter.
 func NewFloatParameter(name string, desc string) *FloatParameter {
 	return &FloatParameter{
@@ -957,6 +1482,10 @@ type FloatParameter struct {
 }
 
 func (p *FloatParameter) Parse(v any) (any, error) {
+	v, err := p.transform(v)
+	if err != nil {
+		return nil, err
+	}
 	var out float64
 	switch newV := v.(type) {
 	default:
@@ -1009,6 +1538,10 @@ func (p *FloatParameter) Manifest() ParameterManifest {
 		Required:     r,
 		Description:  p.Desc,
 		AuthServices: authServiceNames,
+		Default:      p.GetDefault(),
+		Enum:         p.enum(),
+		Minimum:      valueOf(p.MinValue),
+		Maximum:      valueOf(p.MaxValue),
 	}
 }
 
@@ -1019,6 +1552,10 @@ func (p *FloatParameter) McpManifest() (ParameterMcpManifest, []string) {
 	return ParameterMcpManifest{
 		Type:        "number",
 		Description: p.Desc,
+		Default:     p.GetDefault(),
+		Enum:        p.enum(),
+		Minimum:     valueOf(p.MinValue),
+		Maximum:     valueOf(p.MaxValue),
 	}, authServiceNames
 }
 
@@ -1142,9 +1679,22 @@ func (p *BooleanParameter) Manifest() ParameterManifest {
 		Required:     r,
 		Description:  p.Desc,
 		AuthServices: authServiceNames,
+		Default:      p.GetDefault(),
+		Enum:         p.enum(),
 	}
 }
 
+// McpManifest returns the MCP manifest for the BooleanParameter.
+func (p *BooleanParameter) McpManifest() (ParameterMcpManifest, []string) {
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	return ParameterMcpManifest{
+		Type:        p.Type,
+		Description: p.Desc,
+		Default:     p.GetDefault(),
+		Enum:        p.enum(),
+	}, authServiceNames
+}
+
 // NewArrayParameter is a convenience function for initializing a ArrayParameter.
 func NewArrayParameter(name string, desc string, items Parameter) *ArrayParameter {
 	return &ArrayParameter{
@@ -1234,6 +1784,8 @@ type ArrayParameter struct {
 	CommonParameter `yaml:",inline"`
 	Default         *[]any    `yaml:"default"`
 	Items           Parameter `yaml:"items"`
+	MinItems        *int      `yaml:"minItems"`
+	MaxItems        *int      `yaml:"maxItems"`
 }
 
 func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
@@ -1241,12 +1793,16 @@ func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 		CommonParameter `yaml:",inline"`
 		Default         *[]any                  `yaml:"default"`
 		Items           util.DelayedUnmarshaler `yaml:"items"`
+		MinItems        *int                    `yaml:"minItems"`
+		MaxItems        *int                    `yaml:"maxItems"`
 	}
 	if err := unmarshal(&rawItem); err != nil {
 		return err
 	}
 	p.CommonParameter = rawItem.CommonParameter
 	p.Default = rawItem.Default
+	p.MinItems = rawItem.MinItems
+	p.MaxItems = rawItem.MaxItems
 	i, err := parseParamFromDelayedUnmarshaler(ctx, &rawItem.Items)
 	if err != nil {
 		return fmt.Errorf("unable to parse 'items' field: %w", err)
@@ -1254,6 +1810,9 @@ func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 	if i.GetAuthServices() != nil && len(i.GetAuthServices()) != 0 {
 		return fmt.Errorf("nested items should not have auth services")
 	}
+	if i.GetComputed() != "" {
+		return fmt.Errorf("nested items should not be computed")
+	}
 	p.Items = i
 
 	return nil
@@ -1286,6 +1845,10 @@ func (p *ArrayParameter) IsExcludedValues(v []any) bool {
 }
 
 func (p *ArrayParameter) Parse(v any) (any, error) {
+	v, err := p.transform(v)
+	if err != nil {
+		return nil, err
+	}
 	arrVal, ok := v.([]any)
 	if !ok {
 		return nil, &ParseTypeError{p.Name, p.Type, arrVal}
@@ -1296,6 +1859,12 @@ func (p *ArrayParameter) Parse(v any) (any, error) {
 	if p.IsExcludedValues(arrVal) {
 		return nil, fmt.Errorf("%s is an excluded value", arrVal)
 	}
+	if p.MinItems != nil && len(arrVal) < *p.MinItems {
+		return nil, fmt.Errorf("%d items are fewer than the minimum of %d", len(arrVal), *p.MinItems)
+	}
+	if p.MaxItems != nil && len(arrVal) > *p.MaxItems {
+		return nil, fmt.Errorf("%d items are more than the maximum of %d", len(arrVal), *p.MaxItems)
+	}
 	rtn := make([]any, 0, len(arrVal))
 	for idx, val := range arrVal {
 		val, err := p.Items.Parse(val)
@@ -1337,6 +1906,10 @@ func (p *ArrayParameter) Manifest() ParameterManifest {
 		Description:  p.Desc,
 		AuthServices: authServiceNames,
 		Items:        &items,
+		Default:      p.GetDefault(),
+		Enum:         p.enum(),
+		MinItems:     p.MinItems,
+		MaxItems:     p.MaxItems,
 	}
 }
 
@@ -1349,6 +1922,10 @@ func (p *ArrayParameter) McpManifest() (ParameterMcpManifest, []string) {
 		Type:        p.Type,
 		Description: p.Desc,
 		Items:       &items,
+		Default:     p.GetDefault(),
+		Enum:        p.enum(),
+		MinItems:    p.MinItems,
+		MaxItems:    p.MaxItems,
 	}, authServiceNames
 }
 
@@ -1590,6 +2167,8 @@ func (p *MapParameter) Manifest() ParameterManifest {
 		Description:          p.Desc,
 		AuthServices:         authServiceNames,
 		AdditionalProperties: additionalProperties,
+		Default:              p.GetDefault(),
+		Enum:                 p.enum(),
 	}
 }
 
@@ -1613,5 +2192,1041 @@ func (p *MapParameter) McpManifest() (ParameterMcpManifest, []string) {
 		Type:                 "object",
 		Description:          p.Desc,
 		AdditionalProperties: additionalProperties,
+		Default:              p.GetDefault(),
+		Enum:                 p.enum(),
+	}, authServiceNames
+}
+
+// NewObjectParameter is a convenience function for initializing an ObjectParameter.
+func NewObjectParameter(name string, desc string, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeObject,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Properties: properties,
+	}
+}
+
+// NewObjectParameterWithDefault is a convenience function for initializing an ObjectParameter with default value.
+func NewObjectParameterWithDefault(name string, defaultV map[string]any, desc string, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeObject,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Properties: properties,
+		Default:    &defaultV,
+	}
+}
+
+// NewObjectParameterWithRequired is a convenience function for initializing an ObjectParameter.
+func NewObjectParameterWithRequired(name string, desc string, required bool, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeObject,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+		Properties: properties,
+	}
+}
+
+// NewObjectParameterWithAuth is a convenience function for initializing an ObjectParameter with a list of ParamAuthService.
+func NewObjectParameterWithAuth(name string, desc string, properties Parameters, authServices []ParamAuthService) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeObject,
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+		Properties: properties,
+	}
+}
+
+var _ Parameter = &ObjectParameter{}
+
+// ObjectParameter is a parameter representing an object with named, typed
+// properties. Unlike MapParameter, each property has its own type, and keys
+// that aren't listed in Properties are rejected.
+type ObjectParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *map[string]any `yaml:"default"`
+	Properties      Parameters      `yaml:"properties"`
+}
+
+func (p *ObjectParameter) IsAllowedValues(v map[string]any) bool {
+	a := p.GetAllowedValues()
+	if len(a) == 0 {
+		return true
+	}
+	for _, av := range a {
+		if reflect.DeepEqual(v, av) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ObjectParameter) IsExcludedValues(v map[string]any) bool {
+	a := p.GetExcludedValues()
+	if len(a) == 0 {
+		return false
+	}
+	for _, av := range a {
+		if reflect.DeepEqual(v, av) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse validates and parses an incoming value for the object parameter. Each
+// property is parsed by its own parameter, and missing properties are set to
+// their default value if they have one.
+func (p *ObjectParameter) Parse(v any) (any, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		if !slices.ContainsFunc(p.Properties, func(prop Parameter) bool { return prop.GetName() == k }) {
+			return nil, fmt.Errorf("unknown property %q", k)
+		}
+	}
+	rtn := make(map[string]any, len(p.Properties))
+	for _, prop := range p.Properties {
+		name := prop.GetName()
+		val, ok := m[name]
+		if ok && val == nil && !prop.GetNullable() {
+			ok = false
+		}
+		if !ok {
+			val = prop.GetDefault()
+			if CheckParamRequired(prop.GetRequired(), val) {
+				return nil, fmt.Errorf("property %q is required", name)
+			}
+			if val == nil {
+				continue
+			}
+		}
+		if val == nil {
+			// a null given for a nullable property is kept as a typed null
+			if prop.GetNullable() {
+				rtn[name] = nullOf(prop)
+			}
+			continue
+		}
+		val, err := prop.Parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse property %q: %w", name, err)
+		}
+		rtn[name] = val
+	}
+	if !p.IsAllowedValues(rtn) {
+		return nil, fmt.Errorf("%s is not an allowed value", rtn)
+	}
+	if p.IsExcludedValues(rtn) {
+		return nil, fmt.Errorf("%s is an excluded value", rtn)
+	}
+	return rtn, nil
+}
+
+func (p *ObjectParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *ObjectParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+func (p *ObjectParameter) GetProperties() Parameters {
+	return p.Properties
+}
+
+// Manifest returns the manifest for the ObjectParameter.
+func (p *ObjectParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:                 p.Name,
+		Type:                 p.Type,
+		Required:             r,
+		Description:          p.Desc,
+		AuthServices:         authServiceNames,
+		Properties:           p.Properties.Manifest(),
+		AdditionalProperties: false,
+		Default:              p.GetDefault(),
+		Enum:                 p.enum(),
+	}
+}
+
+// McpManifest returns the MCP manifest for the ObjectParameter.
+func (p *ObjectParameter) McpManifest() (ParameterMcpManifest, []string) {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	schema, _ := p.Properties.McpManifest()
+	return ParameterMcpManifest{
+		Type:                 p.Type,
+		Description:          p.Desc,
+		Properties:           schema.Properties,
+		Required:             schema.Required,
+		AdditionalProperties: false,
+		Default:              p.GetDefault(),
+		Enum:                 p.enum(),
+	}, authServiceNames
+}
+
+// Date is the value of a "date" parameter, formatted as YYYY-MM-DD.
+type Date string
+
+// Time returns the date as midnight UTC.
+func (d Date) Time() (time.Time, error) {
+	return time.Parse(time.DateOnly, string(d))
+}
+
+// DateTime is the value of a "datetime" parameter, formatted as RFC 3339.
+type DateTime string
+
+// Time returns the point in time, in the time zone it was given in.
+func (d DateTime) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, string(d))
+}
+
+// UUID is the value of a "uuid" parameter, in canonical lowercase form.
+type UUID string
+
+// Decimal is the value of a "decimal" parameter, as a decimal string.
+type Decimal string
+
+// decimalRegexp matches decimal numbers, with an optional exponent.
+var decimalRegexp = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+
+// NewDateParameter is a convenience function for initializing a DateParameter.
+func NewDateParameter(name string, desc string) *DateParameter {
+	return &DateParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDate,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewDateParameterWithDefault is a convenience function for initializing a DateParameter with default value.
+func NewDateParameterWithDefault(name string, defaultV, desc string) *DateParameter {
+	return &DateParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDate,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewDateParameterWithRequired is a convenience function for initializing a DateParameter.
+func NewDateParameterWithRequired(name string, desc string, required bool) *DateParameter {
+	return &DateParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDate,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+var _ Parameter = &DateParameter{}
+
+// DateParameter is a parameter representing the "date" type, a calendar date
+// formatted as YYYY-MM-DD. Its values are Dates.
+type DateParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+}
+
+func (p *DateParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	t, err := time.Parse(time.DateOnly, strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a date formatted as YYYY-MM-DD", s)
+	}
+	out := Date(t.Format(time.DateOnly))
+	if !p.IsAllowedValues(out) {
+		return nil, fmt.Errorf("%s is not an allowed value", out)
+	}
+	if p.IsExcludedValues(out) {
+		return nil, fmt.Errorf("%s is an excluded value", out)
+	}
+	return out, nil
+}
+
+func (p *DateParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *DateParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the DateParameter.
+func (p *DateParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+		Format:       "date",
+		Default:      p.GetDefault(),
+		Enum:         p.enum(),
+	}
+}
+
+// McpManifest returns the MCP manifest for the DateParameter.
+func (p *DateParameter) McpManifest() (ParameterMcpManifest, []string) {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	return ParameterMcpManifest{
+		Type:        "string",
+		Description: p.Desc,
+		Format:      "date",
+		Default:     p.GetDefault(),
+		Enum:        p.enum(),
+	}, authServiceNames
+}
+
+// NewDateTimeParameter is a convenience function for initializing a DateTimeParameter.
+func NewDateTimeParameter(name string, desc string) *DateTimeParameter {
+	return &DateTimeParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDateTime,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewDateTimeParameterWithDefault is a convenience function for initializing a DateTimeParameter with default value.
+func NewDateTimeParameterWithDefault(name string, defaultV, desc string) *DateTimeParameter {
+	return &DateTimeParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDateTime,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewDateTimeParameterWithRequired is a convenience function for initializing a DateTimeParameter.
+func NewDateTimeParameterWithRequired(name string, desc string, required bool) *DateTimeParameter {
+	return &DateTimeParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDateTime,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+var _ Parameter = &DateTimeParameter{}
+
+// DateTimeParameter is a parameter representing the "datetime" type, a point
+// in time formatted as RFC 3339. A time zone offset is required, so that values
+// don't depend on the time zone of the database. Its values are DateTimes.
+type DateTimeParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+}
+
+func (p *DateTimeParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a datetime formatted as RFC 3339, with a time zone offset", s)
+	}
+	out := DateTime(t.Format(time.RFC3339Nano))
+	if !p.IsAllowedValues(out) {
+		return nil, fmt.Errorf("%s is not an allowed value", out)
+	}
+	if p.IsExcludedValues(out) {
+		return nil, fmt.Errorf("%s is an excluded value", out)
+	}
+	return out, nil
+}
+
+func (p *DateTimeParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *DateTimeParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the DateTimeParameter.
+func (p *DateTimeParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+		Format:       "date-time",
+		Default:      p.GetDefault(),
+		Enum:         p.enum(),
+	}
+}
+
+// McpManifest returns the MCP manifest for the DateTimeParameter.
+func (p *DateTimeParameter) McpManifest() (ParameterMcpManifest, []string) {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	return ParameterMcpManifest{
+		Type:        "string",
+		Description: p.Desc,
+		Format:      "date-time",
+		Default:     p.GetDefault(),
+		Enum:        p.enum(),
+	}, authServiceNames
+}
+
+// NewUUIDParameter is a convenience function for initializing a UUIDParameter.
+func NewUUIDParameter(name string, desc string) *UUIDParameter {
+	return &UUIDParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeUUID,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewUUIDParameterWithDefault is a convenience function for initializing a UUIDParameter with default value.
+func NewUUIDParameterWithDefault(name string, defaultV, desc string) *UUIDParameter {
+	return &UUIDParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeUUID,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewUUIDParameterWithRequired is a convenience function for initializing a UUIDParameter.
+func NewUUIDParameterWithRequired(name string, desc string, required bool) *UUIDParameter {
+	return &UUIDParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeUUID,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+var _ Parameter = &UUIDParameter{}
+
+// UUIDParameter is a parameter representing the "uuid" type. Its values are
+// UUIDs in canonical form.
+type UUIDParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+}
+
+func (p *UUIDParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	u, err := uuid.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid UUID", s)
+	}
+	out := UUID(u.String())
+	if !p.IsAllowedValues(out) {
+		return nil, fmt.Errorf("%s is not an allowed value", out)
+	}
+	if p.IsExcludedValues(out) {
+		return nil, fmt.Errorf("%s is an excluded value", out)
+	}
+	return out, nil
+}
+
+func (p *UUIDParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *UUIDParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the UUIDParameter.
+func (p *UUIDParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+		Format:       "uuid",
+		Default:      p.GetDefault(),
+		Enum:         p.enum(),
+	}
+}
+
+// McpManifest returns the MCP manifest for the UUIDParameter.
+func (p *UUIDParameter) McpManifest() (ParameterMcpManifest, []string) {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	return ParameterMcpManifest{
+		Type:        "string",
+		Description: p.Desc,
+		Format:      "uuid",
+		Default:     p.GetDefault(),
+		Enum:        p.enum(),
+	}, authServiceNames
+}
+
+// NewDecimalParameter is a convenience function for initializing a DecimalParameter.
+func NewDecimalParameter(name string, desc string) *DecimalParameter {
+	return &DecimalParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDecimal,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewDecimalParameterWithDefault is a convenience function for initializing a DecimalParameter with default value.
+func NewDecimalParameterWithDefault(name string, defaultV, desc string) *DecimalParameter {
+	return &DecimalParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDecimal,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewDecimalParameterWithRequired is a convenience function for initializing a DecimalParameter.
+func NewDecimalParameterWithRequired(name string, desc string, required bool) *DecimalParameter {
+	return &DecimalParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDecimal,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+var _ Parameter = &DecimalParameter{}
+
+// DecimalParameter is a parameter representing the "decimal" type, an exact
+// number for values such as prices, which floats can't represent. Numbers are
+// best sent as strings, to keep them from being rounded in transit. Its values
+// are Decimals.
+type DecimalParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+}
+
+func (p *DecimalParameter) Parse(v any) (any, error) {
+	var s string
+	switch newV := v.(type) {
+	default:
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	case string:
+		s = strings.TrimSpace(newV)
+	case json.Number:
+		s = newV.String()
+	case int:
+		s = strconv.Itoa(newV)
+	case float64:
+		s = strconv.FormatFloat(newV, 'f', -1, 64)
+	}
+	if !decimalRegexp.MatchString(s) {
+		return nil, fmt.Errorf("%q is not a decimal number", s)
+	}
+	out := Decimal(strings.TrimPrefix(s, "+"))
+	if !p.IsAllowedValues(out) {
+		return nil, fmt.Errorf("%s is not an allowed value", out)
+	}
+	if p.IsExcludedValues(out) {
+		return nil, fmt.Errorf("%s is an excluded value", out)
+	}
+	return out, nil
+}
+
+func (p *DecimalParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *DecimalParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the DecimalParameter.
+func (p *DecimalParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+		Format:       "decimal",
+		Default:      p.GetDefault(),
+		Enum:         p.enum(),
+	}
+}
+
+// McpManifest returns the MCP manifest for the DecimalParameter.
+func (p *DecimalParameter) McpManifest() (ParameterMcpManifest, []string) {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	return ParameterMcpManifest{
+		Type:        "string",
+		Description: p.Desc,
+		Format:      "decimal",
+		Default:     p.GetDefault(),
+		Enum:        p.enum(),
+	}, authServiceNames
+}
+
+// Identifier is the value of an "identifier" parameter, such as a table or
+// column name, optionally qualified with dots. It's quoted for the dialect of
+// the statement it's substituted into.
+type Identifier string
+
+// identifierRegexp matches identifiers made of letters, digits, underscores
+// and hyphens, optionally qualified with dots, e.g. "sales.orders" or
+// "my-project.sales.orders". Each part starts with a letter or underscore.
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*(\.[A-Za-z_][A-Za-z0-9_-]*)*$`)
+
+// keywordRegexp matches keywords made of one or more words, e.g. "NULLS LAST".
+var keywordRegexp = regexp.MustCompile(`^[A-Za-z]+( [A-Za-z]+)*$`)
+
+// checkTemplateParam verifies that a template parameter can't substitute
+// free-form text into a statement, unless it's marked unsafe.
+func checkTemplateParam(p Parameter) error {
+	switch p.GetType() {
+	case TypeIdentifier, TypeIdentifierList, TypeKeyword, TypeInt, TypeFloat, TypeBool:
+		return nil
+	}
+	if p.GetUnsafe() {
+		return nil
+	}
+	return fmt.Errorf("template parameter %q of type %q can inject SQL into the statement: use type %q, %q or %q, or set 'unsafe: true' to substitute it as it is", p.GetName(), p.GetType(), TypeIdentifier, TypeIdentifierList, TypeKeyword)
+}
+
+// quoteIdentifiers quotes the identifiers in the value of a template
+// parameter, including the elements of lists.
+func quoteIdentifiers(quote string, v any) (any, error) {
+	switch v := v.(type) {
+	case Identifier:
+		return quoteIdentifier(quote, v)
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			q, err := quoteIdentifiers(quote, e)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = q
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+// quoteIdentifier quotes each part of a qualified identifier. Hyphens are
+// only safe inside quotes, so identifiers with hyphens can't be left unquoted.
+func quoteIdentifier(quote string, id Identifier) (string, error) {
+	if quote == QuoteNone && strings.Contains(string(id), "-") {
+		return "", fmt.Errorf("%q can't be substituted unquoted, since it contains hyphens", id)
+	}
+	parts := strings.Split(string(id), ".")
+	for i, part := range parts {
+		switch quote {
+		case QuoteDoubleQuotesLower:
+			part = strings.ToLower(part)
+		case QuoteDoubleQuotesUpper:
+			part = strings.ToUpper(part)
+		}
+		switch quote {
+		case QuoteDoubleQuotes, QuoteDoubleQuotesLower, QuoteDoubleQuotesUpper:
+			parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+		case QuoteBackticks:
+			parts[i] = "`" + strings.ReplaceAll(part, "`", "``") + "`"
+		case QuoteSquareBrackets:
+			parts[i] = "[" + strings.ReplaceAll(part, "]", "]]") + "]"
+		}
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// parseIdentifier parses a value of an identifier parameter.
+func parseIdentifier(p *CommonParameter, v any) (Identifier, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", &ParseTypeError{p.Name, p.Type, v}
+	}
+	if !identifierRegexp.MatchString(s) {
+		return "", fmt.Errorf("%q is not an identifier of letters, digits, underscores and hyphens", s)
+	}
+	return Identifier(s), nil
+}
+
+// checkIdentifier checks an identifier against the allowed and excluded
+// values of a parameter. Like keywords, identifiers are matched to them
+// exactly rather than as regular expressions, so that allowing "users" doesn't
+// allow "users_passwords".
+func checkIdentifier(p *CommonParameter, id Identifier) error {
+	if len(p.AllowedValues) > 0 && !slices.Contains(p.AllowedValues, any(string(id))) {
+		return fmt.Errorf("%s is not an allowed value", id)
+	}
+	if slices.Contains(p.ExcludedValues, any(string(id))) {
+		return fmt.Errorf("%s is an excluded value", id)
+	}
+	return nil
+}
+
+// NewIdentifierParameter is a convenience function for initializing an IdentifierParameter.
+func NewIdentifierParameter(name string, desc string) *IdentifierParameter {
+	return &IdentifierParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeIdentifier,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewIdentifierParameterWithAllowedValues is a convenience function for initializing an IdentifierParameter with a list of allowedValues
+func NewIdentifierParameterWithAllowedValues(name string, desc string, allowedValues []any) *IdentifierParameter {
+	return &IdentifierParameter{
+		CommonParameter: CommonParameter{
+			Name:          name,
+			Type:          TypeIdentifier,
+			Desc:          desc,
+			AllowedValues: allowedValues,
+			AuthServices:  nil,
+		},
+	}
+}
+
+var _ Parameter = &IdentifierParameter{}
+
+// IdentifierParameter is a template parameter representing the "identifier"
+// type, the name of a table, column or other object. Its values are
+// Identifiers.
+type IdentifierParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+}
+
+func (p *IdentifierParameter) Parse(v any) (any, error) {
+	v, err := p.transform(v)
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseIdentifier(&p.CommonParameter, v)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkIdentifier(&p.CommonParameter, id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+func (p *IdentifierParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *IdentifierParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the IdentifierParameter.
+func (p *IdentifierParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+		Pattern:      identifierRegexp.String(),
+		Default:      p.GetDefault(),
+		Enum:         p.AllowedValues,
+	}
+}
+
+// McpManifest returns the MCP manifest for the IdentifierParameter.
+func (p *IdentifierParameter) McpManifest() (ParameterMcpManifest, []string) {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	return ParameterMcpManifest{
+		Type:        "string",
+		Description: p.Desc,
+		Pattern:     identifierRegexp.String(),
+		Default:     p.GetDefault(),
+		Enum:        p.AllowedValues,
+	}, authServiceNames
+}
+
+// NewIdentifierListParameter is a convenience function for initializing an IdentifierListParameter.
+func NewIdentifierListParameter(name string, desc string) *IdentifierListParameter {
+	return &IdentifierListParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeIdentifierList,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+var _ Parameter = &IdentifierListParameter{}
+
+// IdentifierListParameter is a template parameter representing the
+// "identifierList" type, a list of identifiers such as column names. Its
+// values are lists of Identifiers, and allowed and excluded values apply to
+// each identifier.
+type IdentifierListParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *[]any `yaml:"default"`
+	MinItems        *int   `yaml:"minItems"`
+	MaxItems        *int   `yaml:"maxItems"`
+}
+
+func (p *IdentifierListParameter) Parse(v any) (any, error) {
+	v, err := p.transform(v)
+	if err != nil {
+		return nil, err
+	}
+	arrVal, ok := v.([]any)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if p.MinItems != nil && len(arrVal) < *p.MinItems {
+		return nil, fmt.Errorf("%d items are fewer than the minimum of %d", len(arrVal), *p.MinItems)
+	}
+	if p.MaxItems != nil && len(arrVal) > *p.MaxItems {
+		return nil, fmt.Errorf("%d items are more than the maximum of %d", len(arrVal), *p.MaxItems)
+	}
+	rtn := make([]any, 0, len(arrVal))
+	for idx, val := range arrVal {
+		id, err := parseIdentifier(&p.CommonParameter, val)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse element #%d: %w", idx, err)
+		}
+		if err := checkIdentifier(&p.CommonParameter, id); err != nil {
+			return nil, err
+		}
+		rtn = append(rtn, id)
+	}
+	return rtn, nil
+}
+
+func (p *IdentifierListParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *IdentifierListParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the IdentifierListParameter.
+func (p *IdentifierListParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+		Items: &ParameterManifest{
+			Name:         p.Name,
+			Type:         TypeIdentifier,
+			Required:     r,
+			Description:  p.Desc,
+			AuthServices: []string{},
+			Pattern:      identifierRegexp.String(),
+			Enum:         p.AllowedValues,
+		},
+		Default:  p.GetDefault(),
+		MinItems: p.MinItems,
+		MaxItems: p.MaxItems,
+	}
+}
+
+// McpManifest returns the MCP manifest for the IdentifierListParameter.
+func (p *IdentifierListParameter) McpManifest() (ParameterMcpManifest, []string) {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	return ParameterMcpManifest{
+		Type:        "array",
+		Description: p.Desc,
+		Items: &ParameterMcpManifest{
+			Type:        "string",
+			Description: p.Desc,
+			Pattern:     identifierRegexp.String(),
+			Enum:        p.AllowedValues,
+		},
+		Default:  p.GetDefault(),
+		MinItems: p.MinItems,
+		MaxItems: p.MaxItems,
+	}, authServiceNames
+}
+
+// NewKeywordParameter is a convenience function for initializing a KeywordParameter.
+func NewKeywordParameter(name string, desc string, keywords []any) *KeywordParameter {
+	return &KeywordParameter{
+		CommonParameter: CommonParameter{
+			Name:          name,
+			Type:          TypeKeyword,
+			Desc:          desc,
+			AllowedValues: keywords,
+			AuthServices:  nil,
+		},
+	}
+}
+
+var _ Parameter = &KeywordParameter{}
+
+// KeywordParameter is a template parameter representing the "keyword" type,
+// one of the keywords in its allowed values, such as "ASC" or "DESC". Values
+// are matched to them exactly, ignoring case, rather than as regular
+// expressions, and substituted as they're written in the allowed values.
+type KeywordParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+}
+
+func (p *KeywordParameter) Parse(v any) (any, error) {
+	v, err := p.transform(v)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	for _, av := range p.AllowedValues {
+		if k, ok := av.(string); ok && strings.EqualFold(s, k) {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not an allowed value", s)
+}
+
+func (p *KeywordParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *KeywordParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the KeywordParameter.
+func (p *KeywordParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+		Default:      p.GetDefault(),
+		Enum:         p.AllowedValues,
+	}
+}
+
+// McpManifest returns the MCP manifest for the KeywordParameter.
+func (p *KeywordParameter) McpManifest() (ParameterMcpManifest, []string) {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	return ParameterMcpManifest{
+		Type:        "string",
+		Description: p.Desc,
+		Default:     p.GetDefault(),
+		Enum:        p.AllowedValues,
 	}, authServiceNames
 }
@@ -34,6 +34,7 @@ func TestParametersMarshal(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
+	pattern, minLength, maxLength := "^[a-z]+$", 2, 8
 	tcs := []struct {
 		name string
 		in   []map[string]any
@@ -353,6 +354,160 @@ func TestParametersMarshal(t *testing.T) {
 				parameters.NewMapParameter("my_generic_map", "this param is a generic map", ""),
 			},
 		},
+		{
+			name: "string with constraints",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"pattern":     "^[a-z]+$",
+					"minLength":   2,
+					"maxLength":   8,
+				},
+			},
+			want: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+					Pattern:         &pattern,
+					MinLength:       &minLength,
+					MaxLength:       &maxLength,
+				},
+			},
+		},
+		{
+			name: "array with item limits",
+			in: []map[string]any{
+				{
+					"name":        "my_array",
+					"type":        "array",
+					"description": "this param is an array",
+					"items": map[string]any{
+						"name":        "my_string",
+						"type":        "string",
+						"description": "string item",
+					},
+					"minItems": 2,
+					"maxItems": 8,
+				},
+			},
+			want: parameters.Parameters{
+				&parameters.ArrayParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_array", Type: "array", Desc: "this param is an array"},
+					Items:           parameters.NewStringParameter("my_string", "string item"),
+					MinItems:        &minLength,
+					MaxItems:        &maxLength,
+				},
+			},
+		},
+		{
+			name: "object",
+			in: []map[string]any{
+				{
+					"name":        "address",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]any{
+						{
+							"name":        "street",
+							"type":        "string",
+							"description": "street name",
+						},
+						{
+							"name":        "zip",
+							"type":        "integer",
+							"description": "zip code",
+							"required":    false,
+						},
+					},
+				},
+			},
+			want: parameters.Parameters{
+				parameters.NewObjectParameter("address", "this param is an object", parameters.Parameters{
+					parameters.NewStringParameter("street", "street name"),
+					parameters.NewIntParameterWithRequired("zip", "zip code", false),
+				}),
+			},
+		},
+		{
+			name: "array of objects",
+			in: []map[string]any{
+				{
+					"name":        "line_items",
+					"type":        "array",
+					"description": "this param is an array of objects",
+					"items": map[string]any{
+						"name":        "line_item",
+						"type":        "object",
+						"description": "a line item",
+						"properties": []map[string]any{
+							{
+								"name":        "sku",
+								"type":        "string",
+								"description": "product sku",
+							},
+							{
+								"name":        "quantity",
+								"type":        "integer",
+								"description": "quantity",
+								"default":     1,
+							},
+						},
+					},
+				},
+			},
+			want: parameters.Parameters{
+				parameters.NewArrayParameter("line_items", "this param is an array of objects",
+					parameters.NewObjectParameter("line_item", "a line item", parameters.Parameters{
+						parameters.NewStringParameter("sku", "product sku"),
+						parameters.NewIntParameterWithDefault("quantity", 1, "quantity"),
+					}),
+				),
+			},
+		},
+		{
+			name: "nullable",
+			in: []map[string]any{
+				{"name": "my_string", "type": "string", "description": "this param is a string", "nullable": true},
+			},
+			want: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string", Nullable: true},
+				},
+			},
+		},
+		{
+			name: "formatted strings",
+			in: []map[string]any{
+				{"name": "my_date", "type": "date", "description": "this param is a date", "default": "2025-01-01"},
+				{"name": "my_datetime", "type": "datetime", "description": "this param is a datetime"},
+				{"name": "my_uuid", "type": "uuid", "description": "this param is a uuid", "required": false},
+				{"name": "my_decimal", "type": "decimal", "description": "this param is a decimal"},
+			},
+			want: parameters.Parameters{
+				parameters.NewDateParameterWithDefault("my_date", "2025-01-01", "this param is a date"),
+				parameters.NewDateTimeParameter("my_datetime", "this param is a datetime"),
+				parameters.NewUUIDParameterWithRequired("my_uuid", "this param is a uuid", false),
+				parameters.NewDecimalParameter("my_decimal", "this param is a decimal"),
+			},
+		},
+		{
+			name: "template parameters",
+			in: []map[string]any{
+				{"name": "table", "type": "identifier", "description": "this param is an identifier"},
+				{"name": "columns", "type": "identifierList", "description": "this param is a list of identifiers"},
+				{"name": "order", "type": "keyword", "description": "this param is a keyword", "allowedValues": []any{"ASC", "DESC"}},
+				{"name": "filter", "type": "string", "description": "this param is a string", "unsafe": true},
+			},
+			want: parameters.Parameters{
+				parameters.NewIdentifierParameter("table", "this param is an identifier"),
+				parameters.NewIdentifierListParameter("columns", "this param is a list of identifiers"),
+				parameters.NewKeywordParameter("order", "this param is a keyword", []any{"ASC", "DESC"}),
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "filter", Type: "string", Desc: "this param is a string", Unsafe: true},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -695,6 +850,35 @@ func TestAuthParametersMarshal(t *testing.T) {
 func TestParametersParse(t *testing.T) {
 	intValue := 2
 	floatValue := 1.5
+	pattern := "^[a-z]+$"
+	constrainedString := &parameters.StringParameter{
+		CommonParameter: parameters.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+		Pattern:         &pattern,
+		MinLength:       &intValue,
+		MaxLength:       &intValue,
+	}
+	constrainedArray := &parameters.ArrayParameter{
+		CommonParameter: parameters.CommonParameter{Name: "my_array", Type: "array", Desc: "this param is an array"},
+		Items:           parameters.NewStringParameter("my_string", "string item"),
+		MinItems:        &intValue,
+		MaxItems:        &intValue,
+	}
+	nullableString := parameters.NewStringParameter("my_string", "this param is a string")
+	nullableString.Nullable = true
+	nullableInt := parameters.NewIntParameterWithDefault("my_int", 3, "this param is an int")
+	nullableInt.Nullable = true
+	address := parameters.NewObjectParameter("address", "this param is an object", parameters.Parameters{
+		parameters.NewStringParameter("street", "street name"),
+		parameters.NewIntParameterWithRequired("zip", "zip code", false),
+		parameters.NewStringParameterWithDefault("country", "US", "country code"),
+	})
+	nullableUnit := parameters.NewStringParameterWithRequired("unit", "unit number", false)
+	nullableUnit.Nullable = true
+	addressWithUnit := parameters.NewObjectParameter("address", "this param is an object", parameters.Parameters{
+		parameters.NewStringParameter("street", "street name"),
+		parameters.NewIntParameterWithRequired("zip", "zip code", false),
+		nullableUnit,
+	})
 	tcs := []struct {
 		name   string
 		params parameters.Parameters
@@ -886,88 +1070,444 @@ func TestParametersParse(t *testing.T) {
 			},
 		},
 		{
-			name: "int not allowed regex",
+			name: "int not allowed regex",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithAllowedValues("my_int", "this param is an int", []any{"^\\d{2}$"}),
+			},
+			in: map[string]any{
+				"my_int": 100,
+			},
+		},
+		{
+			name: "int excluded",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithExcludedValues("my_int", "this param is an int", []any{1}),
+			},
+			in: map[string]any{
+				"my_int": 1,
+			},
+		},
+		{
+			name: "int excluded regex",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithExcludedValues("my_int", "this param is an int", []any{"^\\d{2}$"}),
+			},
+			in: map[string]any{
+				"my_int": 10,
+			},
+		},
+		{
+			name: "int not excluded",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithExcludedValues("my_int", "this param is an int", []any{1}),
+			},
+			in: map[string]any{
+				"my_int": 2,
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 2}},
+		},
+		{
+			name: "int not excluded regex",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithExcludedValues("my_int", "this param is an int", []any{"^\\d{2}$"}),
+			},
+			in: map[string]any{
+				"my_int": 2,
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 2}},
+		},
+		{
+			name: "int minValue",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithRange("my_int", "this param is an int", &intValue, nil),
+			},
+			in: map[string]any{
+				"my_int": 3,
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 3}},
+		},
+		{
+			name: "int minValue disallow",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithRange("my_int", "this param is an int", &intValue, nil),
+			},
+			in: map[string]any{
+				"my_int": 1,
+			},
+		},
+		{
+			name: "int maxValue",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithRange("my_int", "this param is an int", nil, &intValue),
+			},
+			in: map[string]any{
+				"my_int": 1,
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 1}},
+		},
+		{
+			name: "int maxValue disallow",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithRange("my_int", "this param is an int", nil, &intValue),
+			},
+			in: map[string]any{
+				"my_int": 3,
+			},
+		},
+		{
+			name: "string constraints",
+			params: parameters.Parameters{
+				constrainedString,
+			},
+			in: map[string]any{
+				"my_string": "ab",
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_string", Value: "ab"}},
+		},
+		{
+			name: "string pattern disallow",
+			params: parameters.Parameters{
+				constrainedString,
+			},
+			in: map[string]any{
+				"my_string": "A1",
+			},
+		},
+		{
+			name: "string minLength disallow",
+			params: parameters.Parameters{
+				constrainedString,
+			},
+			in: map[string]any{
+				"my_string": "a",
+			},
+		},
+		{
+			name: "string maxLength disallow",
+			params: parameters.Parameters{
+				constrainedString,
+			},
+			in: map[string]any{
+				"my_string": "abc",
+			},
+		},
+		{
+			name: "array item limits",
+			params: parameters.Parameters{
+				constrainedArray,
+			},
+			in: map[string]any{
+				"my_array": []any{"a", "b"},
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_array", Value: []any{"a", "b"}}},
+		},
+		{
+			name: "array minItems disallow",
+			params: parameters.Parameters{
+				constrainedArray,
+			},
+			in: map[string]any{
+				"my_array": []any{"a"},
+			},
+		},
+		{
+			name: "array maxItems disallow",
+			params: parameters.Parameters{
+				constrainedArray,
+			},
+			in: map[string]any{
+				"my_array": []any{"a", "b", "c"},
+			},
+		},
+		{
+			name: "object",
+			params: parameters.Parameters{
+				address,
+			},
+			in: map[string]any{
+				"address": map[string]any{"street": "Main St", "zip": 94043},
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "address", Value: map[string]any{"street": "Main St", "zip": 94043, "country": "US"}}},
+		},
+		{
+			name: "object null properties",
+			params: parameters.Parameters{
+				addressWithUnit,
+			},
+			in: map[string]any{
+				"address": map[string]any{"street": "Main St", "zip": nil, "unit": nil},
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "address", Value: map[string]any{"street": "Main St", "unit": (*string)(nil)}}},
+		},
+		{
+			name: "object missing required property",
+			params: parameters.Parameters{
+				address,
+			},
+			in: map[string]any{
+				"address": map[string]any{"zip": 94043},
+			},
+		},
+		{
+			name: "object unknown property",
+			params: parameters.Parameters{
+				address,
+			},
+			in: map[string]any{
+				"address": map[string]any{"street": "Main St", "city": "Mountain View"},
+			},
+		},
+		{
+			name: "object property wrong type",
+			params: parameters.Parameters{
+				address,
+			},
+			in: map[string]any{
+				"address": map[string]any{"street": "Main St", "zip": "94043"},
+			},
+		},
+		{
+			name: "array of objects",
+			params: parameters.Parameters{
+				parameters.NewArrayParameter("addresses", "this param is an array of objects", address),
+			},
+			in: map[string]any{
+				"addresses": []any{
+					map[string]any{"street": "Main St"},
+					map[string]any{"street": "Broadway", "country": "CA"},
+				},
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "addresses", Value: []any{
+				map[string]any{"street": "Main St", "country": "US"},
+				map[string]any{"street": "Broadway", "country": "CA"},
+			}}},
+		},
+		{
+			name: "nullable null",
+			params: parameters.Parameters{
+				nullableString,
+				nullableInt,
+			},
+			in: map[string]any{
+				"my_string": nil,
+				"my_int":    nil,
+			},
+			want: parameters.ParamValues{
+				parameters.ParamValue{Name: "my_string", Value: (*string)(nil)},
+				parameters.ParamValue{Name: "my_int", Value: (*int)(nil)},
+			},
+		},
+		{
+			name: "nullable missing",
+			params: parameters.Parameters{
+				nullableInt,
+			},
+			in:   map[string]any{},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 3}},
+		},
+		{
+			name: "nullable required missing",
+			params: parameters.Parameters{
+				nullableString,
+			},
+			in: map[string]any{},
+		},
+		{
+			name: "null for parameter with default",
+			params: parameters.Parameters{
+				parameters.NewIntParameterWithDefault("my_int", 3, "this param is an int"),
+			},
+			in: map[string]any{
+				"my_int": nil,
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 3}},
+		},
+		{
+			name: "null for required parameter",
+			params: parameters.Parameters{
+				parameters.NewStringParameter("my_string", "this param is a string"),
+			},
+			in: map[string]any{
+				"my_string": nil,
+			},
+		},
+		{
+			name: "date",
+			params: parameters.Parameters{
+				parameters.NewDateParameter("my_date", "this param is a date"),
+			},
+			in: map[string]any{
+				"my_date": "2025-03-14",
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_date", Value: parameters.Date("2025-03-14")}},
+		},
+		{
+			name: "date not formatted as YYYY-MM-DD",
+			params: parameters.Parameters{
+				parameters.NewDateParameter("my_date", "this param is a date"),
+			},
+			in: map[string]any{
+				"my_date": "next Tuesday",
+			},
+		},
+		{
+			name: "date out of range",
+			params: parameters.Parameters{
+				parameters.NewDateParameter("my_date", "this param is a date"),
+			},
+			in: map[string]any{
+				"my_date": "2025-02-30",
+			},
+		},
+		{
+			name: "datetime",
+			params: parameters.Parameters{
+				parameters.NewDateTimeParameter("my_datetime", "this param is a datetime"),
+			},
+			in: map[string]any{
+				"my_datetime": "2025-03-14T09:30:00.5-07:00",
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_datetime", Value: parameters.DateTime("2025-03-14T09:30:00.5-07:00")}},
+		},
+		{
+			name: "datetime without time zone",
+			params: parameters.Parameters{
+				parameters.NewDateTimeParameter("my_datetime", "this param is a datetime"),
+			},
+			in: map[string]any{
+				"my_datetime": "2025-03-14T09:30:00",
+			},
+		},
+		{
+			name: "uuid",
+			params: parameters.Parameters{
+				parameters.NewUUIDParameter("my_uuid", "this param is a uuid"),
+			},
+			in: map[string]any{
+				"my_uuid": "{A0EEBC99-9C0B-4EF8-BB6D-6BB9BD380A11}",
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_uuid", Value: parameters.UUID("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")}},
+		},
+		{
+			name: "invalid uuid",
+			params: parameters.Parameters{
+				parameters.NewUUIDParameter("my_uuid", "this param is a uuid"),
+			},
+			in: map[string]any{
+				"my_uuid": "a0eebc99",
+			},
+		},
+		{
+			name: "decimal",
+			params: parameters.Parameters{
+				parameters.NewDecimalParameter("my_decimal", "this param is a decimal"),
+				parameters.NewDecimalParameter("my_decimal2", "this param is a decimal"),
+			},
+			in: map[string]any{
+				"my_decimal":  "+1234567890.123456789",
+				"my_decimal2": 12.5,
+			},
+			want: parameters.ParamValues{
+				parameters.ParamValue{Name: "my_decimal", Value: parameters.Decimal("1234567890.123456789")},
+				parameters.ParamValue{Name: "my_decimal2", Value: parameters.Decimal("12.5")},
+			},
+		},
+		{
+			name: "invalid decimal",
 			params: parameters.Parameters{
-				parameters.NewIntParameterWithAllowedValues("my_int", "this param is an int", []any{"^\\d{2}$"}),
+				parameters.NewDecimalParameter("my_decimal", "this param is a decimal"),
 			},
 			in: map[string]any{
-				"my_int": 100,
+				"my_decimal": "12,5",
 			},
 		},
 		{
-			name: "int excluded",
+			name: "template parameters",
 			params: parameters.Parameters{
-				parameters.NewIntParameterWithExcludedValues("my_int", "this param is an int", []any{1}),
+				parameters.NewIdentifierParameter("table", "this param is an identifier"),
+				parameters.NewIdentifierListParameter("columns", "this param is a list of identifiers"),
+				parameters.NewKeywordParameter("order", "this param is a keyword", []any{"ASC", "DESC"}),
 			},
 			in: map[string]any{
-				"my_int": 1,
+				"table":   "sales.orders",
+				"columns": []any{"id", "total_2024"},
+				"order":   "desc",
+			},
+			want: parameters.ParamValues{
+				parameters.ParamValue{Name: "table", Value: parameters.Identifier("sales.orders")},
+				parameters.ParamValue{Name: "columns", Value: []any{parameters.Identifier("id"), parameters.Identifier("total_2024")}},
+				parameters.ParamValue{Name: "order", Value: "DESC"},
 			},
 		},
 		{
-			name: "int excluded regex",
+			name: "injected identifier",
 			params: parameters.Parameters{
-				parameters.NewIntParameterWithExcludedValues("my_int", "this param is an int", []any{"^\\d{2}$"}),
+				parameters.NewIdentifierParameter("table", "this param is an identifier"),
 			},
 			in: map[string]any{
-				"my_int": 10,
+				"table": "orders; DROP TABLE orders",
 			},
 		},
 		{
-			name: "int not excluded",
+			name: "identifier list with quoted identifier",
 			params: parameters.Parameters{
-				parameters.NewIntParameterWithExcludedValues("my_int", "this param is an int", []any{1}),
+				parameters.NewIdentifierListParameter("columns", "this param is a list of identifiers"),
 			},
 			in: map[string]any{
-				"my_int": 2,
+				"columns": []any{"id", `"name"`},
 			},
-			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 2}},
 		},
 		{
-			name: "int not excluded regex",
+			name: "identifier not allowed",
 			params: parameters.Parameters{
-				parameters.NewIntParameterWithExcludedValues("my_int", "this param is an int", []any{"^\\d{2}$"}),
+				parameters.NewIdentifierParameterWithAllowedValues("table", "this param is an identifier", []any{"orders", "customers"}),
 			},
 			in: map[string]any{
-				"my_int": 2,
+				"table": "users",
 			},
-			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 2}},
 		},
 		{
-			name: "int minValue",
+			name: "identifier containing an allowed value",
 			params: parameters.Parameters{
-				parameters.NewIntParameterWithRange("my_int", "this param is an int", &intValue, nil),
+				parameters.NewIdentifierParameterWithAllowedValues("table", "this param is an identifier", []any{"users"}),
 			},
 			in: map[string]any{
-				"my_int": 3,
+				"table": "users_passwords",
 			},
-			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 3}},
 		},
 		{
-			name: "int minValue disallow",
+			name: "identifier list containing an allowed value",
 			params: parameters.Parameters{
-				parameters.NewIntParameterWithRange("my_int", "this param is an int", &intValue, nil),
+				&parameters.IdentifierListParameter{CommonParameter: parameters.CommonParameter{
+					Name:          "columns",
+					Type:          parameters.TypeIdentifierList,
+					Desc:          "this param is a list of identifiers",
+					AllowedValues: []any{"id", "name"},
+				}},
 			},
 			in: map[string]any{
-				"my_int": 1,
+				"columns": []any{"id", "name_hash"},
 			},
 		},
 		{
-			name: "int maxValue",
+			name: "keyword not allowed",
 			params: parameters.Parameters{
-				parameters.NewIntParameterWithRange("my_int", "this param is an int", nil, &intValue),
+				parameters.NewKeywordParameter("order", "this param is a keyword", []any{"ASC", "DESC"}),
 			},
 			in: map[string]any{
-				"my_int": 1,
+				"order": "ASC, (SELECT 1)",
 			},
-			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 1}},
 		},
 		{
-			name: "int maxValue disallow",
+			name: "array of objects with invalid item",
 			params: parameters.Parameters{
-				parameters.NewIntParameterWithRange("my_int", "this param is an int", nil, &intValue),
+				parameters.NewArrayParameter("addresses", "this param is an array of objects", address),
 			},
 			in: map[string]any{
-				"my_int": 3,
+				"addresses": []any{
+					map[string]any{"street": "Main St"},
+					map[string]any{"zip": 10001},
+				},
 			},
 		},
 		{
@@ -1625,22 +2165,22 @@ func TestParamManifest(t *testing.T) {
 		{
 			name: "string default",
 			in:   parameters.NewStringParameterWithDefault("foo-string", "foo", "bar"),
-			want: parameters.ParameterManifest{Name: "foo-string", Type: "string", Required: false, Description: "bar", AuthServices: []string{}},
+			want: parameters.ParameterManifest{Name: "foo-string", Type: "string", Required: false, Description: "bar", AuthServices: []string{}, Default: "foo"},
 		},
 		{
 			name: "int default",
 			in:   parameters.NewIntParameterWithDefault("foo-int", 1, "bar"),
-			want: parameters.ParameterManifest{Name: "foo-int", Type: "integer", Required: false, Description: "bar", AuthServices: []string{}},
+			want: parameters.ParameterManifest{Name: "foo-int", Type: "integer", Required: false, Description: "bar", AuthServices: []string{}, Default: 1},
 		},
 		{
 			name: "float default",
 			in:   parameters.NewFloatParameterWithDefault("foo-float", 1.1, "bar"),
-			want: parameters.ParameterManifest{Name: "foo-float", Type: "float", Required: false, Description: "bar", AuthServices: []string{}},
+			want: parameters.ParameterManifest{Name: "foo-float", Type: "float", Required: false, Description: "bar", AuthServices: []string{}, Default: 1.1},
 		},
 		{
 			name: "boolean default",
 			in:   parameters.NewBooleanParameterWithDefault("foo-bool", true, "bar"),
-			want: parameters.ParameterManifest{Name: "foo-bool", Type: "boolean", Required: false, Description: "bar", AuthServices: []string{}},
+			want: parameters.ParameterManifest{Name: "foo-bool", Type: "boolean", Required: false, Description: "bar", AuthServices: []string{}, Default: true},
 		},
 		{
 			name: "array default",
@@ -1652,6 +2192,7 @@ func TestParamManifest(t *testing.T) {
 				Description:  "bar",
 				AuthServices: []string{},
 				Items:        &parameters.ParameterManifest{Name: "foo-string", Type: "string", Required: false, Description: "bar", AuthServices: []string{}},
+				Default:      []any{"foo", "bar"},
 			},
 		},
 		{
@@ -1722,6 +2263,35 @@ func TestParamManifest(t *testing.T) {
 				AdditionalProperties: true,
 			},
 		},
+		{
+			name: "object",
+			in: parameters.NewObjectParameter("foo-object", "bar", parameters.Parameters{
+				parameters.NewStringParameter("foo-string", "bar"),
+				parameters.NewIntParameterWithRequired("foo-int", "bar", false),
+			}),
+			want: parameters.ParameterManifest{
+				Name:         "foo-object",
+				Type:         "object",
+				Required:     true,
+				Description:  "bar",
+				AuthServices: []string{},
+				Properties: []parameters.ParameterManifest{
+					{Name: "foo-string", Type: "string", Required: true, Description: "bar", AuthServices: []string{}},
+					{Name: "foo-int", Type: "integer", Required: false, Description: "bar", AuthServices: []string{}},
+				},
+				AdditionalProperties: false,
+			},
+		},
+		{
+			name: "date",
+			in:   parameters.NewDateParameter("foo-date", "bar"),
+			want: parameters.ParameterManifest{Name: "foo-date", Type: "date", Required: true, Description: "bar", AuthServices: []string{}, Format: "date"},
+		},
+		{
+			name: "datetime not required",
+			in:   parameters.NewDateTimeParameterWithRequired("foo-datetime", "bar", false),
+			want: parameters.ParameterManifest{Name: "foo-datetime", Type: "datetime", Required: false, Description: "bar", AuthServices: []string{}, Format: "date-time"},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1734,6 +2304,8 @@ func TestParamManifest(t *testing.T) {
 }
 
 func TestParamMcpManifest(t *testing.T) {
+	minValue, maxValue := 1, 10
+	pattern := "^[a-z]+$"
 	tcs := []struct {
 		name          string
 		in            parameters.Parameter
@@ -1795,6 +2367,127 @@ func TestParamMcpManifest(t *testing.T) {
 			},
 			wantAuthParam: []string{},
 		},
+		{
+			name: "string constraints",
+			in: &parameters.StringParameter{
+				CommonParameter: parameters.CommonParameter{Name: "foo-string", Type: "string", Desc: "bar", AllowedValues: []any{"ab", "cd"}},
+				Default:         &pattern,
+				Pattern:         &pattern,
+				MinLength:       &minValue,
+				MaxLength:       &maxValue,
+			},
+			want: parameters.ParameterMcpManifest{
+				Type:        "string",
+				Description: "bar",
+				Default:     pattern,
+				Enum:        []any{"ab", "cd"},
+				Pattern:     pattern,
+				MinLength:   &minValue,
+				MaxLength:   &maxValue,
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name:          "allowed values with regex",
+			in:            parameters.NewStringParameterWithAllowedValues("foo-string", "bar", []any{"ab", "^c.*"}),
+			want:          parameters.ParameterMcpManifest{Type: "string", Description: "bar"},
+			wantAuthParam: []string{},
+		},
+		{
+			name:          "int range",
+			in:            parameters.NewIntParameterWithRange("foo-int", "bar", &minValue, &maxValue),
+			want:          parameters.ParameterMcpManifest{Type: "integer", Description: "bar", Minimum: 1, Maximum: 10},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "array item limits",
+			in: &parameters.ArrayParameter{
+				CommonParameter: parameters.CommonParameter{Name: "foo-array", Type: "array", Desc: "bar"},
+				Items:           parameters.NewStringParameter("foo-string", "bar"),
+				MinItems:        &minValue,
+				MaxItems:        &maxValue,
+			},
+			want: parameters.ParameterMcpManifest{
+				Type:        "array",
+				Description: "bar",
+				Items:       &parameters.ParameterMcpManifest{Type: "string", Description: "bar"},
+				MinItems:    &minValue,
+				MaxItems:    &maxValue,
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "object",
+			in: parameters.NewObjectParameter("foo-object", "bar", parameters.Parameters{
+				parameters.NewStringParameter("foo-string", "bar"),
+				parameters.NewArrayParameterWithRequired("foo-array", "bar", false,
+					parameters.NewObjectParameter("foo-item", "bar", parameters.Parameters{
+						parameters.NewBooleanParameter("foo-bool", "bar"),
+					}),
+				),
+			}),
+			want: parameters.ParameterMcpManifest{
+				Type:        "object",
+				Description: "bar",
+				Properties: map[string]parameters.ParameterMcpManifest{
+					"foo-string": {Type: "string", Description: "bar"},
+					"foo-array": {
+						Type:        "array",
+						Description: "bar",
+						Items: &parameters.ParameterMcpManifest{
+							Type:                 "object",
+							Description:          "bar",
+							Properties:           map[string]parameters.ParameterMcpManifest{"foo-bool": {Type: "boolean", Description: "bar"}},
+							Required:             []string{"foo-bool"},
+							AdditionalProperties: false,
+						},
+					},
+				},
+				Required:             []string{"foo-string"},
+				AdditionalProperties: false,
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name:          "date",
+			in:            parameters.NewDateParameterWithDefault("foo-date", "2025-01-01", "bar"),
+			want:          parameters.ParameterMcpManifest{Type: "string", Description: "bar", Format: "date", Default: "2025-01-01"},
+			wantAuthParam: []string{},
+		},
+		{
+			name:          "datetime",
+			in:            parameters.NewDateTimeParameter("foo-datetime", "bar"),
+			want:          parameters.ParameterMcpManifest{Type: "string", Description: "bar", Format: "date-time"},
+			wantAuthParam: []string{},
+		},
+		{
+			name:          "uuid",
+			in:            parameters.NewUUIDParameter("foo-uuid", "bar"),
+			want:          parameters.ParameterMcpManifest{Type: "string", Description: "bar", Format: "uuid"},
+			wantAuthParam: []string{},
+		},
+		{
+			name:          "decimal",
+			in:            parameters.NewDecimalParameter("foo-decimal", "bar"),
+			want:          parameters.ParameterMcpManifest{Type: "string", Description: "bar", Format: "decimal"},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "identifier list",
+			in:   parameters.NewIdentifierListParameter("foo-identifiers", "bar"),
+			want: parameters.ParameterMcpManifest{
+				Type:        "array",
+				Description: "bar",
+				Items:       &parameters.ParameterMcpManifest{Type: "string", Description: "bar", Pattern: `^[A-Za-z_][A-Za-z0-9_-]*(\.[A-Za-z_][A-Za-z0-9_-]*)*$`},
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name:          "keyword",
+			in:            parameters.NewKeywordParameter("foo-keyword", "bar", []any{"ASC", "DESC"}),
+			want:          parameters.ParameterMcpManifest{Type: "string", Description: "bar", Enum: []any{"ASC", "DESC"}},
+			wantAuthParam: []string{},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1841,7 +2534,7 @@ func TestMcpManifest(t *testing.T) {
 			wantSchema: parameters.McpToolsSchema{
 				Type: "object",
 				Properties: map[string]parameters.ParameterMcpManifest{
-					"foo-string":       {Type: "string", Description: "bar"},
+					"foo-string":       {Type: "string", Description: "bar", Default: "foo"},
 					"foo-string2":      {Type: "string", Description: "bar"},
 					"foo-string3-auth": {Type: "string", Description: "bar"},
 					"foo-int2":         {Type: "integer", Description: "bar"},
@@ -1868,6 +2561,26 @@ func TestMcpManifest(t *testing.T) {
 				"foo-string3-auth": []string{"my-google-auth-service", "other-auth-service"},
 			},
 		},
+		{
+			name: "nullable",
+			in: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "foo-string", Type: "string", Desc: "bar", Nullable: true, AllowedValues: []any{"a", "b"}},
+				},
+				&parameters.IntParameter{
+					CommonParameter: parameters.CommonParameter{Name: "foo-int", Type: "integer", Desc: "bar", Nullable: true},
+				},
+			},
+			wantSchema: parameters.McpToolsSchema{
+				Type: "object",
+				Properties: map[string]parameters.ParameterMcpManifest{
+					"foo-string": {Type: []any{"string", "null"}, Description: "bar", Enum: []any{"a", "b", nil}},
+					"foo-int":    {Type: []any{"integer", "null"}, Description: "bar"},
+				},
+				Required: []string{"foo-string", "foo-int"},
+			},
+			wantAuthParam: map[string][]string{},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1922,6 +2635,130 @@ func TestFailParametersUnmarshal(t *testing.T) {
 			},
 			err: "parameter is missing 'type' field",
 		},
+		{
+			name: "string invalid pattern",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this is a param for string",
+					"pattern":     "[a-z",
+				},
+			},
+			err: "unable to parse as \"string\": invalid pattern",
+		},
+		{
+			name: "object missing properties",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this is a param for object",
+				},
+			},
+			err: "unable to parse as \"object\": 'properties' must not be empty",
+		},
+		{
+			name: "object duplicate properties",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this is a param for object",
+					"properties": []map[string]any{
+						{"name": "a", "type": "string", "description": "a"},
+						{"name": "a", "type": "integer", "description": "a"},
+					},
+				},
+			},
+			err: "unable to parse as \"object\": parameter name must be unique",
+		},
+		{
+			name: "object property with auth services",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this is a param for object",
+					"properties": []map[string]any{
+						{
+							"name":         "a",
+							"type":         "string",
+							"description":  "a",
+							"authServices": []map[string]any{{"name": "my-google-auth-service", "field": "user_id"}},
+						},
+					},
+				},
+			},
+			err: "unable to parse as \"object\": nested properties should not have auth services",
+		},
+		{
+			name: "computed parameter with invalid template",
+			in: []map[string]any{
+				{
+					"name":        "offset",
+					"type":        "integer",
+					"description": "this is a computed param",
+					"computed":    "{{ mul .page",
+				},
+			},
+			err: "unable to parse computed value of \"offset\": template: offset:1: unclosed action",
+		},
+		{
+			name: "computed parameter with auth services",
+			in: []map[string]any{
+				{
+					"name":         "email",
+					"type":         "string",
+					"description":  "this is a computed param",
+					"computed":     "{{ lower .email }}",
+					"authServices": []map[string]any{{"name": "my-google-auth-service", "field": "email"}},
+				},
+			},
+			err: "computed parameter \"email\" should not have auth services",
+		},
+		{
+			name: "object property computed",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this is a param for object",
+					"properties": []map[string]any{
+						{
+							"name":        "a",
+							"type":        "string",
+							"description": "a",
+							"computed":    "{{ now }}",
+						},
+					},
+				},
+			},
+			err: "unable to parse as \"object\": nested properties should not be computed",
+		},
+		{
+			name: "keyword without allowed values",
+			in: []map[string]any{
+				{
+					"name":        "order",
+					"type":        "keyword",
+					"description": "this is a param for keyword",
+				},
+			},
+			err: "unable to parse as \"keyword\": 'allowedValues' must not be empty",
+		},
+		{
+			name: "keyword with invalid allowed value",
+			in: []map[string]any{
+				{
+					"name":          "order",
+					"type":          "keyword",
+					"description":   "this is a param for keyword",
+					"allowedValues": []any{"ASC", "DESC;"},
+				},
+			},
+			err: "unable to parse as \"keyword\": DESC; is not a keyword",
+		},
 		{
 			name: "common parameter missing description",
 			in: []map[string]any{
@@ -2235,7 +3072,43 @@ func TestResolveTemplateParameters(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			got, _ := parameters.ResolveTemplateParams(tc.templateParams, tc.statement, tc.in)
+			got, _ := parameters.ResolveTemplateParams(tc.templateParams, tc.statement, tc.in, parameters.QuoteNone)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect resolved template params: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestResolveTemplateParametersQuote(t *testing.T) {
+	templateParams := parameters.Parameters{
+		parameters.NewIdentifierParameter("table", "this is an identifier template parameter"),
+		parameters.NewIdentifierListParameter("columns", "this is an identifier list template parameter"),
+		parameters.NewKeywordParameter("order", "this is a keyword template parameter", []any{"ASC", "DESC"}),
+	}
+	statement := "SELECT {{array .columns}} FROM {{.table}} ORDER BY 1 {{.order}}"
+	in := map[string]any{
+		"table":   parameters.Identifier("Sales.orders"),
+		"columns": []any{parameters.Identifier("id"), parameters.Identifier("Total")},
+		"order":   "DESC",
+	}
+	tcs := []struct {
+		quote string
+		want  string
+	}{
+		{quote: parameters.QuoteNone, want: "SELECT id, Total FROM Sales.orders ORDER BY 1 DESC"},
+		{quote: parameters.QuoteDoubleQuotes, want: `SELECT "id", "Total" FROM "Sales"."orders" ORDER BY 1 DESC`},
+		{quote: parameters.QuoteDoubleQuotesLower, want: `SELECT "id", "total" FROM "sales"."orders" ORDER BY 1 DESC`},
+		{quote: parameters.QuoteDoubleQuotesUpper, want: `SELECT "ID", "TOTAL" FROM "SALES"."ORDERS" ORDER BY 1 DESC`},
+		{quote: parameters.QuoteBackticks, want: "SELECT `id`, `Total` FROM `Sales`.`orders` ORDER BY 1 DESC"},
+		{quote: parameters.QuoteSquareBrackets, want: "SELECT [id], [Total] FROM [Sales].[orders] ORDER BY 1 DESC"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.quote, func(t *testing.T) {
+			got, err := parameters.ResolveTemplateParams(templateParams, statement, in, tc.quote)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Fatalf("incorrect resolved template params: diff %v", diff)
 			}
@@ -2243,6 +3116,70 @@ func TestResolveTemplateParameters(t *testing.T) {
 	}
 }
 
+func TestResolveTemplateParametersHyphen(t *testing.T) {
+	templateParams := parameters.Parameters{parameters.NewIdentifierParameter("table", "the table")}
+	in, err := parameters.ParseParams(templateParams, map[string]any{"table": "my-proj.sales.orders"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := parameters.ResolveTemplateParams(templateParams, "SELECT * FROM {{.table}}", in.AsMap(), parameters.QuoteBackticks)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "SELECT * FROM `my-proj`.`sales`.`orders`"; got != want {
+		t.Fatalf("incorrect statement: got %q, want %q", got, want)
+	}
+	// unquoted, the hyphen would be a minus
+	if _, err := parameters.ResolveTemplateParams(templateParams, "SELECT * FROM {{.table}}", in.AsMap(), parameters.QuoteNone); err == nil {
+		t.Fatalf("expected an error substituting a hyphenated identifier unquoted")
+	}
+}
+
+func TestProcessParametersUnsafe(t *testing.T) {
+	unsafe := parameters.NewStringParameter("filter", "this is a free-form template parameter")
+	unsafe.Unsafe = true
+	tcs := []struct {
+		name           string
+		templateParams parameters.Parameters
+		wantErr        bool
+	}{
+		{
+			name: "typed template parameters",
+			templateParams: parameters.Parameters{
+				parameters.NewIdentifierParameter("table", "this is an identifier template parameter"),
+				parameters.NewIntParameter("limit", "this is an integer template parameter"),
+			},
+		},
+		{
+			name:           "unsafe string",
+			templateParams: parameters.Parameters{unsafe},
+		},
+		{
+			name: "string",
+			templateParams: parameters.Parameters{
+				parameters.NewStringParameter("table", "this is a string template parameter"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "array",
+			templateParams: parameters.Parameters{
+				parameters.NewArrayParameter("columns", "this is an array template parameter", parameters.NewStringParameter("column", "a column")),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			params := parameters.Parameters{parameters.NewStringParameter("name", "this is a string parameter")}
+			_, _, err := parameters.ProcessParameters(tc.templateParams, params)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestFailResolveTemplateParameters(t *testing.T) {
 	tcs := []struct {
 		name           string
@@ -2296,7 +3233,7 @@ func TestFailResolveTemplateParameters(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			_, err := parameters.ResolveTemplateParams(tc.templateParams, tc.statement, tc.in)
+			_, err := parameters.ResolveTemplateParams(tc.templateParams, tc.statement, tc.in, parameters.QuoteNone)
 			errStr := err.Error()
 			if errStr != tc.err {
 				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
@@ -2305,6 +3242,41 @@ func TestFailResolveTemplateParameters(t *testing.T) {
 	}
 }
 
+func TestTemplateParamNames(t *testing.T) {
+	tcs := []struct {
+		name      string
+		statement string
+		want      []string
+	}{
+		{
+			name:      "no template parameters",
+			statement: "SELECT * FROM hotels WHERE id = $1",
+			want:      nil,
+		},
+		{
+			name:      "repeated and array parameters",
+			statement: "SELECT {{array .columnNames}} FROM {{.tableName}} JOIN {{.tableName}}",
+			want:      []string{"columnNames", "tableName"},
+		},
+		{
+			name:      "control structures",
+			statement: "SELECT * FROM t {{if .filter}}WHERE {{.filter}}{{else}}{{.fallback}}{{end}}",
+			want:      []string{"filter", "fallback"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parameters.TemplateParamNames(tc.statement)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect names: diff %v", diff)
+			}
+		})
+	}
+}
+
 func TestCheckParamRequired(t *testing.T) {
 	tcs := []struct {
 		name     string
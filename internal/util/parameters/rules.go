@@ -0,0 +1,197 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Rule is a check across the parameters of a tool, e.g. that exactly one of
+// two parameters is given. Exactly one of its checks is set.
+type Rule struct {
+	ExactlyOneOf []string `yaml:"exactlyOneOf"`
+	AtLeastOneOf []string `yaml:"atLeastOneOf"`
+	AtMostOneOf  []string `yaml:"atMostOneOf"`
+	AllOrNoneOf  []string `yaml:"allOrNoneOf"`
+	// Check is a Go template that must output "true" for valid values.
+	Check string `yaml:"check"`
+	// Message replaces the error returned when the rule is broken.
+	Message string `yaml:"message"`
+}
+
+// names returns the parameters a rule lists, or nil for checks.
+func (r Rule) names() []string {
+	return slices.Concat(r.ExactlyOneOf, r.AtLeastOneOf, r.AtMostOneOf, r.AllOrNoneOf)
+}
+
+// name returns the name of the rule's check.
+func (r Rule) name() string {
+	var names []string
+	if r.ExactlyOneOf != nil {
+		names = append(names, "exactlyOneOf")
+	}
+	if r.AtLeastOneOf != nil {
+		names = append(names, "atLeastOneOf")
+	}
+	if r.AtMostOneOf != nil {
+		names = append(names, "atMostOneOf")
+	}
+	if r.AllOrNoneOf != nil {
+		names = append(names, "allOrNoneOf")
+	}
+	if r.Check != "" {
+		names = append(names, "check")
+	}
+	if len(names) != 1 {
+		return ""
+	}
+	return names[0]
+}
+
+// ruleFuncs returns the functions available to the templates of checks, which
+// are those of computed parameters and:
+//   - set: whether a value is given, i.e. not null
+//   - before: whether a date or datetime is before another
+func ruleFuncs(claimsMap map[string]map[string]any) template.FuncMap {
+	funcs := computedFuncs(claimsMap)
+	funcs["set"] = func(v any) bool {
+		return !IsNull(v)
+	}
+	funcs["before"] = func(a, b any) (bool, error) {
+		at, err := toTime(a)
+		if err != nil {
+			return false, err
+		}
+		bt, err := toTime(b)
+		if err != nil {
+			return false, err
+		}
+		return at.Before(bt), nil
+	}
+	return funcs
+}
+
+func toTime(v any) (time.Time, error) {
+	switch v := v.(type) {
+	case Date:
+		return v.Time()
+	case DateTime:
+		return v.Time()
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t, nil
+		}
+		return time.Parse(time.DateOnly, v)
+	}
+	return time.Time{}, fmt.Errorf("%v is not a date or datetime", v)
+}
+
+// CheckRules verifies that rules are valid and only list parameters in names.
+func CheckRules(rules []Rule, names []string) error {
+	for i, r := range rules {
+		switch r.name() {
+		case "":
+			return fmt.Errorf("rule #%d must set exactly one of exactlyOneOf, atLeastOneOf, atMostOneOf, allOrNoneOf or check", i)
+		case "check":
+			if _, err := template.New("check").Funcs(ruleFuncs(nil)).Parse(r.Check); err != nil {
+				return fmt.Errorf("rule #%d: unable to parse check: %w", i, err)
+			}
+			continue
+		}
+		if len(r.names()) < 2 {
+			return fmt.Errorf("rule #%d (%s) must list at least two parameters", i, r.name())
+		}
+		for _, n := range r.names() {
+			if !slices.Contains(names, n) {
+				return fmt.Errorf("rule #%d (%s) lists unknown parameter %q", i, r.name(), n)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateRules checks parameter values against rules, in order, and returns
+// an error describing the first rule they break.
+func ValidateRules(rules []Rule, values ParamValues, claimsMap map[string]map[string]any) error {
+	data := values.AsMap()
+	for i, r := range rules {
+		var given []string
+		for _, n := range r.names() {
+			if !IsNull(data[n]) {
+				given = append(given, n)
+			}
+		}
+		var broken string
+		switch {
+		case r.ExactlyOneOf != nil && len(given) != 1:
+			broken = fmt.Sprintf("exactly one of %s must be given, got %s", describe(r.ExactlyOneOf), describe(given))
+		case r.AtLeastOneOf != nil && len(given) == 0:
+			broken = fmt.Sprintf("at least one of %s must be given", describe(r.AtLeastOneOf))
+		case r.AtMostOneOf != nil && len(given) > 1:
+			broken = fmt.Sprintf("at most one of %s can be given, got %s", describe(r.AtMostOneOf), describe(given))
+		case r.AllOrNoneOf != nil && len(given) != 0 && len(given) != len(r.AllOrNoneOf):
+			broken = fmt.Sprintf("%s must be given together, got %s", describe(r.AllOrNoneOf), describe(given))
+		case r.Check != "":
+			ok, err := runCheck(r.Check, data, claimsMap)
+			if err != nil {
+				return fmt.Errorf("unable to check rule #%d: %w", i, err)
+			}
+			if !ok {
+				broken = fmt.Sprintf("parameters must satisfy %s", r.Check)
+			}
+		}
+		if broken == "" {
+			continue
+		}
+		if r.Message != "" {
+			return errors.New(r.Message)
+		}
+		return errors.New(broken)
+	}
+	return nil
+}
+
+func runCheck(check string, data map[string]any, claimsMap map[string]map[string]any) (bool, error) {
+	t, err := template.New("check").Funcs(ruleFuncs(claimsMap)).Option("missingkey=error").Parse(check)
+	if err != nil {
+		return false, err
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return false, err
+	}
+	switch s := strings.TrimSpace(out.String()); s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("check must output true or false, got %q", s)
+	}
+}
+
+// describe lists parameter names for error messages.
+func describe(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
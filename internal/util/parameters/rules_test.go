@@ -0,0 +1,185 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+func TestValidateRules(t *testing.T) {
+	tcs := []struct {
+		name   string
+		rule   parameters.Rule
+		values parameters.ParamValues
+		want   string
+	}{
+		{
+			name:   "exactly one given",
+			rule:   parameters.Rule{ExactlyOneOf: []string{"id", "email"}},
+			values: parameters.ParamValues{{Name: "id", Value: 1}, {Name: "email", Value: nil}},
+		},
+		{
+			name:   "exactly one, none given",
+			rule:   parameters.Rule{ExactlyOneOf: []string{"id", "email"}},
+			values: parameters.ParamValues{{Name: "id", Value: nil}, {Name: "email", Value: nil}},
+			want:   "exactly one of id, email must be given, got none",
+		},
+		{
+			name:   "exactly one, both given",
+			rule:   parameters.Rule{ExactlyOneOf: []string{"id", "email"}},
+			values: parameters.ParamValues{{Name: "id", Value: 1}, {Name: "email", Value: "a@b.c"}},
+			want:   "exactly one of id, email must be given, got id, email",
+		},
+		{
+			name:   "at least one, none given",
+			rule:   parameters.Rule{AtLeastOneOf: []string{"name", "city"}},
+			values: parameters.ParamValues{{Name: "name", Value: nil}},
+			want:   "at least one of name, city must be given",
+		},
+		{
+			name:   "at most one, both given",
+			rule:   parameters.Rule{AtMostOneOf: []string{"name", "city"}},
+			values: parameters.ParamValues{{Name: "name", Value: "a"}, {Name: "city", Value: "b"}},
+			want:   "at most one of name, city can be given, got name, city",
+		},
+		{
+			name:   "all or none, none given",
+			rule:   parameters.Rule{AllOrNoneOf: []string{"lat", "lng"}},
+			values: parameters.ParamValues{{Name: "lat", Value: nil}, {Name: "lng", Value: nil}},
+		},
+		{
+			name:   "all or none, one given",
+			rule:   parameters.Rule{AllOrNoneOf: []string{"lat", "lng"}},
+			values: parameters.ParamValues{{Name: "lat", Value: 1.5}, {Name: "lng", Value: nil}},
+			want:   "lat, lng must be given together, got lat",
+		},
+		{
+			name:   "check passes",
+			rule:   parameters.Rule{Check: "{{ before .start .end }}"},
+			values: parameters.ParamValues{{Name: "start", Value: parameters.Date("2025-01-01")}, {Name: "end", Value: parameters.DateTime("2025-02-01T00:00:00Z")}},
+		},
+		{
+			name:   "check fails",
+			rule:   parameters.Rule{Check: "{{ or (set .filter) (le .limit 100) }}"},
+			values: parameters.ParamValues{{Name: "filter", Value: nil}, {Name: "limit", Value: 500}},
+			want:   "parameters must satisfy {{ or (set .filter) (le .limit 100) }}",
+		},
+		{
+			name:   "message",
+			rule:   parameters.Rule{Check: "{{ before .start .end }}", Message: "start must be before end"},
+			values: parameters.ParamValues{{Name: "start", Value: parameters.Date("2025-03-01")}, {Name: "end", Value: parameters.Date("2025-02-01")}},
+			want:   "start must be before end",
+		},
+		{
+			name:   "check output",
+			rule:   parameters.Rule{Check: "{{ .limit }}"},
+			values: parameters.ParamValues{{Name: "limit", Value: 5}},
+			want:   `unable to check rule #0: check must output true or false, got "5"`,
+		},
+		{
+			name:   "check unknown parameter",
+			rule:   parameters.Rule{Check: "{{ set .other }}"},
+			values: parameters.ParamValues{{Name: "limit", Value: 5}},
+			want:   "unable to check rule #0",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := parameters.ValidateRules([]parameters.Rule{tc.rule}, tc.values, nil)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestValidateRulesNullable(t *testing.T) {
+	email := parameters.NewStringParameter("email", "the user's email")
+	email.Nullable = true
+	ps := parameters.Parameters{parameters.NewIntParameter("id", "the user's id"), email}
+	values, err := parameters.ParseParams(ps, map[string]any{"id": 5, "email": nil}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rules := []parameters.Rule{
+		{ExactlyOneOf: []string{"id", "email"}},
+		{Check: "{{ not (set .email) }}"},
+	}
+	if err := parameters.ValidateRules(rules, values, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestCheckRules(t *testing.T) {
+	names := []string{"id", "email"}
+	tcs := []struct {
+		name string
+		rule parameters.Rule
+		want string
+	}{
+		{
+			name: "valid",
+			rule: parameters.Rule{ExactlyOneOf: []string{"id", "email"}},
+		},
+		{
+			name: "no check",
+			rule: parameters.Rule{Message: "oops"},
+			want: "rule #0 must set exactly one of",
+		},
+		{
+			name: "two checks",
+			rule: parameters.Rule{ExactlyOneOf: []string{"id", "email"}, Check: "{{ true }}"},
+			want: "rule #0 must set exactly one of",
+		},
+		{
+			name: "one parameter",
+			rule: parameters.Rule{AtLeastOneOf: []string{"id"}},
+			want: "rule #0 (atLeastOneOf) must list at least two parameters",
+		},
+		{
+			name: "unknown parameter",
+			rule: parameters.Rule{AtMostOneOf: []string{"id", "phone"}},
+			want: `rule #0 (atMostOneOf) lists unknown parameter "phone"`,
+		},
+		{
+			name: "invalid check",
+			rule: parameters.Rule{Check: "{{ before .a }"},
+			want: "rule #0: unable to parse check",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := parameters.CheckRules([]parameters.Rule{tc.rule}, names)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
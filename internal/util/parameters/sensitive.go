@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Redacted replaces sensitive values in logs and error messages.
+const Redacted = "[REDACTED]"
+
+// RedactError returns err with the text of sensitive values replaced, e.g.
+// where a source echoes a value back in its error. It returns err unchanged
+// if no values are sensitive.
+func (p ParamValues) RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var values []any
+	for _, v := range p {
+		if v.Sensitive {
+			values = append(values, v.Value)
+		}
+	}
+	return redactError(err, values...)
+}
+
+// redactedError keeps the error it redacts, so that errors.Is and errors.As
+// still work.
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e redactedError) Error() string {
+	return e.msg
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactError replaces the text of values in the message of err.
+func redactError(err error, values ...any) error {
+	msg := err.Error()
+	for _, v := range values {
+		for _, text := range redactTexts(v) {
+			msg = redactToken(msg, text)
+		}
+	}
+	if msg == err.Error() {
+		return err
+	}
+	return redactedError{err: err, msg: msg}
+}
+
+// redactToken replaces the occurrences of text in msg that aren't part of a
+// longer word, so that short values such as 5 don't redact "Error 1054".
+func redactToken(msg, text string) string {
+	var b strings.Builder
+	written := 0
+	for from := 0; ; {
+		i := strings.Index(msg[from:], text)
+		if i < 0 {
+			break
+		}
+		start, end := from+i, from+i+len(text)
+		if !isToken(msg, start, end) {
+			_, size := utf8.DecodeRuneInString(msg[start:])
+			from = start + size
+			continue
+		}
+		b.WriteString(msg[written:start])
+		b.WriteString(Redacted)
+		written, from = end, end
+	}
+	if written == 0 {
+		return msg
+	}
+	b.WriteString(msg[written:])
+	return b.String()
+}
+
+// isToken returns whether msg[start:end] isn't joined to a letter, digit or
+// underscore on either side.
+func isToken(msg string, start, end int) bool {
+	if start > 0 {
+		before, _ := utf8.DecodeLastRuneInString(msg[:start])
+		first, _ := utf8.DecodeRuneInString(msg[start:end])
+		if isWordRune(before) && isWordRune(first) {
+			return false
+		}
+	}
+	if end < len(msg) {
+		last, _ := utf8.DecodeLastRuneInString(msg[start:end])
+		after, _ := utf8.DecodeRuneInString(msg[end:])
+		if isWordRune(last) && isWordRune(after) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// redactTexts returns the ways a value can be written in an error message.
+func redactTexts(v any) []string {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		var texts []string
+		for _, item := range v {
+			texts = append(texts, redactTexts(item)...)
+		}
+		return texts
+	case map[string]any:
+		var texts []string
+		for _, prop := range v {
+			texts = append(texts, redactTexts(prop)...)
+		}
+		return texts
+	}
+	// typed nulls of nullable parameters
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+	text := fmt.Sprint(v)
+	if text == "" {
+		return nil
+	}
+	// quoted first, so that escaped quotes are replaced as a whole
+	return []string{fmt.Sprintf("%q", text), text}
+}
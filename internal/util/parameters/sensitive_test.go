@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+func TestSensitiveParams(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: user
+  type: string
+  description: name of the user
+- name: api_token
+  type: string
+  description: token of the API
+  sensitive: true
+  minLength: 8
+- name: header
+  type: string
+  description: authorization header
+  computed: "Bearer {{ .api_token }}"
+  sensitive: true
+`
+	var params parameters.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	manifest := params.Manifest()
+	if manifest[0].Sensitive || !manifest[1].Sensitive {
+		t.Fatalf("incorrect sensitive manifests: %+v", manifest)
+	}
+
+	values, err := parameters.ParseParams(params, map[string]any{"user": "alice", "api_token": "s3cr3t-token"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := parameters.ParamValues{
+		{Name: "user", Value: "alice"},
+		{Name: "api_token", Value: "s3cr3t-token", Sensitive: true},
+		{Name: "header", Value: "Bearer s3cr3t-token", Sensitive: true},
+	}
+	if diff := cmp.Diff(want, values); diff != "" {
+		t.Fatalf("incorrect values (-want +got):\n%s", diff)
+	}
+	if got, want := fmt.Sprintf("%s", values), "[{user alice} {api_token [REDACTED]} {header [REDACTED]}]"; got != want {
+		t.Fatalf("incorrect formatting: got %q, want %q", got, want)
+	}
+
+	// sources may echo values back in their errors
+	sourceErr := fmt.Errorf(`unauthorized token "s3cr3t-token" for alice`)
+	err = values.RedactError(sourceErr)
+	if got, want := err.Error(), "unauthorized token [REDACTED] for alice"; got != want {
+		t.Fatalf("incorrect redacted error: got %q, want %q", got, want)
+	}
+	if !errors.Is(err, sourceErr) {
+		t.Fatalf("redacted error should wrap %v", sourceErr)
+	}
+
+	// only whole values are redacted, not parts of longer words
+	pin := parameters.ParamValues{{Name: "pin", Value: 5, Sensitive: true}}
+	err = pin.RedactError(fmt.Errorf("Error 1054: pin 5 rejected, 5th attempt"))
+	if got, want := err.Error(), "Error 1054: pin [REDACTED] rejected, 5th attempt"; got != want {
+		t.Fatalf("incorrect redacted error: got %q, want %q", got, want)
+	}
+
+	// invalid values aren't echoed back either
+	_, err = parameters.ParseParams(params, map[string]any{"user": "alice", "api_token": "short"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "[REDACTED] is shorter") {
+		t.Fatalf("expected an error without the value, got %v", err)
+	}
+}
+
+func TestSensitiveManifest(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: api_token
+  type: string
+  description: token of the API
+  sensitive: true
+  default: hunter2
+  allowedValues: ["hunter2", "swordfish"]
+`
+	var params parameters.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+
+	manifest := params.Manifest()
+	if manifest[0].Default != nil || manifest[0].Enum != nil {
+		t.Fatalf("manifest exposes the default or allowed values: %+v", manifest[0])
+	}
+	mcpManifest, _ := params.McpManifest()
+	if p := mcpManifest.Properties["api_token"]; p.Default != nil || p.Enum != nil {
+		t.Fatalf("mcp manifest exposes the default or allowed values: %+v", p)
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// positions of the wildcards added by "like" transforms
+const (
+	likePrefix   = "prefix"
+	likeSuffix   = "suffix"
+	likeContains = "contains"
+)
+
+// Transform is a step that normalizes the values of a parameter before they're
+// validated, e.g. `trim: true`. Exactly one of its fields is set.
+type Transform struct {
+	Trim    bool              `yaml:"trim"`
+	Lower   bool              `yaml:"lower"`
+	Upper   bool              `yaml:"upper"`
+	Replace *ReplaceTransform `yaml:"replace"`
+	Like    string            `yaml:"like" validate:"omitempty,oneof=prefix suffix contains"`
+	Split   *string           `yaml:"split"`
+	Convert *ConvertTransform `yaml:"convert"`
+}
+
+// ReplaceTransform replaces the matches of a regular expression.
+type ReplaceTransform struct {
+	Pattern string `yaml:"pattern" validate:"required"`
+	With    string `yaml:"with"`
+}
+
+// ConvertTransform converts a number from one unit to another.
+type ConvertTransform struct {
+	From string `yaml:"from" validate:"required"`
+	To   string `yaml:"to" validate:"required"`
+}
+
+// unit is a unit of measure, converted to the base unit of its quantity as
+// value*factor + offset.
+type unit struct {
+	quantity string
+	factor   float64
+	offset   float64
+}
+
+var units = map[string]unit{
+	// lengths, in meters
+	"mm": {"length", 0.001, 0},
+	"cm": {"length", 0.01, 0},
+	"m":  {"length", 1, 0},
+	"km": {"length", 1000, 0},
+	"in": {"length", 0.0254, 0},
+	"ft": {"length", 0.3048, 0},
+	"yd": {"length", 0.9144, 0},
+	"mi": {"length", 1609.344, 0},
+	// masses, in grams
+	"mg": {"mass", 0.001, 0},
+	"g":  {"mass", 1, 0},
+	"kg": {"mass", 1000, 0},
+	"oz": {"mass", 28.349523125, 0},
+	"lb": {"mass", 453.59237, 0},
+	// durations, in seconds
+	"ms":  {"duration", 0.001, 0},
+	"s":   {"duration", 1, 0},
+	"min": {"duration", 60, 0},
+	"h":   {"duration", 3600, 0},
+	"d":   {"duration", 86400, 0},
+	// temperatures, in degrees Celsius
+	"C": {"temperature", 1, 0},
+	"F": {"temperature", 5.0 / 9, -32 * 5.0 / 9},
+	"K": {"temperature", 1, -273.15},
+	// amounts of data, in bytes
+	"B":   {"data", 1, 0},
+	"KB":  {"data", 1e3, 0},
+	"MB":  {"data", 1e6, 0},
+	"GB":  {"data", 1e9, 0},
+	"TB":  {"data", 1e12, 0},
+	"KiB": {"data", 1 << 10, 0},
+	"MiB": {"data", 1 << 20, 0},
+	"GiB": {"data", 1 << 30, 0},
+	"TiB": {"data", 1 << 40, 0},
+}
+
+// patterns caches the regular expressions of parameters by pattern, so that
+// they're compiled once when the parameter is loaded rather than on every
+// invocation.
+var patterns sync.Map
+
+// compilePattern returns the compiled regular expression of a pattern.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns, and the backslash that
+// escapes them.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// likeBracketEscaper escapes the "[" that starts a character range in the LIKE
+// patterns of SQL Server.
+var likeBracketEscaper = strings.NewReplacer("[", `\[`)
+
+// likeEscapeClause matches the ESCAPE clause that declares the backslash as
+// the escape character of a LIKE pattern.
+var likeEscapeClause = regexp.MustCompile(`(?i)\bESCAPE\s+'\\'`)
+
+// name returns the name of the transform's step.
+func (t Transform) name() string {
+	var names []string
+	if t.Trim {
+		names = append(names, "trim")
+	}
+	if t.Lower {
+		names = append(names, "lower")
+	}
+	if t.Upper {
+		names = append(names, "upper")
+	}
+	if t.Replace != nil {
+		names = append(names, "replace")
+	}
+	if t.Like != "" {
+		names = append(names, "like")
+	}
+	if t.Split != nil {
+		names = append(names, "split")
+	}
+	if t.Convert != nil {
+		names = append(names, "convert")
+	}
+	if len(names) != 1 {
+		return ""
+	}
+	return names[0]
+}
+
+// checkTransforms verifies that the transforms of a parameter are valid and
+// apply to its type.
+func checkTransforms(p Parameter) error {
+	typ := p.GetType()
+	for i, t := range p.GetTransforms() {
+		var types []string
+		switch t.name() {
+		case "":
+			return fmt.Errorf("transform #%d must set exactly one of trim, lower, upper, replace, like, split or convert", i)
+		case "trim", "lower", "upper", "replace":
+			types = []string{TypeString, TypeIdentifier, TypeKeyword}
+		case "like":
+			types = []string{TypeString}
+		case "split":
+			types = []string{TypeArray, TypeIdentifierList}
+		case "convert":
+			types = []string{TypeInt, TypeFloat}
+		}
+		if !slices.Contains(types, typ) {
+			return fmt.Errorf("transform #%d (%s) doesn't apply to %q parameters", i, t.name(), typ)
+		}
+		if t.Replace != nil {
+			if _, err := compilePattern(t.Replace.Pattern); err != nil {
+				return fmt.Errorf("transform #%d (replace): invalid pattern: %w", i, err)
+			}
+		}
+		if t.Convert != nil {
+			from, ok := units[t.Convert.From]
+			if !ok {
+				return fmt.Errorf("transform #%d (convert): unknown unit %q", i, t.Convert.From)
+			}
+			to, ok := units[t.Convert.To]
+			if !ok {
+				return fmt.Errorf("transform #%d (convert): unknown unit %q", i, t.Convert.To)
+			}
+			if from.quantity != to.quantity {
+				return fmt.Errorf("transform #%d (convert): unable to convert %s to %s", i, t.Convert.From, t.Convert.To)
+			}
+		}
+	}
+	return nil
+}
+
+// transform applies the transforms of the parameter to a value, in order.
+// Values of other types than a step applies to are left as they are, and
+// rejected by Parse.
+func (p *CommonParameter) transform(v any) (any, error) {
+	for _, t := range p.Transforms {
+		s, isString := v.(string)
+		switch {
+		case t.Trim && isString:
+			v = strings.TrimSpace(s)
+		case t.Lower && isString:
+			v = strings.ToLower(s)
+		case t.Upper && isString:
+			v = strings.ToUpper(s)
+		case t.Replace != nil && isString:
+			re, err := compilePattern(t.Replace.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", t.Replace.Pattern, err)
+			}
+			v = re.ReplaceAllString(s, t.Replace.With)
+		case t.Like != "" && isString:
+			// wildcards in the value match themselves, escaped with a
+			// backslash
+			s = likeEscaper.Replace(s)
+			switch t.Like {
+			case likePrefix:
+				v = s + "%"
+			case likeSuffix:
+				v = "%" + s
+			case likeContains:
+				v = "%" + s + "%"
+			}
+		case t.Split != nil && isString:
+			// an empty string is an empty list, rather than a list of ""
+			items := make([]any, 0)
+			if s != "" {
+				for _, item := range strings.Split(s, *t.Split) {
+					items = append(items, item)
+				}
+			}
+			v = items
+		case t.Convert != nil:
+			n, err := toFloat(v)
+			if err != nil {
+				continue
+			}
+			out, err := p.convert(n, t.Convert)
+			if err != nil {
+				return nil, err
+			}
+			v = out
+		}
+	}
+	return v, nil
+}
+
+// convert converts a number between units. Results are rounded to 12
+// significant digits, so that e.g. 1.1 km is 1100 m rather than
+// 1100.0000000000002 m, and must be whole numbers for integer parameters.
+func (p *CommonParameter) convert(n float64, c *ConvertTransform) (any, error) {
+	from, ok := units[c.From]
+	if !ok {
+		return nil, fmt.Errorf("unknown unit %q", c.From)
+	}
+	to, ok := units[c.To]
+	if !ok {
+		return nil, fmt.Errorf("unknown unit %q", c.To)
+	}
+	out := (n*from.factor + from.offset - to.offset) / to.factor
+	out, err := strconv.ParseFloat(strconv.FormatFloat(out, 'g', 12, 64), 64)
+	if err != nil {
+		return nil, err
+	}
+	if p.Type != TypeInt {
+		return out, nil
+	}
+	if out != math.Trunc(out) || math.Abs(out) > math.MaxInt64 {
+		return nil, fmt.Errorf("%g %s is not a whole number of %s", n, c.From, c.To)
+	}
+	return int(out), nil
+}
+
+// hasLike reports whether a parameter, or the items of an array parameter,
+// makes its values into LIKE patterns.
+func hasLike(p Parameter) bool {
+	if a, ok := p.(*ArrayParameter); ok && hasLike(a.GetItems()) {
+		return true
+	}
+	return slices.ContainsFunc(p.GetTransforms(), func(t Transform) bool { return t.Like != "" })
+}
+
+// CheckLikeEscape verifies that a statement declares the backslash as the
+// escape character of LIKE patterns if any of params is made into one. It's
+// used for dialects with no default escape character, such as SQL Server,
+// SQLite, Oracle, Trino and Firebird.
+func CheckLikeEscape(statement string, params Parameters) error {
+	for _, p := range params {
+		if hasLike(p) && !likeEscapeClause.MatchString(statement) {
+			return fmt.Errorf(`parameter %q is a LIKE pattern, so the statement must declare its escape character with ESCAPE '\'`, p.GetName())
+		}
+	}
+	return nil
+}
+
+// EscapeLikeBrackets escapes "[" in the values of parameters made into LIKE
+// patterns, which SQL Server treats as the start of a character range. Like
+// the wildcards, it's escaped with a backslash.
+func EscapeLikeBrackets(params Parameters, values ParamValues) ParamValues {
+	likes := make(map[string]bool)
+	for _, p := range params {
+		if hasLike(p) {
+			likes[p.GetName()] = true
+		}
+	}
+	if len(likes) == 0 {
+		return values
+	}
+	escaped := slices.Clone(values)
+	for i, v := range escaped {
+		if !likes[v.Name] {
+			continue
+		}
+		switch val := v.Value.(type) {
+		case string:
+			escaped[i].Value = likeBracketEscaper.Replace(val)
+		case []any:
+			items := make([]any, len(val))
+			for j, item := range val {
+				if s, ok := item.(string); ok {
+					item = likeBracketEscaper.Replace(s)
+				}
+				items[j] = item
+			}
+			escaped[i].Value = items
+		}
+	}
+	return escaped
+}
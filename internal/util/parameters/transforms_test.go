@@ -0,0 +1,404 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters_test
+
+import (
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+func TestTransforms(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		name    string
+		param   string
+		in      any
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "trim and lower",
+			param: `
+name: company
+type: string
+description: company name
+transforms:
+  - trim: true
+  - lower: true`,
+			in:   "  ACME Corp ",
+			want: "acme corp",
+		},
+		{
+			name: "replace and like",
+			param: `
+name: company
+type: string
+description: company name
+transforms:
+  - replace: {pattern: '\s+', with: ' '}
+  - upper: true
+  - like: contains`,
+			in:   "acme   corp",
+			want: "%ACME CORP%",
+		},
+		{
+			name: "like escapes wildcards",
+			param: `
+name: path
+type: string
+description: path prefix
+transforms:
+  - like: prefix`,
+			in:   `100%_off\`,
+			want: `100\%\_off\\%`,
+		},
+		{
+			name: "transforms run before validation",
+			param: `
+name: status
+type: string
+description: status
+allowedValues: ["open", "closed"]
+transforms:
+  - lower: true`,
+			in:   "OPEN",
+			want: "open",
+		},
+		{
+			name: "split into array",
+			param: `
+name: tags
+type: array
+description: tags
+transforms:
+  - split: ","
+items:
+  name: tag
+  type: string
+  description: tag
+  transforms:
+    - trim: true`,
+			in:   "red, green ,blue",
+			want: []any{"red", "green", "blue"},
+		},
+		{
+			name: "split empty string",
+			param: `
+name: tags
+type: array
+description: tags
+transforms:
+  - split: ","
+items:
+  name: tag
+  type: string
+  description: tag`,
+			in:   "",
+			want: []any{},
+		},
+		{
+			name: "array given as array",
+			param: `
+name: tags
+type: array
+description: tags
+transforms:
+  - split: ","
+items:
+  name: tag
+  type: string
+  description: tag`,
+			in:   []any{"red", "green"},
+			want: []any{"red", "green"},
+		},
+		{
+			name: "convert to integer",
+			param: `
+name: distance
+type: integer
+description: distance in km
+transforms:
+  - convert: {from: km, to: m}`,
+			in:   1.1,
+			want: 1100,
+		},
+		{
+			name: "convert temperature",
+			param: `
+name: temperature
+type: float
+description: temperature in Fahrenheit
+transforms:
+  - convert: {from: F, to: C}`,
+			in:   212,
+			want: 100.0,
+		},
+		{
+			name: "convert to fraction of integer",
+			param: `
+name: distance
+type: integer
+description: distance in m
+transforms:
+  - convert: {from: m, to: km}`,
+			in:      1500,
+			wantErr: true,
+		},
+		{
+			name: "wrong type is still rejected",
+			param: `
+name: company
+type: string
+description: company name
+transforms:
+  - trim: true`,
+			in:      42,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var params parameters.Parameters
+			if err := yaml.UnmarshalContext(ctx, []byte("- "+strings.ReplaceAll(strings.TrimSpace(tc.param), "\n", "\n  ")), &params); err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			got, err := params[0].Parse(tc.in)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect value (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFailTransformsUnmarshal(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		name  string
+		param string
+		err   string
+	}{
+		{
+			name: "no step",
+			param: `
+name: company
+type: string
+description: company name
+transforms:
+  - {}`,
+			err: "transform #0 must set exactly one of trim, lower, upper, replace, like, split or convert",
+		},
+		{
+			name: "two steps",
+			param: `
+name: company
+type: string
+description: company name
+transforms:
+  - {trim: true, lower: true}`,
+			err: "transform #0 must set exactly one of trim, lower, upper, replace, like, split or convert",
+		},
+		{
+			name: "wrong type",
+			param: `
+name: count
+type: integer
+description: count
+transforms:
+  - trim: true`,
+			err: `transform #0 (trim) doesn't apply to "integer" parameters`,
+		},
+		{
+			name: "invalid like",
+			param: `
+name: company
+type: string
+description: company name
+transforms:
+  - like: both`,
+			err: "oneof",
+		},
+		{
+			name: "invalid pattern",
+			param: `
+name: company
+type: string
+description: company name
+transforms:
+  - replace: {pattern: '[a-', with: ''}`,
+			err: "transform #0 (replace): invalid pattern",
+		},
+		{
+			name: "incompatible units",
+			param: `
+name: distance
+type: float
+description: distance
+transforms:
+  - convert: {from: km, to: kg}`,
+			err: "transform #0 (convert): unable to convert km to kg",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var params parameters.Parameters
+			err := yaml.UnmarshalContext(ctx, []byte("- "+strings.ReplaceAll(strings.TrimSpace(tc.param), "\n", "\n  ")), &params)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want it to contain %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestLikeDialects(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var params parameters.Parameters
+	in := `
+- name: name
+  type: string
+  description: name
+  transforms:
+    - like: contains
+- name: tags
+  type: array
+  description: tags
+  items:
+    name: tag
+    type: string
+    description: tag
+    transforms:
+      - like: prefix
+- name: id
+  type: string
+  description: id`
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	values, err := parameters.ParseParams(params, map[string]any{"name": `[a]_%\`, "tags": []any{"[x"}, "id": "[1]"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// PostgreSQL, MySQL, SQLite and Oracle only need the wildcards and the
+	// backslash escaped
+	want := parameters.ParamValues{
+		{Name: "name", Value: `%[a]\_\%\\%`},
+		{Name: "tags", Value: []any{`[x%`}},
+		{Name: "id", Value: "[1]"},
+	}
+	if diff := cmp.Diff(want, values); diff != "" {
+		t.Fatalf("incorrect values (-want +got):\n%s", diff)
+	}
+
+	// SQL Server also treats "[" as the start of a character range
+	want = parameters.ParamValues{
+		{Name: "name", Value: `%\[a]\_\%\\%`},
+		{Name: "tags", Value: []any{`\[x%`}},
+		{Name: "id", Value: "[1]"},
+	}
+	if diff := cmp.Diff(want, parameters.EscapeLikeBrackets(params, values)); diff != "" {
+		t.Fatalf("incorrect SQL Server values (-want +got):\n%s", diff)
+	}
+}
+
+func TestCheckLikeEscape(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var params parameters.Parameters
+	in := `
+- name: name
+  type: string
+  description: name
+  transforms:
+    - like: contains`
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	tcs := []struct {
+		name      string
+		statement string
+		params    parameters.Parameters
+		err       string
+	}{
+		{
+			name:      "escape clause",
+			statement: `SELECT * FROM t WHERE name LIKE @name ESCAPE '\'`,
+			params:    params,
+		},
+		{
+			name:      "lowercase escape clause",
+			statement: `SELECT * FROM t WHERE name like @name escape '\'`,
+			params:    params,
+		},
+		{
+			name:      "no like parameters",
+			statement: "SELECT * FROM t WHERE name = @name",
+			params:    parameters.Parameters{parameters.NewStringParameter("name", "name")},
+		},
+		{
+			name:      "missing escape clause",
+			statement: "SELECT * FROM t WHERE name LIKE @name",
+			params:    params,
+			err:       `parameter "name" is a LIKE pattern, so the statement must declare its escape character with ESCAPE '\'`,
+		},
+		{
+			name:      "other escape character",
+			statement: "SELECT * FROM t WHERE name LIKE @name ESCAPE '!'",
+			params:    params,
+			err:       `parameter "name" is a LIKE pattern`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := parameters.CheckLikeEscape(tc.statement, tc.params)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want it to contain %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltx
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PgxArgs converts the values of date, datetime, uuid and decimal parameters
+// to the types pgx binds natively, so that statements don't depend on casts
+// from text. Embedded vectors are bound as pgvector's text format, such as
+// "[1,2.5]", since pgx would encode them as Postgres arrays, which can't be
+// cast to vector. Other values are left as they are.
+func PgxArgs(args []any) ([]any, error) {
+	return convertArgs(args, func(v any) (any, error) {
+		switch v := v.(type) {
+		case parameters.Date:
+			t, err := v.Time()
+			if err != nil {
+				return nil, err
+			}
+			return pgtype.Date{Time: t, Valid: true}, nil
+		case parameters.DateTime:
+			return v.Time()
+		case parameters.UUID:
+			u, err := uuid.Parse(string(v))
+			if err != nil {
+				return nil, err
+			}
+			return pgtype.UUID{Bytes: u, Valid: true}, nil
+		case parameters.Decimal:
+			var n pgtype.Numeric
+			if err := n.Scan(string(v)); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case []float64:
+			return vectorLiteral(v), nil
+		}
+		return v, nil
+	})
+}
+
+// vectorLiteral formats a vector in pgvector's text format.
+func vectorLiteral(v []float64) string {
+	elems := make([]string, len(v))
+	for i, f := range v {
+		elems[i] = strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return "[" + strings.Join(elems, ",") + "]"
+}
+
+// DBArgs converts the values of date, datetime, uuid and decimal parameters
+// to the types database/sql drivers bind natively. Dates and datetimes are
+// bound as time.Time, and uuids and decimals as strings, which drivers convert
+// without loss. It's not used for SQLite, which stores dates as text.
+func DBArgs(args []any) ([]any, error) {
+	return convertArgs(args, func(v any) (any, error) {
+		switch v := v.(type) {
+		case parameters.Date:
+			return v.Time()
+		case parameters.DateTime:
+			return v.Time()
+		case parameters.UUID:
+			return string(v), nil
+		case parameters.Decimal:
+			return string(v), nil
+		}
+		return v, nil
+	})
+}
+
+// SpannerArgs converts the values of date, datetime, uuid and decimal
+// parameters in Spanner's named parameters to the types the client binds
+// natively. Dates are bound as civil.Date, datetimes as time.Time and uuids as
+// strings. Decimals are bound as NUMERIC, which is a big.Rat in GoogleSQL and
+// a PGNumeric in the PostgreSQL dialect. The client binds typed nils of most
+// types as NULLs, but not *int, so null integers are bound as NullInt64.
+func SpannerArgs(args map[string]any, dialect string) (map[string]any, error) {
+	return convertNamedArgs(args, func(v any) (any, error) {
+		switch v := v.(type) {
+		case parameters.Date:
+			return civil.ParseDate(string(v))
+		case parameters.DateTime:
+			return v.Time()
+		case parameters.UUID:
+			return string(v), nil
+		case parameters.Decimal:
+			if strings.EqualFold(dialect, "postgresql") {
+				return spanner.PGNumeric{Numeric: string(v), Valid: true}, nil
+			}
+			r, ok := new(big.Rat).SetString(string(v))
+			if !ok {
+				return nil, fmt.Errorf("invalid decimal")
+			}
+			return r, nil
+		case *int:
+			if v == nil {
+				return spanner.NullInt64{}, nil
+			}
+			return int64(*v), nil
+		}
+		return v, nil
+	})
+}
+
+// BigQueryArgs converts the values of date, datetime, uuid and decimal
+// parameters to the types the BigQuery client binds natively: civil.Date for
+// DATE, time.Time for TIMESTAMP, strings for uuids, and *big.Rat for NUMERIC.
+// The client can't bind nils, so null values of the scalar parameters in ps
+// are bound as NULLs of the parameter's BigQuery type.
+func BigQueryArgs(ps parameters.Parameters, args map[string]any) (map[string]any, error) {
+	out, err := convertNamedArgs(args, func(v any) (any, error) {
+		switch v := v.(type) {
+		case parameters.Date:
+			return civil.ParseDate(string(v))
+		case parameters.DateTime:
+			return v.Time()
+		case parameters.UUID:
+			return string(v), nil
+		case parameters.Decimal:
+			r, ok := new(big.Rat).SetString(string(v))
+			if !ok {
+				return nil, fmt.Errorf("invalid decimal")
+			}
+			return r, nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range ps {
+		name := p.GetName()
+		if v, ok := out[name]; ok && IsNull(v) {
+			if null, ok := bigQueryNull(p.GetType()); ok {
+				out[name] = null
+			}
+		}
+	}
+	return out, nil
+}
+
+// bigQueryNull returns the value the BigQuery client binds as a NULL of a
+// parameter type. NUMERIC has no null type of its own, so it's given as an
+// explicitly typed parameter value.
+func bigQueryNull(paramType string) (any, bool) {
+	switch paramType {
+	case parameters.TypeString, parameters.TypeUUID:
+		return bigquery.NullString{}, true
+	case parameters.TypeInt:
+		return bigquery.NullInt64{}, true
+	case parameters.TypeFloat:
+		return bigquery.NullFloat64{}, true
+	case parameters.TypeBool:
+		return bigquery.NullBool{}, true
+	case parameters.TypeDate:
+		return bigquery.NullDate{}, true
+	case parameters.TypeDateTime:
+		return bigquery.NullTimestamp{}, true
+	case parameters.TypeDecimal:
+		return &bigquery.QueryParameterValue{
+			Type:  bigquery.StandardSQLDataType{TypeKind: "NUMERIC"},
+			Value: bigquery.NullString{},
+		}, true
+	}
+	return nil, false
+}
+
+// IsNull reports whether v is nil or a typed nil pointer, which is how the
+// null value of a nullable parameter is given.
+func IsNull(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+// DBStatements converts the arguments of each statement with DBArgs.
+func DBStatements(stmts []Statement) ([]Statement, error) {
+	out := make([]Statement, len(stmts))
+	for i, stmt := range stmts {
+		args, err := DBArgs(stmt.Args)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %w", i+1, err)
+		}
+		stmt.Args = args
+		out[i] = stmt
+	}
+	return out, nil
+}
+
+// convertArgs applies convert to each argument, and to the elements of
+// arguments that are arrays.
+func convertArgs(args []any, convert func(any) (any, error)) ([]any, error) {
+	out := make([]any, len(args))
+	for i, arg := range args {
+		if arr, ok := arg.([]any); ok {
+			elems, err := convertArgs(arr, convert)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = elems
+			continue
+		}
+		v, err := convert(arg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to bind %q: %w", arg, err)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// convertNamedArgs applies convert to each named argument, and to the
+// elements of arguments that are arrays.
+func convertNamedArgs(args map[string]any, convert func(any) (any, error)) (map[string]any, error) {
+	out := make(map[string]any, len(args))
+	for name, arg := range args {
+		v, err := convertArgs([]any{arg}, convert)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = v[0]
+	}
+	return out, nil
+}
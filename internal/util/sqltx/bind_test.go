@@ -0,0 +1,202 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltx_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestPgxArgs(t *testing.T) {
+	id := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	args := []any{
+		"plain",
+		parameters.Date("2025-03-14"),
+		parameters.DateTime("2025-03-14T09:30:00+02:00"),
+		parameters.UUID(id),
+		parameters.Decimal("12.50"),
+		[]any{parameters.Date("2025-01-01"), 1},
+		[]float64{0.25, -1, 1e-7},
+	}
+	got, err := sqltx.PgxArgs(args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		"plain",
+		pgtype.Date{Time: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), Valid: true},
+		time.Date(2025, 3, 14, 7, 30, 0, 0, time.UTC),
+		pgtype.UUID{Bytes: uuid.MustParse(id), Valid: true},
+		pgtype.Numeric{Int: big.NewInt(1250), Exp: -2, Valid: true},
+		[]any{pgtype.Date{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}, 1},
+		"[0.25,-1,1e-07]",
+	}
+	opts := []cmp.Option{
+		cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) }),
+		cmp.Comparer(func(a, b *big.Int) bool { return a.Cmp(b) == 0 }),
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Fatalf("incorrect args (-want +got):\n%s", diff)
+	}
+}
+
+func TestDBArgs(t *testing.T) {
+	args := []any{
+		parameters.Date("2025-03-14"),
+		parameters.DateTime("2025-03-14T09:30:00Z"),
+		parameters.UUID("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"),
+		parameters.Decimal("12.50"),
+		int64(3),
+	}
+	got, err := sqltx.DBArgs(args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+		"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		"12.50",
+		int64(3),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect args (-want +got):\n%s", diff)
+	}
+}
+
+func TestSpannerArgs(t *testing.T) {
+	args := map[string]any{
+		"date":     parameters.Date("2025-03-14"),
+		"datetime": parameters.DateTime("2025-03-14T09:30:00Z"),
+		"uuid":     parameters.UUID("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"),
+		"decimal":  parameters.Decimal("12.50"),
+		"count":    int64(3),
+	}
+	opts := []cmp.Option{
+		cmp.Comparer(func(a, b *big.Rat) bool { return a.Cmp(b) == 0 }),
+	}
+
+	got, err := sqltx.SpannerArgs(args, "googlesql")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]any{
+		"date":     civil.Date{Year: 2025, Month: time.March, Day: 14},
+		"datetime": time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+		"uuid":     "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		"decimal":  big.NewRat(25, 2),
+		"count":    int64(3),
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Fatalf("incorrect args (-want +got):\n%s", diff)
+	}
+
+	got, err = sqltx.SpannerArgs(args, "postgresql")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want["decimal"] = spanner.PGNumeric{Numeric: "12.50", Valid: true}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Fatalf("incorrect args (-want +got):\n%s", diff)
+	}
+}
+
+func TestBigQueryArgs(t *testing.T) {
+	args := map[string]any{
+		"date":     parameters.Date("2025-03-14"),
+		"datetime": parameters.DateTime("2025-03-14T09:30:00Z"),
+		"uuid":     parameters.UUID("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"),
+		"decimal":  parameters.Decimal("12.50"),
+		"names":    []any{"a", "b"},
+	}
+	got, err := sqltx.BigQueryArgs(nil, args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]any{
+		"date":     civil.Date{Year: 2025, Month: time.March, Day: 14},
+		"datetime": time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+		"uuid":     "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		"decimal":  big.NewRat(25, 2),
+		"names":    []any{"a", "b"},
+	}
+	opts := []cmp.Option{
+		cmp.Comparer(func(a, b *big.Rat) bool { return a.Cmp(b) == 0 }),
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Fatalf("incorrect args (-want +got):\n%s", diff)
+	}
+}
+
+func TestNullArgs(t *testing.T) {
+	name := parameters.NewStringParameter("name", "a name")
+	count := parameters.NewIntParameter("count", "a count")
+	price := parameters.NewDecimalParameter("price", "a price")
+	day := parameters.NewDateParameter("day", "a day")
+	ps := parameters.Parameters{name, count, price, day}
+	name.Nullable = true
+	count.Nullable = true
+	price.Nullable = true
+	day.Nullable = true
+
+	params, err := parameters.ParseParams(ps, map[string]any{"name": nil, "count": nil, "price": nil, "day": nil}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := sqltx.BigQueryArgs(ps, params.AsMap())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]any{
+		"name":  bigquery.NullString{},
+		"count": bigquery.NullInt64{},
+		"price": &bigquery.QueryParameterValue{
+			Type:  bigquery.StandardSQLDataType{TypeKind: "NUMERIC"},
+			Value: bigquery.NullString{},
+		},
+		"day": bigquery.NullDate{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect bigquery args (-want +got):\n%s", diff)
+	}
+
+	got, err = sqltx.SpannerArgs(params.AsMap(), "googlesql")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(spanner.NullInt64{}, got["count"]); diff != "" {
+		t.Fatalf("incorrect spanner null integer (-want +got):\n%s", diff)
+	}
+}
+
+func TestFailArgs(t *testing.T) {
+	if _, err := sqltx.PgxArgs([]any{parameters.Date("next tuesday")}); err == nil {
+		t.Fatalf("expected an error for an invalid date")
+	}
+	if _, err := sqltx.DBArgs([]any{parameters.DateTime("2025-03-14")}); err == nil {
+		t.Fatalf("expected an error for an invalid datetime")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltx
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// DryRunParameter is the name of the parameter that asks a SQL tool to
+// explain its statement instead of running it.
+const DryRunParameter = "dry_run"
+
+// WithDryRun appends the dry run parameter to params when enabled.
+func WithDryRun(params parameters.Parameters, enabled bool) parameters.Parameters {
+	if !enabled {
+		return params
+	}
+	return append(slices.Clone(params), parameters.NewBooleanParameterWithDefault(
+		DryRunParameter,
+		false,
+		"If set to true, the statement's query plan and estimated cost will be returned "+
+			"without running the statement. Defaults to false.",
+	))
+}
+
+// IsDryRun reports whether an invocation asked to explain the statement
+// instead of running it.
+func IsDryRun(params parameters.ParamValues) bool {
+	for _, p := range params {
+		if p.Name == DryRunParameter {
+			v, _ := p.Value.(bool)
+			return v
+		}
+	}
+	return false
+}
+
+// Plan is the result of explaining a statement instead of running it.
+// EstimatedCost is in the engine's own units, and is only set by engines
+// that report one.
+type Plan struct {
+	Plan          any      `json:"plan"`
+	EstimatedCost *float64 `json:"estimatedCost,omitempty"`
+}
+
+// FirstValue returns the first column of the first row of a RunSQL result.
+func FirstValue(res any) (any, error) {
+	rows, ok := res.([]any)
+	if !ok || len(rows) == 0 {
+		return nil, fmt.Errorf("no query plan returned")
+	}
+	switch row := rows[0].(type) {
+	case orderedmap.Row:
+		if len(row.Columns) > 0 {
+			return row.Columns[0].Value, nil
+		}
+	case map[string]any:
+		for _, v := range row {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("no query plan returned in row")
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltx_test
+
+import (
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
+)
+
+func TestWithDryRun(t *testing.T) {
+	params := parameters.Parameters{parameters.NewStringParameter("name", "")}
+	if got := sqltx.WithDryRun(params, false); len(got) != 1 {
+		t.Fatalf("expected parameters to be unchanged, got %d", len(got))
+	}
+	got := sqltx.WithDryRun(params, true)
+	if len(got) != 2 || got[1].GetName() != sqltx.DryRunParameter || len(params) != 1 {
+		t.Fatalf("expected dry run parameter to be appended to a copy, got %v", got)
+	}
+}
+
+func TestIsDryRun(t *testing.T) {
+	if sqltx.IsDryRun(parameters.ParamValues{{Name: "name", Value: "a"}}) {
+		t.Fatalf("expected no dry run without the parameter")
+	}
+	if sqltx.IsDryRun(parameters.ParamValues{{Name: sqltx.DryRunParameter, Value: false}}) {
+		t.Fatalf("expected no dry run when the parameter is false")
+	}
+	if !sqltx.IsDryRun(parameters.ParamValues{{Name: sqltx.DryRunParameter, Value: true}}) {
+		t.Fatalf("expected dry run when the parameter is true")
+	}
+}
+
+func TestFirstValue(t *testing.T) {
+	row := orderedmap.Row{}
+	row.Add("QUERY PLAN", "plan")
+	row.Add("other", "value")
+	got, err := sqltx.FirstValue([]any{row})
+	if err != nil || got != "plan" {
+		t.Fatalf("unexpected result: %v, %v", got, err)
+	}
+	got, err = sqltx.FirstValue([]any{map[string]any{"EXPLAIN": "plan"}})
+	if err != nil || got != "plan" {
+		t.Fatalf("unexpected result: %v, %v", got, err)
+	}
+	if _, err := sqltx.FirstValue([]any(nil)); err == nil {
+		t.Fatalf("expected error for empty result")
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqltx runs several SQL statements in a single transaction.
+package sqltx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IsolationLevel is the isolation level of a transaction. The empty value
+// uses the database default.
+type IsolationLevel string
+
+const (
+	ReadUncommitted IsolationLevel = "read-uncommitted"
+	ReadCommitted   IsolationLevel = "read-committed"
+	RepeatableRead  IsolationLevel = "repeatable-read"
+	Serializable    IsolationLevel = "serializable"
+)
+
+func (l *IsolationLevel) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var level string
+	if err := unmarshal(&level); err != nil {
+		return err
+	}
+	switch v := IsolationLevel(strings.ToLower(level)); v {
+	case "", ReadUncommitted, ReadCommitted, RepeatableRead, Serializable:
+		*l = v
+		return nil
+	default:
+		return fmt.Errorf(`isolationLevel invalid: must be one of "read-uncommitted", "read-committed", "repeatable-read", or "serializable"`)
+	}
+}
+
+// SQL returns the database/sql equivalent of the isolation level.
+func (l IsolationLevel) SQL() sql.IsolationLevel {
+	switch l {
+	case ReadUncommitted:
+		return sql.LevelReadUncommitted
+	case ReadCommitted:
+		return sql.LevelReadCommitted
+	case RepeatableRead:
+		return sql.LevelRepeatableRead
+	case Serializable:
+		return sql.LevelSerializable
+	default:
+		return sql.LevelDefault
+	}
+}
+
+// Pgx returns the pgx equivalent of the isolation level.
+func (l IsolationLevel) Pgx() pgx.TxIsoLevel {
+	switch l {
+	case ReadUncommitted:
+		return pgx.ReadUncommitted
+	case ReadCommitted:
+		return pgx.ReadCommitted
+	case RepeatableRead:
+		return pgx.RepeatableRead
+	case Serializable:
+		return pgx.Serializable
+	default:
+		return ""
+	}
+}
+
+// Statement is a statement and the arguments bound to it.
+type Statement struct {
+	SQL  string
+	Args []any
+	// NamedArgs is used instead of Args by sources that only accept named
+	// parameters, such as Spanner.
+	NamedArgs map[string]any
+	// ReturnsRows is set for DML that returns rows, e.g. with a RETURNING or
+	// OUTPUT clause. It's only needed by database/sql sources, which can't
+	// report the rows a statement affected when it's run as a query.
+	ReturnsRows bool
+}
+
+// Result is the outcome of a single statement. Rows is set for statements
+// that return rows, including DML with a RETURNING or OUTPUT clause.
+type Result struct {
+	Rows         []any  `json:"rows,omitempty"`
+	CommandTag   string `json:"commandTag,omitempty"`
+	RowsAffected int64  `json:"rowsAffected"`
+	LastInsertID *int64 `json:"lastInsertId,omitempty"`
+	// Columns are the names of the columns of Rows, which are known for
+	// statements that return rows even when they return none.
+	Columns []string `json:"-"`
+}
+
+var leadingComments = regexp.MustCompile(`^(\s|\(|--[^\n]*(\n|$)|/\*(?s:.*?)\*/)*`)
+
+// keyword returns the leading keyword of a statement, skipping whitespace,
+// parentheses, and comments.
+func keyword(statement string) string {
+	rest := statement[len(leadingComments.FindString(statement)):]
+	end := strings.IndexFunc(rest, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	if end >= 0 {
+		rest = rest[:end]
+	}
+	return strings.ToUpper(rest)
+}
+
+// IsDML reports whether a statement modifies rows, based on its leading
+// keyword.
+func IsDML(statement string) bool {
+	return isDMLKeyword(keyword(statement))
+}
+
+func isDMLKeyword(keyword string) bool {
+	switch keyword {
+	case "INSERT", "UPDATE", "DELETE", "MERGE", "REPLACE", "UPSERT":
+		return true
+	}
+	return false
+}
+
+// IsDML reports whether the result's command tag is that of a statement that
+// modifies rows.
+func (r Result) IsDML() bool {
+	return isDMLKeyword(keyword(r.CommandTag))
+}
+
+// StatementConfig is a statement of a transaction tool, along with the names
+// of the tool parameters bound to it, in order.
+type StatementConfig struct {
+	Statement   string   `yaml:"statement" validate:"required"`
+	Parameters  []string `yaml:"parameters"`
+	ReturnsRows bool     `yaml:"returnsRows"`
+}
+
+// CheckParameters verifies that every statement only binds declared
+// parameters.
+func CheckParameters(stmts []StatementConfig, params parameters.Parameters) error {
+	for i, stmt := range stmts {
+		if err := checkNames(stmt.Parameters, params); err != nil {
+			return fmt.Errorf("statement %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func checkNames(names []string, params parameters.Parameters) error {
+	for _, name := range names {
+		found := false
+		for _, p := range params {
+			if p.GetName() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("parameter %q is not declared", name)
+		}
+	}
+	return nil
+}
+
+// SelectParams returns the values of the named parameters, in order.
+func SelectParams(names []string, values parameters.ParamValues) parameters.ParamValues {
+	out := make(parameters.ParamValues, 0, len(names))
+	for _, name := range names {
+		for _, v := range values {
+			if v.Name == name {
+				out = append(out, v)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// RunDB runs the statements in a single database/sql transaction, rolling it
+// back if any statement fails. processRows converts the rows of statements
+// that return rows.
+func RunDB(ctx context.Context, db *sql.DB, level IsolationLevel, stmts []Statement, processRows func(*sql.Rows) ([]any, error)) ([]any, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: level.SQL()})
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	out := make([]any, 0, len(stmts))
+	for i, stmt := range stmts {
+		res, err := ExecDB(ctx, tx, stmt.SQL, stmt.Args, stmt.ReturnsRows, processRows)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d failed, transaction rolled back: %w", i+1, err)
+		}
+		out = append(out, res)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return out, nil
+}
+
+// DB is implemented by both *sql.DB and *sql.Tx.
+type DB interface {
+	QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	ExecContext(context.Context, string, ...any) (sql.Result, error)
+}
+
+// ExecDB runs a single statement and reports its rows, or else the number of
+// rows it affected. Statements other than DML are always run as queries, which
+// database/sql also accepts for statements without rows, while DML is only
+// run as a query if returnsRows is set.
+func ExecDB(ctx context.Context, db DB, statement string, args []any, returnsRows bool, processRows func(*sql.Rows) ([]any, error)) (Result, error) {
+	if returnsRows || !IsDML(statement) {
+		rows, err := db.QueryContext(ctx, statement, args...)
+		if err != nil {
+			return Result{}, err
+		}
+		defer rows.Close()
+		out, err := processRows(rows)
+		if err != nil {
+			return Result{}, err
+		}
+		return Result{Rows: out, RowsAffected: int64(len(out))}, nil
+	}
+
+	res, err := db.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return Result{}, err
+	}
+	out := Result{}
+	if n, err := res.RowsAffected(); err == nil {
+		out.RowsAffected = n
+	}
+	// only inserts generate keys, and some drivers report the last generated
+	// key for any statement
+	switch keyword(statement) {
+	case "INSERT", "REPLACE", "UPSERT":
+		if id, err := res.LastInsertId(); err == nil && id != 0 {
+			out.LastInsertID = &id
+		}
+	}
+	return out, nil
+}
+
+// RunPgx runs the statements in a single pgx transaction, rolling it back if
+// any statement fails.
+func RunPgx(ctx context.Context, pool *pgxpool.Pool, level IsolationLevel, stmts []Statement) ([]any, error) {
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: level.Pgx()})
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	out := make([]any, 0, len(stmts))
+	for i, stmt := range stmts {
+		res, err := QueryPgx(ctx, tx, stmt.SQL, stmt.Args)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d failed, transaction rolled back: %w", i+1, err)
+		}
+		out = append(out, res)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return out, nil
+}
+
+// QueryPgx runs a single statement and reports its rows, if any, along with
+// its command tag.
+func QueryPgx(ctx context.Context, q interface {
+	Query(context.Context, string, ...any) (pgx.Rows, error)
+}, statement string, args []any) (Result, error) {
+	args, err := PgxArgs(args)
+	if err != nil {
+		return Result{}, err
+	}
+	rows, err := q.Query(ctx, statement, args...)
+	if err != nil {
+		return Result{}, err
+	}
+	defer rows.Close()
+
+	fields := rows.FieldDescriptions()
+	var columns []string
+	for _, f := range fields {
+		columns = append(columns, f.Name)
+	}
+	var out []any
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return Result{}, fmt.Errorf("unable to parse row: %w", err)
+		}
+		row := orderedmap.Row{}
+		for i, f := range fields {
+			row.Add(f.Name, values[i])
+		}
+		out = append(out, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return Result{}, err
+	}
+	tag := rows.CommandTag()
+	return Result{Rows: out, Columns: columns, CommandTag: tag.String(), RowsAffected: tag.RowsAffected()}, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltx_test
+
+import (
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/util/sqltx"
+)
+
+func TestIsDML(t *testing.T) {
+	tcs := []struct {
+		statement string
+		want      bool
+	}{
+		{statement: "INSERT INTO t VALUES (1)", want: true},
+		{statement: "/* bump */ update t SET a = 1", want: true},
+		{statement: "DELETE FROM t RETURNING id", want: true},
+		{statement: "MERGE INTO t USING s ON t.id = s.id WHEN MATCHED THEN DELETE", want: true},
+		{statement: "SELECT * FROM inserts", want: false},
+		{statement: "CREATE TABLE t (id INT)", want: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.statement, func(t *testing.T) {
+			if got := sqltx.IsDML(tc.statement); got != tc.want {
+				t.Fatalf("IsDML(%q) = %v, want %v", tc.statement, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResultIsDML(t *testing.T) {
+	for tag, want := range map[string]bool{
+		"INSERT 0 1": true,
+		"UPDATE 3":   true,
+		"MERGE 2":    true,
+		"SELECT 5":   false,
+		"CREATE":     false,
+	} {
+		if got := (sqltx.Result{CommandTag: tag}).IsDML(); got != want {
+			t.Errorf("Result{CommandTag: %q}.IsDML() = %v, want %v", tag, got, want)
+		}
+	}
+}
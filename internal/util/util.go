@@ -189,4 +189,42 @@ func InstrumentationFromContext(ctx context.Context) (*telemetry.Instrumentation
 	return nil, fmt.Errorf("unable to retrieve instrumentation")
 }
 
+// verifiedAuthServicesKey is the key used to store the auth services verified
+// for a tool invocation within context
+const verifiedAuthServicesKey contextKey = "verifiedAuthServices"
+
+// WithVerifiedAuthServices adds the names of the auth services whose tokens
+// were verified for a tool invocation into the context as a value
+func WithVerifiedAuthServices(ctx context.Context, verifiedAuthServices []string) context.Context {
+	return context.WithValue(ctx, verifiedAuthServicesKey, verifiedAuthServices)
+}
+
+// VerifiedAuthServicesFromContext retrieves the verified auth services or
+// return an error
+func VerifiedAuthServicesFromContext(ctx context.Context) ([]string, error) {
+	if verified, ok := ctx.Value(verifiedAuthServicesKey).([]string); ok {
+		return verified, nil
+	}
+	return nil, fmt.Errorf("unable to retrieve verified auth services")
+}
+
+// claimsKey is the key used to store the claims of the tokens verified for a
+// tool invocation within context
+const claimsKey contextKey = "claims"
+
+// WithClaims adds the claims of the tokens verified for a tool invocation,
+// keyed by auth service name, into the context as a value
+func WithClaims(ctx context.Context, claims map[string]map[string]any) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
+// ClaimsFromContext retrieves the claims of the verified tokens or return an
+// error
+func ClaimsFromContext(ctx context.Context) (map[string]map[string]any, error) {
+	if claims, ok := ctx.Value(claimsKey).(map[string]map[string]any); ok {
+		return claims, nil
+	}
+	return nil, fmt.Errorf("unable to retrieve claims")
+}
+
 var ErrUnauthorized = errors.New("unauthorized")
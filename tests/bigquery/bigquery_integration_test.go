@@ -98,8 +98,9 @@ func TestBigQueryToolEndpoints(t *testing.T) {
 		datasetName,
 		strings.ReplaceAll(uuid.New().String(), "-", ""),
 	)
-	tableNameTemplateParam := fmt.Sprintf("`%s.%s.template_param_table_%s`",
-		BigqueryProject,
+	// template parameters take identifiers, which are quoted by the tools, and
+	// the source's project is the default
+	tableNameTemplateParam := fmt.Sprintf("%s.template_param_table_%s",
 		datasetName,
 		strings.ReplaceAll(uuid.New().String(), "-", ""),
 	)
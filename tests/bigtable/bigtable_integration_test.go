@@ -296,7 +296,7 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"description": "Create table tool with template parameters",
 		"statement":   "SELECT TO_INT64(cf['age']) as age, TO_INT64(cf['id']) as id, CAST(cf['name'] AS string) as name, FROM {{.tableName}};",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	toolsMap["select-templateParams-combined-tool"] = map[string]any{
@@ -306,7 +306,7 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"statement":   "SELECT TO_INT64(cf['age']) as age, TO_INT64(cf['id']) as id, CAST(cf['name'] AS string) as name, FROM {{.tableName}} WHERE TO_INT64(cf['id']) = @id;",
 		"parameters":  []parameters.Parameter{parameters.NewIntParameter("id", "the id of the user")},
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	toolsMap["select-fields-templateParams-tool"] = map[string]any{
@@ -315,8 +315,8 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"description": "Create table tool with template parameters",
 		"statement":   "SELECT {{array .fields}}, FROM {{.tableName}};",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewArrayParameter("fields", "The fields to select from", parameters.NewStringParameter("field", "A field that will be returned from the query.")),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			tests.UnsafeTemplateParam(parameters.NewArrayParameter("fields", "The fields to select from", parameters.NewStringParameter("field", "A field that will be returned from the query."))),
 		},
 	}
 	toolsMap["select-filter-templateParams-combined-tool"] = map[string]any{
@@ -326,8 +326,8 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"statement":   "SELECT TO_INT64(cf['age']) as age, TO_INT64(cf['id']) as id, CAST(cf['name'] AS string) as name, FROM {{.tableName}} WHERE {{.columnFilter}} = @name;",
 		"parameters":  []parameters.Parameter{parameters.NewStringParameter("name", "the name of the user")},
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewStringParameter("columnFilter", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			tests.UnsafeTemplateParam(parameters.NewStringParameter("columnFilter", "some description")),
 		},
 	}
 	config["tools"] = toolsMap
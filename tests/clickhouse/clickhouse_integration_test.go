@@ -191,8 +191,8 @@ func addClickHouseTemplateParamConfig(t *testing.T, config map[string]any, toolK
 		"description": "Create table tool with template parameters",
 		"statement":   "CREATE TABLE {{.tableName}} ({{array .columns}}) ORDER BY id",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewArrayParameter("columns", "The columns to create", parameters.NewStringParameter("column", "A column name that will be created")),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			tests.UnsafeTemplateParam(parameters.NewArrayParameter("columns", "The columns to create", parameters.NewStringParameter("column", "A column name that will be created"))),
 		},
 	}
 	toolsMap["insert-table-templateParams-tool"] = map[string]any{
@@ -201,9 +201,9 @@ func addClickHouseTemplateParamConfig(t *testing.T, config map[string]any, toolK
 		"description": "Insert table tool with template parameters",
 		"statement":   "INSERT INTO {{.tableName}} ({{array .columns}}) VALUES ({{.values}})",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewArrayParameter("columns", "The columns to insert into", parameters.NewStringParameter("column", "A column name that will be returned from the query.")),
-			parameters.NewStringParameter("values", "The values to insert as a comma separated string"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			parameters.NewIdentifierListParameter("columns", "The columns to insert into"),
+			tests.UnsafeTemplateParam(parameters.NewStringParameter("values", "The values to insert as a comma separated string")),
 		},
 	}
 	toolsMap["select-templateParams-tool"] = map[string]any{
@@ -212,7 +212,7 @@ func addClickHouseTemplateParamConfig(t *testing.T, config map[string]any, toolK
 		"description": "Select table tool with template parameters",
 		"statement":   "SELECT id AS \"id\", name AS \"name\", age AS \"age\" FROM {{.tableName}} ORDER BY id",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	toolsMap["select-templateParams-combined-tool"] = map[string]any{
@@ -224,7 +224,7 @@ func addClickHouseTemplateParamConfig(t *testing.T, config map[string]any, toolK
 			parameters.NewIntParameter("id", "the id of the user"),
 		},
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	toolsMap["select-fields-templateParams-tool"] = map[string]any{
@@ -233,7 +233,7 @@ func addClickHouseTemplateParamConfig(t *testing.T, config map[string]any, toolK
 		"description": "Select specific fields tool with template parameters",
 		"statement":   "SELECT name AS \"name\" FROM {{.tableName}} ORDER BY id",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	toolsMap["select-filter-templateParams-combined-tool"] = map[string]any{
@@ -245,8 +245,8 @@ func addClickHouseTemplateParamConfig(t *testing.T, config map[string]any, toolK
 			parameters.NewStringParameter("name", "the name to filter by"),
 		},
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewStringParameter("columnFilter", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("columnFilter", "some description"),
 		},
 	}
 	// Firebird uses simple DROP TABLE syntax without IF EXISTS
@@ -256,7 +256,7 @@ func addClickHouseTemplateParamConfig(t *testing.T, config map[string]any, toolK
 		"description": "Drop table tool with template parameters",
 		"statement":   "DROP TABLE {{.tableName}}",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	config["tools"] = toolsMap
@@ -157,7 +157,7 @@ func TestCloudSQLMySQLToolEndpoints(t *testing.T) {
 	tests.RunToolInvokeTest(t, select1Want, tests.DisableArrayTest())
 	tests.RunMCPToolCallMethod(t, mcpMyFailToolWant, mcpSelect1Want)
 	tests.RunExecuteSqlToolInvokeTest(t, createTableStatement, select1Want)
-	tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam)
+	tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam, tests.WithInsert1Want(`{"rowsAffected":1}`))
 
 	// Run specific MySQL tool tests
 	const expectedOwner = "'toolbox-identity'@'%'"
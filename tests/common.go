@@ -314,6 +314,19 @@ func AddPostgresPrebuiltConfig(t *testing.T, config map[string]any) map[string]a
 	return config
 }
 
+// UnsafeTemplateParam marks a string or array template parameter as unsafe,
+// so that the tests can substitute SQL fragments such as column definitions
+// and values. Names of tables and columns use identifier parameters instead.
+func UnsafeTemplateParam(p parameters.Parameter) parameters.Parameter {
+	switch p := p.(type) {
+	case *parameters.StringParameter:
+		p.Unsafe = true
+	case *parameters.ArrayParameter:
+		p.Unsafe = true
+	}
+	return p
+}
+
 func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplSelectCombined, tmplSelectFilterCombined string, tmplSelectAll string) map[string]any {
 	toolsMap, ok := config["tools"].(map[string]any)
 	if !ok {
@@ -331,8 +344,8 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"description": "Create table tool with template parameters",
 		"statement":   "CREATE TABLE {{.tableName}} ({{array .columns}})",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewArrayParameter("columns", "The columns to create", parameters.NewStringParameter("column", "A column name that will be created")),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			UnsafeTemplateParam(parameters.NewArrayParameter("columns", "The columns to create", parameters.NewStringParameter("column", "A column name that will be created"))),
 		},
 	}
 	toolsMap["insert-table-templateParams-tool"] = map[string]any{
@@ -341,9 +354,9 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"description": "Insert tool with template parameters",
 		"statement":   "INSERT INTO {{.tableName}} ({{array .columns}}) VALUES ({{.values}})",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewArrayParameter("columns", "The columns to insert into", parameters.NewStringParameter("column", "A column name that will be returned from the query.")),
-			parameters.NewStringParameter("values", "The values to insert as a comma separated string"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			parameters.NewIdentifierListParameter("columns", "The columns to insert into"),
+			UnsafeTemplateParam(parameters.NewStringParameter("values", "The values to insert as a comma separated string")),
 		},
 	}
 	toolsMap["select-templateParams-tool"] = map[string]any{
@@ -352,7 +365,7 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"description": "Create table tool with template parameters",
 		"statement":   selectAll,
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	toolsMap["select-templateParams-combined-tool"] = map[string]any{
@@ -362,7 +375,7 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"statement":   tmplSelectCombined,
 		"parameters":  []parameters.Parameter{parameters.NewIntParameter("id", "the id of the user")},
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	toolsMap["select-fields-templateParams-tool"] = map[string]any{
@@ -371,8 +384,8 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"description": "Create table tool with template parameters",
 		"statement":   "SELECT {{array .fields}} FROM {{.tableName}} ORDER BY id",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewArrayParameter("fields", "The fields to select from", parameters.NewStringParameter("field", "A field that will be returned from the query.")),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			parameters.NewIdentifierListParameter("fields", "The fields to select from"),
 		},
 	}
 	toolsMap["select-filter-templateParams-combined-tool"] = map[string]any{
@@ -382,8 +395,8 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"statement":   tmplSelectFilterCombined,
 		"parameters":  []parameters.Parameter{parameters.NewStringParameter("name", "the name of the user")},
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewStringParameter("columnFilter", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("columnFilter", "some description"),
 		},
 	}
 	toolsMap["drop-table-templateParams-tool"] = map[string]any{
@@ -392,7 +405,7 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"description": "Drop table tool with template parameters",
 		"statement":   "DROP TABLE IF EXISTS {{.tableName}}",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	config["tools"] = toolsMap
@@ -362,7 +362,7 @@ func runQueryParamInvokeTest(t *testing.T) {
 			name:        "invoke query-param-tool (required param nil)",
 			api:         "http://127.0.0.1:5000/api/tool/my-query-param-tool/invoke",
 			requestBody: bytes.NewBuffer([]byte(`{"reqId": null, "page": "1"}`)),
-			want:        `"page=1\u0026reqId="`, // reqId becomes "",
+			isErr:       true, // null is the same as leaving out the required reqId
 		},
 	}
 	for _, tc := range invokeTcs {
@@ -380,6 +380,12 @@ func runQueryParamInvokeTest(t *testing.T) {
 			}
 			defer resp.Body.Close()
 
+			if tc.isErr {
+				if resp.StatusCode == http.StatusOK {
+					t.Fatalf("expected an error, got status code 200")
+				}
+				return
+			}
 			if resp.StatusCode != http.StatusOK {
 				bodyBytes, _ := io.ReadAll(resp.Body)
 				t.Fatalf("response status code is not 200, got %d: %s", resp.StatusCode, string(bodyBytes))
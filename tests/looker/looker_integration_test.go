@@ -428,6 +428,7 @@ func TestLooker(t *testing.T) {
 					map[string]any{
 						"additionalProperties": true,
 						"authSources":          []any{},
+						"default":              map[string]any{},
 						"description":          "The filters for the query",
 						"name":                 "filters",
 						"required":             false,
@@ -435,6 +436,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     []any{},
 						"description": "The query pivots (must be included in fields as well).",
 						"items": map[string]any{
 							"authSources": []any{},
@@ -449,6 +451,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     []any{},
 						"description": "The sorts like \"field.id desc 0\".",
 						"items": map[string]any{
 							"authSources": []any{},
@@ -463,6 +466,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(500),
 						"description": "The row limit.",
 						"name":        "limit",
 						"required":    false,
@@ -516,6 +520,7 @@ func TestLooker(t *testing.T) {
 					map[string]any{
 						"additionalProperties": true,
 						"authSources":          []any{},
+						"default":              map[string]any{},
 						"description":          "The filters for the query",
 						"name":                 "filters",
 						"required":             false,
@@ -523,6 +528,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     []any{},
 						"description": "The query pivots (must be included in fields as well).",
 						"items": map[string]any{
 							"authSources": []any{},
@@ -537,6 +543,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     []any{},
 						"description": "The sorts like \"field.id desc 0\".",
 						"items": map[string]any{
 							"authSources": []any{},
@@ -551,6 +558,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(500),
 						"description": "The row limit.",
 						"name":        "limit",
 						"required":    false,
@@ -604,6 +612,7 @@ func TestLooker(t *testing.T) {
 					map[string]any{
 						"additionalProperties": true,
 						"authSources":          []any{},
+						"default":              map[string]any{},
 						"description":          "The filters for the query",
 						"name":                 "filters",
 						"required":             false,
@@ -611,6 +620,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     []any{},
 						"description": "The query pivots (must be included in fields as well).",
 						"items": map[string]any{
 							"authSources": []any{},
@@ -625,6 +635,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     []any{},
 						"description": "The sorts like \"field.id desc 0\".",
 						"items": map[string]any{
 							"authSources": []any{},
@@ -639,6 +650,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(500),
 						"description": "The row limit.",
 						"name":        "limit",
 						"required":    false,
@@ -654,6 +666,7 @@ func TestLooker(t *testing.T) {
 					map[string]any{
 						"additionalProperties": true,
 						"authSources":          []any{},
+						"default":              map[string]any{},
 						"description":          "The visualization config for the query",
 						"name":                 "vis_config",
 						"required":             false,
@@ -671,6 +684,7 @@ func TestLooker(t *testing.T) {
 				"parameters": []any{
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The title of the look.",
 						"name":        "title",
 						"required":    false,
@@ -678,6 +692,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The description of the look.",
 						"name":        "desc",
 						"required":    false,
@@ -685,6 +700,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(100),
 						"description": "The number of looks to fetch. Default 100",
 						"name":        "limit",
 						"required":    false,
@@ -692,6 +708,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(0),
 						"description": "The number of looks to skip before fetching. Default 0",
 						"name":        "offset",
 						"required":    false,
@@ -738,6 +755,7 @@ func TestLooker(t *testing.T) {
 					map[string]any{
 						"additionalProperties": true,
 						"authSources":          []any{},
+						"default":              map[string]any{},
 						"description":          "The filters for the query",
 						"name":                 "filters",
 						"required":             false,
@@ -745,6 +763,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     []any{},
 						"description": "The query pivots (must be included in fields as well).",
 						"items": map[string]any{
 							"authSources": []any{},
@@ -759,6 +778,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     []any{},
 						"description": "The sorts like \"field.id desc 0\".",
 						"items": map[string]any{
 							"authSources": []any{},
@@ -773,6 +793,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(500),
 						"description": "The row limit.",
 						"name":        "limit",
 						"required":    false,
@@ -794,6 +815,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The description of the Look",
 						"name":        "description",
 						"required":    false,
@@ -802,6 +824,7 @@ func TestLooker(t *testing.T) {
 					map[string]any{
 						"additionalProperties": true,
 						"authSources":          []any{},
+						"default":              map[string]any{},
 						"description":          "The visualization config for the query",
 						"name":                 "vis_config",
 						"required":             false,
@@ -819,6 +842,7 @@ func TestLooker(t *testing.T) {
 				"parameters": []any{
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The title of the dashboard.",
 						"name":        "title",
 						"required":    false,
@@ -826,6 +850,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The description of the dashboard.",
 						"name":        "desc",
 						"required":    false,
@@ -833,6 +858,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(100),
 						"description": "The number of dashboards to fetch. Default 100",
 						"name":        "limit",
 						"required":    false,
@@ -840,6 +866,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(0),
 						"description": "The number of dashboards to skip before fetching. Default 0",
 						"name":        "offset",
 						"required":    false,
@@ -864,6 +891,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The description of the Dashboard",
 						"name":        "description",
 						"required":    false,
@@ -902,6 +930,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     "field_filter",
 						"description": "The filter_type of the Dashboard Filter: date_filter, number_filter, string_filter, field_filter (default field_filter)",
 						"name":        "filter_type",
 						"required":    false,
@@ -937,6 +966,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     true,
 						"description": "The Dashboard Filter should allow multiple values (default true)",
 						"name":        "allow_multiple_values",
 						"required":    false,
@@ -944,6 +974,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     false,
 						"description": "The Dashboard Filter is required to run dashboard (default false)",
 						"name":        "required",
 						"required":    false,
@@ -990,6 +1021,7 @@ func TestLooker(t *testing.T) {
 					map[string]any{
 						"additionalProperties": true,
 						"authSources":          []any{},
+						"default":              map[string]any{},
 						"description":          "The filters for the query",
 						"name":                 "filters",
 						"required":             false,
@@ -997,6 +1029,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     []any{},
 						"description": "The query pivots (must be included in fields as well).",
 						"items": map[string]any{
 							"authSources": []any{},
@@ -1011,6 +1044,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     []any{},
 						"description": "The sorts like \"field.id desc 0\".",
 						"items": map[string]any{
 							"authSources": []any{},
@@ -1025,6 +1059,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(500),
 						"description": "The row limit.",
 						"name":        "limit",
 						"required":    false,
@@ -1046,6 +1081,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The title of the Dashboard Element",
 						"name":        "title",
 						"required":    false,
@@ -1054,6 +1090,7 @@ func TestLooker(t *testing.T) {
 					map[string]any{
 						"additionalProperties": true,
 						"authSources":          []any{},
+						"default":              map[string]any{},
 						"description":          "The visualization config for the query",
 						"name":                 "vis_config",
 						"required":             false,
@@ -1065,6 +1102,7 @@ func TestLooker(t *testing.T) {
 						"items": map[string]any{
 							"additionalProperties": true,
 							"authSources":          []any{},
+							"default":              map[string]any{},
 							"description":          `A dashboard filter like {"dashboard_filter_name": "name", "field": "view_name.field_name"}`,
 							"name":                 "dashboard_filter",
 							"required":             false,
@@ -1163,6 +1201,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(90),
 						"description": "The timeframe in days to analyze.",
 						"name":        "timeframe",
 						"required":    false,
@@ -1170,6 +1209,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(0),
 						"description": "The minimum number of queries for a model or explore to be considered used.",
 						"name":        "min_queries",
 						"required":    false,
@@ -1194,6 +1234,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The Looker project to vacuum (optional).",
 						"name":        "project",
 						"required":    false,
@@ -1201,6 +1242,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The Looker model to vacuum (optional).",
 						"name":        "model",
 						"required":    false,
@@ -1208,6 +1250,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The Looker explore to vacuum (optional).",
 						"name":        "explore",
 						"required":    false,
@@ -1215,6 +1258,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(90),
 						"description": "The timeframe in days to analyze.",
 						"name":        "timeframe",
 						"required":    false,
@@ -1222,6 +1266,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     float64(1),
 						"description": "The minimum number of queries for a model or explore to be considered used.",
 						"name":        "min_queries",
 						"required":    false,
@@ -1239,6 +1284,7 @@ func TestLooker(t *testing.T) {
 				"parameters": []any{
 					map[string]any{
 						"authSources": []any{},
+						"default":     true,
 						"description": "Whether to set Dev Mode.",
 						"name":        "devMode",
 						"required":    false,
@@ -1392,6 +1438,7 @@ func TestLooker(t *testing.T) {
 				"parameters": []any{
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "Type of Looker content to embed (ie. dashboards, looks, query-visualization)",
 						"name":        "type",
 						"required":    false,
@@ -1399,6 +1446,7 @@ func TestLooker(t *testing.T) {
 					},
 					map[string]any{
 						"authSources": []any{},
+						"default":     "",
 						"description": "The ID of the content to embed.",
 						"name":        "id",
 						"required":    false,
@@ -164,6 +164,7 @@ func TestNeo4jToolEndpoints(t *testing.T) {
 							"name":        "dry_run",
 							"type":        "boolean",
 							"required":    false,
+							"default":     false,
 							"description": "If set to true, the query will be validated and information about the execution will be returned without running the query. Defaults to false.",
 							"authSources": []any{},
 						},
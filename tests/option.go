@@ -183,6 +183,7 @@ type TemplateParameterTestConfig struct {
 	selectNameWant  string
 	selectEmptyWant string
 	insert1Want     string
+	insert2Want     string
 
 	nameFieldArray string
 	nameColFilter  string
@@ -243,6 +244,15 @@ func WithInsert1Want(s string) TemplateParamOption {
 	}
 }
 
+// WithInsert2Want represents the response value of the second insert-table-templateParams-tool
+// invocation. It defaults to the insert1Want value.
+// e.g. tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam, tests.WithInsert2Want("custom"))
+func WithInsert2Want(s string) TemplateParamOption {
+	return func(c *TemplateParameterTestConfig) {
+		c.insert2Want = s
+	}
+}
+
 // WithNameFieldArray represents fields array parameter for select-fields-templateParams-tool.
 // e.g. tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam, tests.WithNameFieldArray("custom"))
 func WithNameFieldArray(s string) TemplateParamOption {
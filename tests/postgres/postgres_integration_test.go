@@ -16,7 +16,10 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"regexp"
@@ -140,7 +143,7 @@ func TestPostgres(t *testing.T) {
 	tests.RunToolInvokeTest(t, select1Want)
 	tests.RunMCPToolCallMethod(t, mcpMyFailToolWant, mcpSelect1Want)
 	tests.RunExecuteSqlToolInvokeTest(t, createTableStatement, select1Want)
-	tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam)
+	tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam, tests.WithInsert1Want(`{"commandTag":"INSERT 0 1","rowsAffected":1}`))
 
 	// Run Postgres prebuilt tool tests
 	tests.RunPostgresListTablesTest(t, tableNameParam, tableNameAuth, PostgresUser)
@@ -165,3 +168,151 @@ func TestPostgres(t *testing.T) {
 	tests.RunPostgresListDatabaseStatsTest(t, ctx, pool)
 	tests.RunPostgresListRolesTest(t, ctx, pool)
 }
+
+// TestPostgresVectorSearch checks that embedded parameters can be compared
+// with pgvector columns.
+func TestPostgresVectorSearch(t *testing.T) {
+	sourceConfig := getPostgresVars(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pool, err := initPostgresConnectionPool(PostgresHost, PostgresPort, PostgresUser, PostgresPass, PostgresDatabase)
+	if err != nil {
+		t.Fatalf("unable to create postgres connection pool: %s", err)
+	}
+	if _, err := pool.Exec(ctx, "CREATE EXTENSION IF NOT EXISTS vector"); err != nil {
+		t.Skipf("pgvector is not available: %s", err)
+	}
+
+	tableName := "vector_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	createStmt := fmt.Sprintf("CREATE TABLE %s (id SERIAL PRIMARY KEY, name TEXT, embedding vector(3));", tableName)
+	insertStmt := fmt.Sprintf("INSERT INTO %s (name, embedding) VALUES ($1, $2::vector), ($3, $4::vector);", tableName)
+	teardownTable := tests.SetupPostgresSQLTable(t, ctx, pool, createStmt, insertStmt, tableName, []any{"apple", "[1,0,0]", "banana", "[0,1,0]"})
+	defer teardownTable(t)
+
+	// an OpenAI-compatible embedding model with fixed vectors
+	embeddings := map[string][]float64{"red fruit": {0.9, 0.1, 0}, "yellow fruit": {0.1, 0.9, 0}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data := make([]map[string]any, len(req.Input))
+		for i, text := range req.Input {
+			data[i] = map[string]any{"index": i, "embedding": embeddings[text]}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	toolsFile := map[string]any{
+		"sources": map[string]any{
+			"my-instance": sourceConfig,
+		},
+		"embeddingModels": map[string]any{
+			"my-embeddings": map[string]any{
+				"kind":     "openai",
+				"model":    "test-embedding",
+				"endpoint": srv.URL,
+			},
+		},
+		"tools": map[string]any{
+			"search-vector": map[string]any{
+				"kind":        PostgresToolKind,
+				"source":      "my-instance",
+				"description": "Search by similarity.",
+				"statement":   fmt.Sprintf("SELECT name FROM %s ORDER BY embedding <=> $1::vector LIMIT 1;", tableName),
+				"parameters": []map[string]any{
+					{
+						"name":        "query",
+						"type":        "string",
+						"description": "what to search for",
+						"embeddedBy":  "my-embeddings",
+					},
+				},
+			},
+		},
+	}
+	cmd, cleanup, err := tests.StartCmd(ctx, toolsFile)
+	if err != nil {
+		t.Fatalf("command initialization returned an error: %s", err)
+	}
+	defer cleanup()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := testutils.WaitForString(waitCtx, regexp.MustCompile(`Server ready to serve`), cmd.Out)
+	if err != nil {
+		t.Logf("toolbox command logs: \n%s", out)
+		t.Fatalf("toolbox didn't start successfully: %s", err)
+	}
+
+	tests.RunToolInvokeParametersTest(t, "search-vector", []byte(`{"query": "red fruit"}`), `[{"name":"apple"}]`)
+	tests.RunToolInvokeParametersTest(t, "search-vector", []byte(`{"query": "yellow fruit"}`), `[{"name":"banana"}]`)
+}
+
+func TestPostgresDMLResults(t *testing.T) {
+	sourceConfig := getPostgresVars(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pool, err := initPostgresConnectionPool(PostgresHost, PostgresPort, PostgresUser, PostgresPass, PostgresDatabase)
+	if err != nil {
+		t.Fatalf("unable to create postgres connection pool: %s", err)
+	}
+
+	tableName := "dml_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	createStmt := fmt.Sprintf("CREATE TABLE %s (id SERIAL PRIMARY KEY, name TEXT);", tableName)
+	insertStmt := fmt.Sprintf("INSERT INTO %s (name) VALUES ($1);", tableName)
+	teardownTable := tests.SetupPostgresSQLTable(t, ctx, pool, createStmt, insertStmt, tableName, []any{"apple"})
+	defer teardownTable(t)
+
+	nameParam := []map[string]any{
+		{
+			"name":        "name",
+			"type":        "string",
+			"description": "name of the fruit",
+		},
+	}
+	toolsFile := map[string]any{
+		"sources": map[string]any{
+			"my-instance": sourceConfig,
+		},
+		"tools": map[string]any{
+			"insert-returning": map[string]any{
+				"kind":        PostgresToolKind,
+				"source":      "my-instance",
+				"description": "Insert a fruit and return its name.",
+				"statement":   fmt.Sprintf("INSERT INTO %s (name) VALUES ($1) RETURNING name;", tableName),
+				"parameters":  nameParam,
+			},
+			"insert": map[string]any{
+				"kind":        PostgresToolKind,
+				"source":      "my-instance",
+				"description": "Insert a fruit.",
+				"statement":   fmt.Sprintf("INSERT INTO %s (name) VALUES ($1);", tableName),
+				"parameters":  nameParam,
+			},
+		},
+	}
+	cmd, cleanup, err := tests.StartCmd(ctx, toolsFile)
+	if err != nil {
+		t.Fatalf("command initialization returned an error: %s", err)
+	}
+	defer cleanup()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := testutils.WaitForString(waitCtx, regexp.MustCompile(`Server ready to serve`), cmd.Out)
+	if err != nil {
+		t.Logf("toolbox command logs: \n%s", out)
+		t.Fatalf("toolbox didn't start successfully: %s", err)
+	}
+
+	// DML with a RETURNING clause returns its rows like a query
+	tests.RunToolInvokeParametersTest(t, "insert-returning", []byte(`{"name": "banana"}`), `[{"name":"banana"}]`)
+	tests.RunToolInvokeParametersTest(t, "insert", []byte(`{"name": "cherry"}`), `{"commandTag":"INSERT 0 1","rowsAffected":1}`)
+}
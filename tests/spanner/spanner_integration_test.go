@@ -207,6 +207,7 @@ func TestSpannerToolEndpoints(t *testing.T) {
 		t, tableNameTemplateParam,
 		tests.WithSelectAllWant(tmplSelectAllWwant),
 		tests.WithTmplSelectId1Want(tmplSelectId1Want),
+		tests.WithInsert1Want(`{"rowsAffected":1}`),
 		tests.DisableDdlTest(),
 	)
 	runSpannerSchemaToolInvokeTest(t, accessSchemaWant)
@@ -422,9 +423,9 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"description": "Insert tool with template parameters",
 		"statement":   "INSERT INTO {{.tableName}} ({{array .columns}}) VALUES ({{.values}})",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewArrayParameter("columns", "The columns to insert into", parameters.NewStringParameter("column", "A column name that will be returned from the query.")),
-			parameters.NewStringParameter("values", "The values to insert as a comma separated string"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			parameters.NewIdentifierListParameter("columns", "The columns to insert into"),
+			tests.UnsafeTemplateParam(parameters.NewStringParameter("values", "The values to insert as a comma separated string")),
 		},
 	}
 	toolsMap["select-templateParams-tool"] = map[string]any{
@@ -433,7 +434,7 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"description": "Create table tool with template parameters",
 		"statement":   "SELECT * FROM {{.tableName}}",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	toolsMap["select-templateParams-combined-tool"] = map[string]any{
@@ -443,7 +444,7 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"statement":   "SELECT * FROM {{.tableName}} WHERE id = @id",
 		"parameters":  []parameters.Parameter{parameters.NewIntParameter("id", "the id of the user")},
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
 		},
 	}
 	toolsMap["select-fields-templateParams-tool"] = map[string]any{
@@ -452,8 +453,8 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"description": "Create table tool with template parameters",
 		"statement":   "SELECT {{array .fields}} FROM {{.tableName}}",
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewArrayParameter("fields", "The fields to select from", parameters.NewStringParameter("field", "A field that will be returned from the query.")),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			parameters.NewIdentifierListParameter("fields", "The fields to select from"),
 		},
 	}
 	toolsMap["select-filter-templateParams-combined-tool"] = map[string]any{
@@ -463,8 +464,8 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"statement":   "SELECT * FROM {{.tableName}} WHERE {{.columnFilter}} = @name",
 		"parameters":  []parameters.Parameter{parameters.NewStringParameter("name", "the name of the user")},
 		"templateParameters": []parameters.Parameter{
-			parameters.NewStringParameter("tableName", "some description"),
-			parameters.NewStringParameter("columnFilter", "some description"),
+			parameters.NewIdentifierParameter("tableName", "some description"),
+			parameters.NewIdentifierParameter("columnFilter", "some description"),
 		},
 	}
 	config["tools"] = toolsMap
@@ -558,7 +559,7 @@ func runSpannerExecuteSqlToolInvokeTest(t *testing.T, select1Want, invokeParamWa
 			api:           "http://127.0.0.1:5000/api/tool/my-exec-sql-tool/invoke",
 			requestHeader: map[string]string{},
 			requestBody:   bytes.NewBuffer([]byte(fmt.Sprintf("{\"sql\":\"INSERT INTO %s (id, name) VALUES (5, 'test_name')\"}", tableNameParam))),
-			want:          "null",
+			want:          `{"rowsAffected":1}`,
 			isErr:         false,
 		},
 		{
@@ -164,7 +164,10 @@ func TestSQLiteToolEndpoint(t *testing.T) {
 	tests.RunToolGetTest(t)
 	tests.RunToolInvokeTest(t, select1Want, tests.DisableArrayTest())
 	tests.RunMCPToolCallMethod(t, mcpMyFailToolWant, mcpSelect1Want)
-	tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam)
+	tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam,
+		tests.WithInsert1Want(`{"rowsAffected":1,"lastInsertId":1}`),
+		tests.WithInsert2Want(`{"rowsAffected":1,"lastInsertId":2}`),
+	)
 }
 
 func TestSQLiteExecuteSqlTool(t *testing.T) {
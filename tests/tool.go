@@ -474,6 +474,9 @@ func RunToolInvokeWithTemplateParameters(t *testing.T, tableName string, options
 	for _, option := range options {
 		option(configs)
 	}
+	if configs.insert2Want == "" {
+		configs.insert2Want = configs.insert1Want
+	}
 
 	selectOnlyNamesWant := "[{\"name\":\"Alex\"},{\"name\":\"Alice\"}]"
 
@@ -513,7 +516,7 @@ func RunToolInvokeWithTemplateParameters(t *testing.T, tableName string, options
 			api:           "http://127.0.0.1:5000/api/tool/insert-table-templateParams-tool/invoke",
 			requestHeader: map[string]string{},
 			requestBody:   bytes.NewBuffer([]byte(fmt.Sprintf(`{"tableName": "%s", "columns":["id","name","age"], "values":"2, 'Alice', 100"}`, tableName))),
-			want:          configs.insert1Want,
+			want:          configs.insert2Want,
 			isErr:         false,
 		},
 		{